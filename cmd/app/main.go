@@ -39,7 +39,7 @@ func run() error {
 	}
 
 	// create http server with all handlers & services & repositories
-	srv, err := http_server.NewHttpServer(logger, cfg.Postgres, cfg.Redis, cfg.HttpAddr)
+	srv, closeServer, err := http_server.NewHttpServer(logger, cfg)
 	if err != nil {
 		logger.Error("create http server", zap.Error(err))
 		return err
@@ -56,10 +56,16 @@ func run() error {
 		if err = srv.Shutdown(ctx); err != nil {
 			logger.Error("HTTP Server Shutdown", zap.Error(err))
 		}
+		// mails answered already are let out before the stores close
+		ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err = closeServer(ctx); err != nil {
+			logger.Error("HTTP Server Close", zap.Error(err))
+		}
 		close(stopped)
 	}()
 
-	logger.Info("Starting HTTP server", zap.String("addr", cfg.HttpAddr))
+	logger.Info("Starting HTTP server", zap.String("addr", cfg.Http.Addr))
 
 	// start HTTP server
 	if err = srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
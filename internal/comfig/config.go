@@ -4,15 +4,92 @@ import (
 	"fmt"
 	"github.com/spf13/viper"
 	"log"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	Postgres PostgresConfig
 	Redis    RedisConfig
+	Http     HttpConfig
+	Auth     AuthConfig
+	Mail     MailConfig
 	Env      string
-	HttpAddr string
+}
+
+type AuthConfig struct {
+	// PasswordHasher hashes new passwords, argon2id or bcrypt.
+	PasswordHasher string
+	// PasswordMinLength and PasswordMinClasses make the password policy,
+	// 8 characters mixing 2 of letter cases, digits and symbols by default.
+	PasswordMinLength  int
+	PasswordMinClasses int
+	// RequireVerifiedMail keeps users out until they follow the link sent
+	// to their mail.
+	RequireVerifiedMail bool
+	// LoginMaxAttempts failed sign ins of an account or LoginMaxIPAttempts
+	// from an address within LoginWindow lock sign in for LoginLockout.
+	LoginMaxAttempts   int
+	LoginMaxIPAttempts int
+	LoginWindow        time.Duration
+	LoginLockout       time.Duration
+	JWT                JWTConfig
+}
+
+// JWTConfig sets up the bearer access tokens and the refresh tokens that
+// renew them.
+type JWTConfig struct {
+	Issuer string
+	// AccessTTL is how long an access token works unless its session is
+	// revoked earlier. RefreshTTL is how long a refresh token works.
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	// SigningKey is the id of the key new access tokens are signed with,
+	// the other keys only verify tokens signed before a rotation.
+	SigningKey string
+	Keys       []JWTKeyConfig
+}
+
+// JWTKeyConfig is a key identified by the kid header of access tokens,
+// an HS256 secret or an Ed25519 key pair in PEM. An Ed25519 key with the
+// public key alone only verifies tokens. The config file names the key, the
+// key itself is read from the JWT_KEY_<ID>_SECRET, JWT_KEY_<ID>_PRIVATE_KEY
+// and JWT_KEY_<ID>_PUBLIC_KEY environment variables or from the files of
+// secret_file, private_key_file and public_key_file.
+type JWTKeyConfig struct {
+	ID             string `mapstructure:"id"`
+	Algorithm      string `mapstructure:"algorithm"`
+	SecretFile     string `mapstructure:"secret_file"`
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	PublicKeyFile  string `mapstructure:"public_key_file"`
+	Secret         string `mapstructure:"-"`
+	PrivateKey     string `mapstructure:"-"`
+	PublicKey      string `mapstructure:"-"`
+}
+
+type MailConfig struct {
+	// Driver sends the mails, smtp or log, which only writes them to the log.
+	Driver   string
+	From     string
+	Host     string
+	Port     string
+	Username string
+	Password string
+}
+
+type HttpConfig struct {
+	Addr string
+	// LegacyStatusCodes makes responses go out with 200 OK, the real status
+	// is only reported inside the JSON envelope. Clients override it per
+	// request with the X-Status-Codes header, legacy or http.
+	LegacyStatusCodes bool
+	// TrustedProxies are the addresses, single or CIDR, of the proxies in
+	// front of the server. Only their X-Real-IP and X-Forwarded-For headers
+	// are believed, the other clients are known by their own address.
+	TrustedProxies []netip.Prefix
 }
 
 type RedisConfig struct {
@@ -42,6 +119,19 @@ func MustLoad() *Config {
 
 func newConfig() *Config {
 	redisDb, _ := strconv.Atoi(viper.GetString("redis.db"))
+	var jwtKeys []JWTKeyConfig
+	if err := viper.UnmarshalKey("auth.jwt.keys", &jwtKeys); err != nil {
+		log.Fatalf("Ошибка при загрузке ключей JWT: %s", err)
+	}
+	for i := range jwtKeys {
+		if err := jwtKeys[i].loadMaterial(); err != nil {
+			log.Fatalf("Ошибка при загрузке ключей JWT: %s", err)
+		}
+	}
+	trustedProxies, err := parsePrefixes(viper.GetStringSlice("server.trusted_proxies"))
+	if err != nil {
+		log.Fatalf("Ошибка при загрузке доверенных прокси: %s", err)
+	}
 	return &Config{
 		Postgres: PostgresConfig{
 			Host:     viper.GetString("db.host"),
@@ -56,7 +146,103 @@ func newConfig() *Config {
 			Password: "",
 			DB:       redisDb,
 		},
-		Env:      viper.GetString("env"),
-		HttpAddr: fmt.Sprintf("%s:%s", viper.GetString("server.host"), viper.GetString("server.port")),
+		Http: HttpConfig{
+			Addr:              fmt.Sprintf("%s:%s", viper.GetString("server.host"), viper.GetString("server.port")),
+			LegacyStatusCodes: viper.GetBool("server.legacy_status_codes"),
+			TrustedProxies:    trustedProxies,
+		},
+		Auth: AuthConfig{
+			PasswordHasher:      viper.GetString("auth.password_hasher"),
+			PasswordMinLength:   viper.GetInt("auth.password_min_length"),
+			PasswordMinClasses:  viper.GetInt("auth.password_min_classes"),
+			RequireVerifiedMail: viper.GetBool("auth.require_verified_mail"),
+			LoginMaxAttempts:    viper.GetInt("auth.login_max_attempts"),
+			LoginMaxIPAttempts:  viper.GetInt("auth.login_max_ip_attempts"),
+			LoginWindow:         viper.GetDuration("auth.login_window"),
+			LoginLockout:        viper.GetDuration("auth.login_lockout"),
+			JWT: JWTConfig{
+				Issuer:     viper.GetString("auth.jwt.issuer"),
+				AccessTTL:  viper.GetDuration("auth.jwt.access_ttl"),
+				RefreshTTL: viper.GetDuration("auth.jwt.refresh_ttl"),
+				SigningKey: viper.GetString("auth.jwt.signing_key"),
+				Keys:       jwtKeys,
+			},
+		},
+		Mail: MailConfig{
+			Driver:   viper.GetString("mail.driver"),
+			From:     viper.GetString("mail.from"),
+			Host:     viper.GetString("mail.host"),
+			Port:     viper.GetString("mail.port"),
+			Username: viper.GetString("mail.username"),
+			Password: viper.GetString("mail.password"),
+		},
+		Env: viper.GetString("env"),
+	}
+}
+
+// parsePrefixes reads addresses written either alone or in CIDR notation.
+func parsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if addr, err := netip.ParseAddr(value); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("address %q: %w", value, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// loadMaterial reads the key from the environment, falling back to the key
+// files. A key with nothing to sign or verify with is an error, so the server
+// does not start without its signing key.
+func (k *JWTKeyConfig) loadMaterial() error {
+	prefix := "JWT_KEY_" + envName(k.ID) + "_"
+	var err error
+	if k.Secret, err = secretValue(prefix+"SECRET", k.SecretFile); err != nil {
+		return err
+	}
+	if k.PrivateKey, err = secretValue(prefix+"PRIVATE_KEY", k.PrivateKeyFile); err != nil {
+		return err
+	}
+	if k.PublicKey, err = secretValue(prefix+"PUBLIC_KEY", k.PublicKeyFile); err != nil {
+		return err
 	}
+	if k.Secret == "" && k.PrivateKey == "" && k.PublicKey == "" {
+		return fmt.Errorf("key %q is not set, set %sSECRET, %sPRIVATE_KEY or a key file", k.ID, prefix, prefix)
+	}
+	return nil
+}
+
+// secretValue returns the environment variable or, when it is not set, the
+// content of the file.
+func secretValue(env, file string) (string, error) {
+	if value := os.Getenv(env); value != "" {
+		return value, nil
+	}
+	if file == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// envName makes an environment variable name of the key id, dev-1 is DEV_1.
+func envName(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, id)
 }
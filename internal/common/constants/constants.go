@@ -5,9 +5,18 @@ import "time"
 type ctxKey string
 
 const (
-	KeyRequestInfo ctxKey = "request_info"
-	CookieExpire          = 30 * 24 * time.Hour
-	Host                  = "http://localhost:8000"
-	UserRole              = 0
-	AdminRole             = 1
+	KeyRequestInfo    ctxKey = "request_info"
+	KeySession        ctxKey = "session"
+	CookieExpire             = 30 * 24 * time.Hour
+	VerifyTokenExpire        = 24 * time.Hour
+	ResetTokenExpire         = time.Hour
+	Host                     = "http://localhost:8000"
+	UserRole                 = 0
+	AdminRole                = 1
+	EditorRole               = 2
+	ModeratorRole            = 3
+	DefaultPageLimit         = 20
+	MaxPageLimit             = 100
+	DefaultSimilarity        = 0.3
+	SuggestionsLimit         = 5
 )
@@ -11,6 +11,7 @@ type Actor struct {
 	gender    string
 	birthDate time.Time
 	films     []*Film
+	roles     []*Casting
 }
 
 // NewActor создает нового актера.
@@ -69,3 +70,13 @@ func (a *Actor) GetFilms() []*Film {
 func (a *Actor) AddFilm(film *Film) {
 	a.films = append(a.films, film)
 }
+
+// GetRoles возвращает роли актера в фильмах.
+func (a *Actor) GetRoles() []*Casting {
+	return a.roles
+}
+
+// AddRole добавляет роль актера в фильме.
+func (a *Actor) AddRole(casting *Casting) {
+	a.roles = append(a.roles, casting)
+}
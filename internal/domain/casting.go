@@ -0,0 +1,44 @@
+package domain
+
+import "fmt"
+
+// RoleType tells how an actor appears in a film.
+type RoleType string
+
+const (
+	RoleTypeRegular RoleType = "regular"
+	RoleTypeCameo   RoleType = "cameo"
+	RoleTypeVoice   RoleType = "voice"
+)
+
+// Casting is the part an actor plays in a film.
+type Casting struct {
+	FilmID  int
+	ActorID int
+	// RoleName is the character played, empty when unknown.
+	RoleName string
+	// Billing is the position in the credits starting from 1, nil for uncredited roles.
+	Billing  *int
+	RoleType RoleType
+}
+
+// HasRole reports whether any role details are set.
+func (c Casting) HasRole() bool {
+	return c.RoleName != "" || c.Billing != nil || c.RoleType != ""
+}
+
+// Validate checks the role details. An empty role type stands for a regular role.
+func (c Casting) Validate() error {
+	if len(c.RoleName) > 255 {
+		return fmt.Errorf("%w: role name length should not exceed 255 characters", ErrInvalidCasting)
+	}
+	if c.Billing != nil && *c.Billing < 1 {
+		return fmt.Errorf("%w: billing position should be positive", ErrInvalidCasting)
+	}
+	switch c.RoleType {
+	case "", RoleTypeRegular, RoleTypeCameo, RoleTypeVoice:
+		return nil
+	default:
+		return fmt.Errorf("%w: role type must be regular/cameo/voice", ErrInvalidCasting)
+	}
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "fmt"
+
+// Job is the work a crew member did on a film.
+type Job string
+
+const (
+	JobDirector Job = "director"
+	JobWriter   Job = "writer"
+	JobComposer Job = "composer"
+	JobProducer Job = "producer"
+)
+
+// Credit is a job a person did on a film. A person may hold several jobs on
+// the same film. The names are filled in when credits are read.
+type Credit struct {
+	FilmID     int
+	PersonID   int
+	Job        Job
+	FilmTitle  string
+	PersonName string
+}
+
+// Validate checks the job of the credit.
+func (c Credit) Validate() error {
+	switch c.Job {
+	case JobDirector, JobWriter, JobComposer, JobProducer:
+		return nil
+	case "":
+		return fmt.Errorf("%w: job is required", ErrInvalidCredit)
+	default:
+		return fmt.Errorf("%w: job must be director/writer/composer/producer", ErrInvalidCredit)
+	}
+}
@@ -1,9 +1,45 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrNotFound        = errors.New("not found")
 	ErrRequired        = errors.New("required parameter is omitted")
 	ErrInvalidPassword = errors.New("invalid password")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrInvalidFilter   = errors.New("invalid filter")
+	ErrInvalidSort     = errors.New("invalid sort")
+	ErrInvalidQuery    = errors.New("invalid search query")
+	ErrUnknownActors   = errors.New("unknown actors")
+	ErrInvalidCasting  = errors.New("invalid casting")
+	ErrInvalidCredit   = errors.New("invalid credit")
+	ErrUnknownGenres   = errors.New("unknown genres")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrForbidden       = errors.New("forbidden")
+	ErrInvalidWatch    = errors.New("invalid watch date")
+	ErrInvalidMail     = errors.New("invalid mail")
+	ErrWeakPassword    = errors.New("weak password")
+	ErrInvalidToken    = errors.New("invalid or expired token")
+	ErrUnverified      = errors.New("mail is not verified")
+	ErrTooManyAttempts = errors.New("too many failed attempts")
+	ErrDisabled        = errors.New("account is disabled")
+	ErrInvalidRole     = errors.New("invalid user role")
 )
+
+// LockedError tells how long sign in stays locked after too many failed
+// attempts, it matches ErrTooManyAttempts.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockedError) Unwrap() error {
+	return ErrTooManyAttempts
+}
@@ -11,7 +11,13 @@ type Film struct {
 	description string
 	releaseDate time.Time
 	rating      float64
+	userRating  float64
+	voteCount   int
 	actors      []*Actor
+	cast        []*Casting
+	crew        []*Credit
+	genres      []*Genre
+	userState   *FilmUserState
 }
 
 // NewFilm creates a new film.
@@ -71,6 +77,22 @@ func (f *Film) GetRating() float64 {
 	return f.rating
 }
 
+// GetUserRating returns the mean of the user ratings, zero without votes.
+func (f *Film) GetUserRating() float64 {
+	return f.userRating
+}
+
+// GetVoteCount returns the number of users who rated the film.
+func (f *Film) GetVoteCount() int {
+	return f.voteCount
+}
+
+// SetUserRating sets the mean of the user ratings and their number.
+func (f *Film) SetUserRating(userRating float64, voteCount int) {
+	f.userRating = userRating
+	f.voteCount = voteCount
+}
+
 // GetActors returns the actors associated with the film.
 func (f *Film) GetActors() []*Actor {
 	return f.actors
@@ -80,3 +102,43 @@ func (f *Film) GetActors() []*Actor {
 func (f *Film) AddActor(actor *Actor) {
 	f.actors = append(f.actors, actor)
 }
+
+// GetCast returns the roles of the film actors in billing order.
+func (f *Film) GetCast() []*Casting {
+	return f.cast
+}
+
+// AddCasting adds the role of an actor to the film.
+func (f *Film) AddCasting(casting *Casting) {
+	f.cast = append(f.cast, casting)
+}
+
+// GetCrew returns the crew credits of the film.
+func (f *Film) GetCrew() []*Credit {
+	return f.crew
+}
+
+// AddCredit adds a crew credit to the film.
+func (f *Film) AddCredit(credit *Credit) {
+	f.crew = append(f.crew, credit)
+}
+
+// GetGenres returns the genres of the film.
+func (f *Film) GetGenres() []*Genre {
+	return f.genres
+}
+
+// AddGenre adds a genre to the film.
+func (f *Film) AddGenre(genre *Genre) {
+	f.genres = append(f.genres, genre)
+}
+
+// GetUserState returns where the current user keeps the film, nil when unknown.
+func (f *Film) GetUserState() *FilmUserState {
+	return f.userState
+}
+
+// SetUserState sets where the current user keeps the film.
+func (f *Film) SetUserState(state *FilmUserState) {
+	f.userState = state
+}
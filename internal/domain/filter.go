@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// GenreMatch tells how a film list filter combines several genres.
+type GenreMatch string
+
+const (
+	// GenreMatchAny keeps films that belong to at least one of the genres.
+	GenreMatchAny GenreMatch = "any"
+	// GenreMatchAll keeps films that belong to every one of the genres.
+	GenreMatchAll GenreMatch = "all"
+)
+
+// FilmFilter narrows a film list. Unset fields do not restrict the result,
+// set fields are combined with AND.
+type FilmFilter struct {
+	// ReleasedFrom and ReleasedTo bound the release date, both inclusive.
+	ReleasedFrom *time.Time
+	ReleasedTo   *time.Time
+	// MinRating and MaxRating bound the rating, both inclusive.
+	MinRating *float64
+	MaxRating *float64
+	// MinUserRating and MaxUserRating bound the mean of the user ratings,
+	// both inclusive. A film without votes has zero.
+	MinUserRating *float64
+	MaxUserRating *float64
+	// ActorIDs keeps films every listed actor took part in.
+	ActorIDs []int
+	// TitlePrefix keeps films whose title starts with it, case-insensitively.
+	TitlePrefix string
+	// WithoutActors keeps films that have no actors at all.
+	WithoutActors bool
+	// GenreIDs keeps films of the listed genres, combined as GenreMatch says.
+	GenreIDs []int
+	// GenreMatch is GenreMatchAny when empty.
+	GenreMatch GenreMatch
+}
+
+// Validate checks that the filter bounds are consistent.
+func (f FilmFilter) Validate() error {
+	if f.ReleasedFrom != nil && f.ReleasedTo != nil && f.ReleasedFrom.After(*f.ReleasedTo) {
+		return fmt.Errorf("%w: release date range is empty", ErrInvalidFilter)
+	}
+
+	for _, rating := range []*float64{f.MinRating, f.MaxRating} {
+		if rating != nil && (*rating < 0 || *rating > 10) {
+			return fmt.Errorf("%w: rating should be between 0 and 10", ErrInvalidFilter)
+		}
+	}
+	if f.MinRating != nil && f.MaxRating != nil && *f.MinRating > *f.MaxRating {
+		return fmt.Errorf("%w: rating range is empty", ErrInvalidFilter)
+	}
+	for _, rating := range []*float64{f.MinUserRating, f.MaxUserRating} {
+		if rating != nil && (*rating < 0 || *rating > 10) {
+			return fmt.Errorf("%w: user rating should be between 0 and 10", ErrInvalidFilter)
+		}
+	}
+	if f.MinUserRating != nil && f.MaxUserRating != nil && *f.MinUserRating > *f.MaxUserRating {
+		return fmt.Errorf("%w: user rating range is empty", ErrInvalidFilter)
+	}
+
+	for _, id := range f.ActorIDs {
+		if id < 1 {
+			return fmt.Errorf("%w: invalid actor id %d", ErrInvalidFilter, id)
+		}
+	}
+	if f.WithoutActors && len(f.ActorIDs) > 0 {
+		return fmt.Errorf("%w: actor ids can not be combined with no actors", ErrInvalidFilter)
+	}
+
+	for _, id := range f.GenreIDs {
+		if id < 1 {
+			return fmt.Errorf("%w: invalid genre id %d", ErrInvalidFilter, id)
+		}
+	}
+	if f.GenreMatch != "" && f.GenreMatch != GenreMatchAny && f.GenreMatch != GenreMatchAll {
+		return fmt.Errorf("%w: genre match must be any/all", ErrInvalidFilter)
+	}
+
+	return nil
+}
+
+// ActorFilter narrows an actor list. Unset fields do not restrict the result,
+// set fields are combined with AND.
+type ActorFilter struct {
+	// Name keeps actors whose name contains it, case-insensitively.
+	Name   string
+	Gender string
+	// BornFrom and BornTo bound the birth date, both inclusive.
+	BornFrom *time.Time
+	BornTo   *time.Time
+}
+
+// Validate checks that the filter bounds are consistent.
+func (f ActorFilter) Validate() error {
+	if f.Gender != "" && f.Gender != "male" && f.Gender != "female" && f.Gender != "other" {
+		return fmt.Errorf("%w: gender must be male/female/other", ErrInvalidFilter)
+	}
+	if f.BornFrom != nil && f.BornTo != nil && f.BornFrom.After(*f.BornTo) {
+		return fmt.Errorf("%w: birth date range is empty", ErrInvalidFilter)
+	}
+
+	return nil
+}
+
+// PersonFilter narrows a person list.
+type PersonFilter struct {
+	// Name keeps people whose name contains it, case-insensitively.
+	Name string
+	// Job keeps people credited with it on at least one film.
+	Job Job
+}
+
+// Validate checks that the job is known.
+func (f PersonFilter) Validate() error {
+	if f.Job == "" {
+		return nil
+	}
+	if err := (Credit{Job: f.Job}).Validate(); err != nil {
+		return fmt.Errorf("%w: job must be director/writer/composer/producer", ErrInvalidFilter)
+	}
+	return nil
+}
+
+// UserFilter narrows the user list of admins.
+type UserFilter struct {
+	// Search keeps users whose name or mail contains it, case-insensitively.
+	Search string
+	// Role keeps users with the role.
+	Role *int
+}
+
+// Validate checks that the role is known.
+func (f UserFilter) Validate() error {
+	if f.Role != nil && !ValidRole(*f.Role) {
+		return fmt.Errorf("%w: unknown role %d", ErrInvalidFilter, *f.Role)
+	}
+	return nil
+}
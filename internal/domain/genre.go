@@ -0,0 +1,42 @@
+package domain
+
+import "fmt"
+
+// Genre classifies films, a film may belong to several genres.
+type Genre struct {
+	id   int
+	name string
+}
+
+// NewGenre creates a new genre.
+func NewGenre(id int, name string) (*Genre, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrRequired)
+	}
+
+	if len(name) > 100 {
+		return nil, fmt.Errorf("name length should not exceed 100 characters")
+	}
+
+	return &Genre{
+		id:   id,
+		name: name,
+	}, nil
+}
+
+// GetId returns the id of the genre.
+func (g *Genre) GetId() int {
+	return g.id
+}
+
+// GetName returns the name of the genre.
+func (g *Genre) GetName() string {
+	return g.name
+}
+
+// GenreCount is a facet of a film list: how many of the listed films belong
+// to the genre.
+type GenreCount struct {
+	Genre *Genre
+	Count int
+}
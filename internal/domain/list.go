@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// FilmList is a named list of films kept by a user. A list with a share
+// token can be read by anyone who has the token.
+type FilmList struct {
+	id         int
+	userID     int
+	name       string
+	shareToken string
+	films      []*Film
+}
+
+// NewFilmList creates a new film list.
+func NewFilmList(id, userID int, name string) (*FilmList, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrRequired)
+	}
+
+	if len(name) > 100 {
+		return nil, fmt.Errorf("name length should not exceed 100 characters")
+	}
+
+	return &FilmList{
+		id:     id,
+		userID: userID,
+		name:   name,
+	}, nil
+}
+
+// GetId returns the id of the list.
+func (l *FilmList) GetId() int {
+	return l.id
+}
+
+// GetUserID returns the id of the owner.
+func (l *FilmList) GetUserID() int {
+	return l.userID
+}
+
+// GetName returns the name of the list.
+func (l *FilmList) GetName() string {
+	return l.name
+}
+
+// GetShareToken returns the token the list is shared by, empty when not shared.
+func (l *FilmList) GetShareToken() string {
+	return l.shareToken
+}
+
+// SetShareToken sets the token the list is shared by.
+func (l *FilmList) SetShareToken(token string) {
+	l.shareToken = token
+}
+
+// GetFilms returns the films in the list.
+func (l *FilmList) GetFilms() []*Film {
+	return l.films
+}
+
+// AddFilm adds a film to the list.
+func (l *FilmList) AddFilm(film *Film) {
+	l.films = append(l.films, film)
+}
+
+// WatchEntry records that a user watched a film on a date.
+type WatchEntry struct {
+	ID        int
+	UserID    int
+	FilmID    int
+	FilmTitle string
+	WatchedAt time.Time
+}
+
+// Validate checks the watch date, films can not be watched in the future.
+func (e WatchEntry) Validate() error {
+	if e.WatchedAt.After(time.Now()) {
+		return fmt.Errorf("%w: film cannot be watched in the future", ErrInvalidWatch)
+	}
+	return nil
+}
+
+// FilmUserState tells where the current user keeps a film.
+type FilmUserState struct {
+	InWatchlist bool
+	// WatchCount is the number of entries of the film in the watched log.
+	WatchCount int
+	// ListIDs are the ids of the lists of the user holding the film.
+	ListIDs []int
+}
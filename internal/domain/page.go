@@ -0,0 +1,30 @@
+package domain
+
+// PageRequest selects a window of a list either by offset or, when Cursor
+// is set, by keyset starting right after (or before) the cursor row.
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Cursor *Cursor
+}
+
+// Cursor points at a boundary row of a page.
+type Cursor struct {
+	// Sort identifies the ordering the cursor was issued for.
+	Sort string
+	// Values holds the sort column values of the boundary row.
+	Values []string
+	// ID of the boundary row, used as the tie-breaker.
+	ID int
+	// Backward is set for cursors that walk to the previous page.
+	Backward bool
+}
+
+// PageInfo describes the returned window of a list.
+type PageInfo struct {
+	Total      int
+	Limit      int
+	Offset     int
+	NextCursor *Cursor
+	PrevCursor *Cursor
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "github.com/Max425/film-library.git/internal/common/constants"
+
+// Permission allows an action on a kind of resource, written as resource:action.
+type Permission string
+
+const (
+	PermFilmRead       Permission = "film:read"
+	PermFilmWrite      Permission = "film:write"
+	PermFilmDelete     Permission = "film:delete"
+	PermActorRead      Permission = "actor:read"
+	PermActorWrite     Permission = "actor:write"
+	PermActorDelete    Permission = "actor:delete"
+	PermPersonRead     Permission = "person:read"
+	PermPersonWrite    Permission = "person:write"
+	PermPersonDelete   Permission = "person:delete"
+	PermGenreRead      Permission = "genre:read"
+	PermGenreWrite     Permission = "genre:write"
+	PermGenreDelete    Permission = "genre:delete"
+	PermReviewRead     Permission = "review:read"
+	PermReviewWrite    Permission = "review:write"
+	PermReviewModerate Permission = "review:moderate"
+	PermListRead       Permission = "list:read"
+	PermListWrite      Permission = "list:write"
+	PermUserManage     Permission = "user:manage"
+)
+
+// catalogRead lets a user browse the catalog.
+var catalogRead = []Permission{PermFilmRead, PermActorRead, PermPersonRead, PermGenreRead}
+
+// catalogWrite lets a user keep the catalog.
+var catalogWrite = []Permission{
+	PermFilmWrite, PermFilmDelete,
+	PermActorWrite, PermActorDelete,
+	PermPersonWrite, PermPersonDelete,
+	PermGenreWrite, PermGenreDelete,
+}
+
+// ownContent lets a user write reviews and keep film lists of their own.
+var ownContent = []Permission{PermReviewRead, PermReviewWrite, PermListRead, PermListWrite}
+
+// rolePermissions are the permissions of each role. Users browse the catalog
+// and keep their own reviews and lists, editors keep the catalog, moderators
+// remove reviews of others and admins do all of it and manage users.
+var rolePermissions = map[int]map[Permission]struct{}{
+	constants.UserRole:      permissionSet(catalogRead, ownContent),
+	constants.EditorRole:    permissionSet(catalogRead, ownContent, catalogWrite),
+	constants.ModeratorRole: permissionSet(catalogRead, ownContent, []Permission{PermReviewModerate}),
+	constants.AdminRole:     permissionSet(catalogRead, ownContent, catalogWrite, []Permission{PermReviewModerate, PermUserManage}),
+}
+
+func permissionSet(groups ...[]Permission) map[Permission]struct{} {
+	set := make(map[Permission]struct{})
+	for _, group := range groups {
+		for _, permission := range group {
+			set[permission] = struct{}{}
+		}
+	}
+	return set
+}
+
+// RoleHas reports whether the role grants the permission, unknown roles grant none.
+func RoleHas(role int, permission Permission) bool {
+	_, ok := rolePermissions[role][permission]
+	return ok
+}
+
+// ValidRole reports whether the role is one of the known roles.
+func ValidRole(role int) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "fmt"
+
+// Person is a crew member: a director, a writer, a composer or a producer.
+type Person struct {
+	id      int
+	name    string
+	credits []*Credit
+}
+
+// NewPerson creates a new person.
+func NewPerson(id int, name string, credits []*Credit) (*Person, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrRequired)
+	}
+
+	if len(name) > 255 {
+		return nil, fmt.Errorf("name length should not exceed 255 characters")
+	}
+
+	return &Person{
+		id:      id,
+		name:    name,
+		credits: credits,
+	}, nil
+}
+
+// GetId returns the id of the person.
+func (p *Person) GetId() int {
+	return p.id
+}
+
+// GetName returns the name of the person.
+func (p *Person) GetName() string {
+	return p.name
+}
+
+// GetCredits returns the jobs the person did on films.
+func (p *Person) GetCredits() []*Credit {
+	return p.credits
+}
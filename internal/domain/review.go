@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Review is the rating a user gave a film, optionally with a text.
+type Review struct {
+	id        int
+	filmID    int
+	userID    int
+	rating    int
+	text      string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewReview creates a new review.
+func NewReview(id, filmID, userID, rating int, text string) (*Review, error) {
+	if rating < 1 || rating > 10 {
+		return nil, fmt.Errorf("%w: rating should be between 1 and 10", ErrRequired)
+	}
+
+	if len(text) > 5000 {
+		return nil, fmt.Errorf("text length should not exceed 5000 characters")
+	}
+
+	return &Review{
+		id:     id,
+		filmID: filmID,
+		userID: userID,
+		rating: rating,
+		text:   text,
+	}, nil
+}
+
+// GetId returns the id of the review.
+func (r *Review) GetId() int {
+	return r.id
+}
+
+// GetFilmID returns the id of the reviewed film.
+func (r *Review) GetFilmID() int {
+	return r.filmID
+}
+
+// GetUserID returns the id of the author.
+func (r *Review) GetUserID() int {
+	return r.userID
+}
+
+// GetRating returns the rating from 1 to 10.
+func (r *Review) GetRating() int {
+	return r.rating
+}
+
+// GetText returns the text of the review, empty for a bare rating.
+func (r *Review) GetText() string {
+	return r.text
+}
+
+// GetCreatedAt returns the time the review was written.
+func (r *Review) GetCreatedAt() time.Time {
+	return r.createdAt
+}
+
+// GetUpdatedAt returns the time the review was last edited.
+func (r *Review) GetUpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// SetTimestamps sets the times the review was written and last edited.
+func (r *Review) SetTimestamps(createdAt, updatedAt time.Time) {
+	r.createdAt = createdAt
+	r.updatedAt = updatedAt
+}
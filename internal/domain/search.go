@@ -0,0 +1,25 @@
+package domain
+
+// FilmMatch is a film found by a search.
+type FilmMatch struct {
+	Film *Film
+	// Rank is the relevance of the film to the search query, higher is better.
+	// For fuzzy matches it is the trigram similarity.
+	Rank float64
+	// TitleHeadline and DescriptionHeadline are the title and a description
+	// fragment with the matched words wrapped in <b></b>, empty for fuzzy matches.
+	TitleHeadline       string
+	DescriptionHeadline string
+}
+
+// FilmSearchResult is a page of films found by a search.
+type FilmSearchResult struct {
+	Matches []*FilmMatch
+	Page    *PageInfo
+	// Fuzzy is set when nothing matched the query exactly and the matches
+	// are films with a title, an actor or a crew name similar to it.
+	Fuzzy bool
+	// Suggestions are film titles, actor and crew names similar to the query,
+	// given when nothing matched it exactly.
+	Suggestions []string
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Session is the signed in user behind a session id, along with the client
+// the user signed in from.
+type Session struct {
+	// ID tells the sessions of a user apart, it is not the session id of the cookie.
+	ID        string
+	UserID    int
+	Role      int
+	CreatedAt time.Time
+	UserAgent string
+	IP        string
+	// Family ties together the refresh sessions rotated from one sign in.
+	Family string
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "fmt"
+
+// FilmSortField is a film attribute a list can be ordered by.
+type FilmSortField string
+
+const (
+	FilmSortTitle       FilmSortField = "title"
+	FilmSortRating      FilmSortField = "rating"
+	FilmSortUserRating  FilmSortField = "user_rating"
+	FilmSortReleaseDate FilmSortField = "release_date"
+)
+
+// FilmSort orders a film list by one field.
+type FilmSort struct {
+	Field FilmSortField
+	Desc  bool
+}
+
+// DefaultFilmSort is used when no ordering is requested.
+var DefaultFilmSort = []FilmSort{{Field: FilmSortRating, Desc: true}}
+
+// ValidateFilmSort checks that the ordering uses known fields, each at most once.
+func ValidateFilmSort(sort []FilmSort) error {
+	fields := make([]FilmSortField, len(sort))
+	for i, s := range sort {
+		fields[i] = s.Field
+	}
+	return validateSortFields(fields, FilmSortTitle, FilmSortRating, FilmSortUserRating, FilmSortReleaseDate)
+}
+
+// ActorSortField is an actor attribute a list can be ordered by.
+type ActorSortField string
+
+const (
+	ActorSortName      ActorSortField = "name"
+	ActorSortBirthDate ActorSortField = "birth_date"
+	ActorSortFilmCount ActorSortField = "film_count"
+)
+
+// ActorSort orders an actor list by one field. Without any, actors are ordered by id.
+type ActorSort struct {
+	Field ActorSortField
+	Desc  bool
+}
+
+// ValidateActorSort checks that the ordering uses known fields, each at most once.
+func ValidateActorSort(sort []ActorSort) error {
+	fields := make([]ActorSortField, len(sort))
+	for i, s := range sort {
+		fields[i] = s.Field
+	}
+	return validateSortFields(fields, ActorSortName, ActorSortBirthDate, ActorSortFilmCount)
+}
+
+func validateSortFields[F ~string](fields []F, known ...F) error {
+	seen := make(map[F]bool, len(fields))
+	for _, field := range fields {
+		valid := false
+		for _, k := range known {
+			valid = valid || field == k
+		}
+		if !valid {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidSort, field)
+		}
+		if seen[field] {
+			return fmt.Errorf("%w: field %q is repeated", ErrInvalidSort, field)
+		}
+		seen[field] = true
+	}
+	return nil
+}
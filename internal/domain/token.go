@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// TokenPurpose tells apart the single use tokens sent by mail, a token
+// only works for the purpose it was issued for.
+type TokenPurpose string
+
+const (
+	TokenPasswordReset    TokenPurpose = "password_reset"
+	TokenMailVerification TokenPurpose = "mail_verification"
+)
+
+// TokenPair is what a client without cookies signs in with, a short lived
+// access token to send as a bearer token and a refresh token to get the
+// next pair with.
+type TokenPair struct {
+	AccessToken  string
+	ExpiresAt    time.Time
+	RefreshToken string
+}
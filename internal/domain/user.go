@@ -2,15 +2,21 @@ package domain
 
 import (
 	"fmt"
+	"net/mail"
+	"strings"
+	"time"
 )
 
 type User struct {
-	id       int
-	name     string
-	mail     string
-	password string
-	salt     string
-	role     int
+	id         int
+	name       string
+	mail       string
+	password   string
+	salt       string
+	role       int
+	createdAt  time.Time
+	verifiedAt time.Time
+	disabledAt time.Time
 }
 
 func NewUser(id int, name, mail, password, salt string, role int) (*User, error) {
@@ -19,19 +25,19 @@ func NewUser(id int, name, mail, password, salt string, role int) (*User, error)
 	}
 
 	if name == "" {
-		return nil, fmt.Errorf("%w: title is required", ErrRequired)
+		return nil, fmt.Errorf("%w: name is required", ErrRequired)
 	}
 
 	if mail == "" {
-		return nil, fmt.Errorf("%w: title is required", ErrRequired)
+		return nil, fmt.Errorf("%w: mail is required", ErrRequired)
 	}
 
 	if password == "" {
-		return nil, fmt.Errorf("%w: title is required", ErrRequired)
+		return nil, fmt.Errorf("%w: password is required", ErrRequired)
 	}
 
-	if role < 0 {
-		return nil, fmt.Errorf("invalid user role: %d", role)
+	if !ValidRole(role) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRole, role)
 	}
 
 	return &User{
@@ -73,6 +79,43 @@ func (u *User) Role() int {
 	return u.role
 }
 
+// CreatedAt returns the time the user signed up.
+func (u *User) CreatedAt() time.Time {
+	return u.createdAt
+}
+
+func (u *User) SetCreatedAt(createdAt time.Time) {
+	u.createdAt = createdAt
+}
+
+// Verified reports whether the user has proven to own the mail.
+func (u *User) Verified() bool {
+	return !u.verifiedAt.IsZero()
+}
+
+// VerifiedAt returns the time the mail was verified, zero while it is not.
+func (u *User) VerifiedAt() time.Time {
+	return u.verifiedAt
+}
+
+func (u *User) SetVerifiedAt(verifiedAt time.Time) {
+	u.verifiedAt = verifiedAt
+}
+
+// Disabled reports whether an admin keeps the user from signing in.
+func (u *User) Disabled() bool {
+	return !u.disabledAt.IsZero()
+}
+
+// DisabledAt returns the time the user was disabled, zero while the user is not.
+func (u *User) DisabledAt() time.Time {
+	return u.disabledAt
+}
+
+func (u *User) SetDisabledAt(disabledAt time.Time) {
+	u.disabledAt = disabledAt
+}
+
 func (u *User) SetSalt(salt string) {
 	u.salt = salt
 }
@@ -80,3 +123,18 @@ func (u *User) SetSalt(salt string) {
 func (u *User) SetPassword(password string) {
 	u.password = password
 }
+
+// NormalizeMail lowercases and trims the mail, so a mail is the same however
+// the user typed it in.
+func NormalizeMail(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// ValidateMail checks that the mail is a bare address like bob@example.com.
+func ValidateMail(address string) error {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil || parsed.Address != address || !strings.Contains(address[strings.LastIndex(address, "@"):], ".") {
+		return fmt.Errorf("%w: %q is not an email address", ErrInvalidMail, address)
+	}
+	return nil
+}
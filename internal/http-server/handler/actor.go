@@ -6,10 +6,10 @@ import (
 	"github.com/Max425/film-library.git/internal/common"
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
 	"go.uber.org/zap"
 	"io"
 	"net/http"
-	"strconv"
 )
 
 type ActorService interface {
@@ -17,7 +17,9 @@ type ActorService interface {
 	GetActorByID(ctx context.Context, id int) (*domain.Actor, error)
 	UpdateActor(ctx context.Context, actor *domain.Actor) (*domain.Actor, error)
 	DeleteActor(ctx context.Context, id int) error
-	GetAllActors(ctx context.Context) ([]*domain.Actor, error)
+	AddActorFilm(ctx context.Context, casting domain.Casting) (*domain.Actor, error)
+	RemoveActorFilm(ctx context.Context, actorID, filmID int) (*domain.Actor, error)
+	GetAllActors(ctx context.Context, sort []domain.ActorSort, filter domain.ActorFilter, page domain.PageRequest) ([]*domain.Actor, *domain.PageInfo, error)
 }
 
 type ActorHandler struct {
@@ -41,13 +43,9 @@ func NewActorHandler(log *zap.Logger, actorService ActorService) *ActorHandler {
 // @Success 201 {object} dto.Actor "Actor created successfully"
 // @Failure 400 {string} string "Bad request"
 // @Failure 500 {string} string "Internal server error"
+// @Router /api/actors [post]
 // @Router /api/create_actors [post]
 func (h *ActorHandler) CreateActor(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
-		return
-	}
-
 	var actor dto.Actor
 	body, _ := io.ReadAll(r.Body)
 	if err := actor.UnmarshalJSON(body); err != nil {
@@ -69,25 +67,56 @@ func (h *ActorHandler) CreateActor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dto.NewSuccessClientResponseDto(r.Context(), w, dto.ActorDomainToDto(actorCreated))
+	dto.NewCreatedClientResponseDto(r.Context(), w, dto.ActorDomainToDto(actorCreated))
+}
+
+// GetActorByID retrieves an actor with their filmography.
+// @Summary Retrieve an actor by ID
+// @Tags actors
+// @Accept json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Success 200 {object} dto.Actor "Actor with films"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/actors/{id} [get]
+func (h *ActorHandler) GetActorByID(w http.ResponseWriter, r *http.Request) {
+	actorID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid actor ID")
+		return
+	}
+
+	actor, err := h.actorService.GetActorByID(r.Context(), actorID)
+	if err != nil {
+		h.log.Error("Failed to get actor", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.ActorDomainToDto(actor))
 }
 
 // UpdateActor updates an existing actor.
+// On the legacy route the actor ID is taken from the request body.
 // @Summary Update an existing actor
 // @Tags actors
 // @Accept json
 // @Produce json
+// @Param id path int true "Actor ID"
 // @Param input body dto.Actor true "Actor object to be updated"
 // @Success 200 {object} dto.Actor "Actor updated successfully"
 // @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
 // @Failure 500 {string} string "Internal server error"
+// @Router /api/actors/{id} [put]
 // @Router /api/update_actors [put]
 func (h *ActorHandler) UpdateActor(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPut {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
-		return
-	}
-
 	var actor dto.Actor
 	body, _ := io.ReadAll(r.Body)
 	if err := actor.UnmarshalJSON(body); err != nil {
@@ -95,6 +124,15 @@ func (h *ActorHandler) UpdateActor(w http.ResponseWriter, r *http.Request) {
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
 		return
 	}
+
+	if router.Param(r, "id") != "" {
+		actorID, err := router.IntParam(r, "id")
+		if err != nil {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid actor ID")
+			return
+		}
+		actor.ID = actorID
+	}
 	domainActor, err := dto.ActorDtoToDomain(&actor)
 	if err != nil {
 		h.log.Error("Failed to convert actor", zap.Error(err))
@@ -116,6 +154,67 @@ func (h *ActorHandler) UpdateActor(w http.ResponseWriter, r *http.Request) {
 	dto.NewSuccessClientResponseDto(r.Context(), w, dto.ActorDomainToDto(actorUpdated))
 }
 
+// PatchActor partially updates an existing actor.
+// Only the fields present in the request body are changed.
+// @Summary Partially update an existing actor
+// @Tags actors
+// @Accept json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Param input body dto.Actor true "Actor fields to be updated"
+// @Success 200 {object} dto.Actor "Actor updated successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/actors/{id} [patch]
+func (h *ActorHandler) PatchActor(w http.ResponseWriter, r *http.Request) {
+	actorID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid actor ID")
+		return
+	}
+
+	currentActor, err := h.actorService.GetActorByID(r.Context(), actorID)
+	if err != nil {
+		h.log.Error("Failed to get actor", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	actor := dto.ActorDomainToDto(currentActor)
+	body, _ := io.ReadAll(r.Body)
+	if err = actor.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode actor", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	actor.ID = actorID
+
+	domainActor, err := dto.ActorDtoToDomain(actor)
+	if err != nil {
+		h.log.Error("Failed to convert actor", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actorUpdated, err := h.actorService.UpdateActor(r.Context(), domainActor)
+	if err != nil {
+		h.log.Error("Failed to update actor", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.ActorDomainToDto(actorUpdated))
+}
+
 // DeleteActor deletes an existing actor.
 // @Summary Delete an existing actor
 // @Tags actors
@@ -127,13 +226,7 @@ func (h *ActorHandler) UpdateActor(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {string} string "Internal server error"
 // @Router /api/actors/{id} [delete]
 func (h *ActorHandler) DeleteActor(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
-		return
-	}
-
-	idStr := r.URL.Path[len("/api/actors/"):]
-	actorID, err := strconv.Atoi(idStr)
+	actorID, err := router.IntParam(r, "id")
 	if err != nil {
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid actor ID")
 		return
@@ -152,23 +245,52 @@ func (h *ActorHandler) DeleteActor(w http.ResponseWriter, r *http.Request) {
 	dto.NewSuccessClientResponseDto(r.Context(), w, "Actor deleted successfully")
 }
 
-// GetAllActors retrieves all actors.
+// GetAllActors retrieves all actors with optional search, filtering and sorting.
+// Several sort fields may be given, e.g. sort_by=film_count,name&order=desc,asc.
+// By default, actors are sorted by id. Filters are combined with AND.
 // @Summary Retrieve all actors
 // @Tags actors
 // @Accept json
 // @Produce json
+// @Param name query string false "Name fragment, case-insensitive"
+// @Param gender query string false "Gender: male, female, other"
+// @Param born_from query string false "Earliest birth date, YYYY-MM-DD"
+// @Param born_to query string false "Latest birth date, YYYY-MM-DD"
+// @Param sort_by query string false "Comma separated sort fields: name, birth_date, film_count"
+// @Param order query string false "Comma separated sort orders: asc, desc, one order applies to all fields"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Param offset query int false "Number of actors to skip, ignored with cursor"
+// @Param cursor query string false "Cursor from a previous page"
 // @Success 200 {array} []dto.Actor "List of actors"
+// @Failure 400 {string} string "Bad request"
 // @Failure 500 {string} string "Internal server error"
 // @Router /api/actors [get]
 func (h *ActorHandler) GetAllActors(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	sort, err := parseActorSort(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter, err := parseActorFilter(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	actors, err := h.actorService.GetAllActors(r.Context())
+	page, err := parsePageRequest(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actors, info, err := h.actorService.GetAllActors(r.Context(), sort, filter, page)
 	if err != nil {
 		h.log.Error("Failed to get all actors", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidCursor) || errors.Is(err, domain.ErrInvalidFilter) || errors.Is(err, domain.ErrInvalidSort) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -178,5 +300,68 @@ func (h *ActorHandler) GetAllActors(w http.ResponseWriter, r *http.Request) {
 		data[i] = dto.ActorDomainToDto(film)
 	}
 
-	dto.NewSuccessClientResponseDto(r.Context(), w, data)
+	dto.NewPageClientResponseDto(r.Context(), w, data, info)
+}
+
+// AddActorFilm adds a film to the filmography of an actor. The optional body
+// sets the role, adding a film that is already there only updates the role.
+// @Summary Add a film to the filmography of an actor
+// @Tags actors
+// @Accept json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Param filmId path int true "Film ID"
+// @Param input body dto.Casting false "Role name, billing position and role type: regular, cameo, voice"
+// @Success 200 {object} dto.Actor "Actor with the updated filmography"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Actor or film not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/actors/{id}/films/{filmId} [post]
+func (h *ActorHandler) AddActorFilm(w http.ResponseWriter, r *http.Request) {
+	h.changeActorFilm(w, r, h.actorService.AddActorFilm)
+}
+
+// RemoveActorFilm removes a film from the filmography of an actor. Removing a
+// film that is not there succeeds without changes.
+// @Summary Remove a film from the filmography of an actor
+// @Tags actors
+// @Accept json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Param filmId path int true "Film ID"
+// @Success 200 {object} dto.Actor "Actor with the updated filmography"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Actor not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/actors/{id}/films/{filmId} [delete]
+func (h *ActorHandler) RemoveActorFilm(w http.ResponseWriter, r *http.Request) {
+	h.changeActorFilm(w, r, func(ctx context.Context, casting domain.Casting) (*domain.Actor, error) {
+		return h.actorService.RemoveActorFilm(ctx, casting.ActorID, casting.FilmID)
+	})
+}
+
+func (h *ActorHandler) changeActorFilm(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, casting domain.Casting) (*domain.Actor, error)) {
+	casting, err := parseCasting(r, "filmId", "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := change(r.Context(), casting)
+	if err != nil {
+		h.log.Error("Failed to change actor film", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidCasting) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.ActorDomainToDto(actor))
 }
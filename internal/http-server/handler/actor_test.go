@@ -3,7 +3,9 @@ package handler
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/router"
 	"github.com/Max425/film-library.git/mocks/service"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -32,15 +34,15 @@ func TestActorHandler_CreateActor(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockActorService, actor *domain.Actor) {
 				r.EXPECT().CreateActor(gomock.Any(), actor).Return(mockActor, nil)
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":0,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]}}`,
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"status":201,"message":"success","payload":{"id":0,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]}}`,
 		},
 		{
 			name:                 "Invalid JSON",
 			requestMethod:        http.MethodPost,
 			requestBody:          `{"name": "Bob", "gender": "male", "birth_date": "2024-03-18"`,
 			mockBehavior:         func(r *mock_handler.MockActorService, actor *domain.Actor) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
 		},
 		{
@@ -50,7 +52,7 @@ func TestActorHandler_CreateActor(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockActorService, actor *domain.Actor) {
 				r.EXPECT().CreateActor(gomock.Any(), actor).Return(mockActor, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
 	}
@@ -80,6 +82,79 @@ func TestActorHandler_CreateActor(t *testing.T) {
 	}
 }
 
+func TestActorHandler_GetActorByID(t *testing.T) {
+	mockFilm, _ := domain.NewFilm(1, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
+	mockActor, _ := domain.NewActor(1, "Bob", "male", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), []*domain.Film{mockFilm})
+	tests := []struct {
+		name                 string
+		requestURL           string
+		mockBehavior         func(r *mock_handler.MockActorService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Ok",
+			requestURL: "/api/actors/1",
+			mockBehavior: func(r *mock_handler.MockActorService) {
+				r.EXPECT().GetActorByID(gomock.Any(), 1).Return(mockActor, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}]}}`,
+		},
+		{
+			name:                 "Invalid actor ID",
+			requestURL:           "/api/actors/invalid",
+			mockBehavior:         func(r *mock_handler.MockActorService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid actor ID","payload":""}`,
+		},
+		{
+			name:       "Not found",
+			requestURL: "/api/actors/2",
+			mockBehavior: func(r *mock_handler.MockActorService) {
+				r.EXPECT().GetActorByID(gomock.Any(), 2).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:       "Get actor error",
+			requestURL: "/api/actors/1",
+			mockBehavior: func(r *mock_handler.MockActorService) {
+				r.EXPECT().GetActorByID(gomock.Any(), 1).Return(nil, errors.New("error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockActorService := mock_handler.NewMockActorService(mockCtrl)
+			test.mockBehavior(mockActorService)
+
+			logger := zap.NewNop()
+			actorHandler := NewActorHandler(logger, mockActorService)
+
+			req, err := http.NewRequest(http.MethodGet, test.requestURL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodGet, "/api/actors/{id}", actorHandler.GetActorByID)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
 func TestActorHandler_UpdateActor(t *testing.T) {
 	mockActor, _ := domain.NewActor(0, "Bob", "male", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), nil)
 	tests := []struct {
@@ -105,7 +180,7 @@ func TestActorHandler_UpdateActor(t *testing.T) {
 			requestMethod:        http.MethodPut,
 			requestBody:          `{"name": "Bob", "gender": "male", "birth_date": "2024-03-18"`,
 			mockBehavior:         func(r *mock_handler.MockActorService, actor *domain.Actor) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
 		},
 		{
@@ -115,7 +190,7 @@ func TestActorHandler_UpdateActor(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockActorService, actor *domain.Actor) {
 				r.EXPECT().UpdateActor(gomock.Any(), actor).Return(mockActor, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
 	}
@@ -145,6 +220,67 @@ func TestActorHandler_UpdateActor(t *testing.T) {
 	}
 }
 
+func TestActorHandler_PatchActor(t *testing.T) {
+	mockActor, _ := domain.NewActor(1, "Bob", "male", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), nil)
+	patchedActor, _ := domain.NewActor(1, "Robert", "male", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), nil)
+	tests := []struct {
+		name                 string
+		requestURL           string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockActorService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestURL:  "/api/actors/1",
+			requestBody: `{"name": "Robert"}`,
+			mockBehavior: func(r *mock_handler.MockActorService) {
+				r.EXPECT().GetActorByID(gomock.Any(), 1).Return(mockActor, nil)
+				r.EXPECT().UpdateActor(gomock.Any(), patchedActor).Return(patchedActor, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"name":"Robert","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]}}`,
+		},
+		{
+			name:        "Not found",
+			requestURL:  "/api/actors/2",
+			requestBody: `{"name": "Robert"}`,
+			mockBehavior: func(r *mock_handler.MockActorService) {
+				r.EXPECT().GetActorByID(gomock.Any(), 2).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockActorService := mock_handler.NewMockActorService(mockCtrl)
+			test.mockBehavior(mockActorService)
+
+			logger := zap.NewNop()
+			actorHandler := NewActorHandler(logger, mockActorService)
+
+			req, err := http.NewRequest(http.MethodPatch, test.requestURL, bytes.NewBufferString(test.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPatch, "/api/actors/{id}", actorHandler.PatchActor)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
 func TestActorHandler_DeleteActor(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -169,7 +305,7 @@ func TestActorHandler_DeleteActor(t *testing.T) {
 			requestMethod:        http.MethodDelete,
 			requestURL:           "/api/actors/invalid",
 			mockBehavior:         func(r *mock_handler.MockActorService, actorID int) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"status":400,"message":"invalid actor ID","payload":""}`,
 		},
 		{
@@ -179,7 +315,7 @@ func TestActorHandler_DeleteActor(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockActorService, actorID int) {
 				r.EXPECT().DeleteActor(gomock.Any(), actorID).Return(errors.New("error"))
 			},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
 	}
@@ -202,7 +338,9 @@ func TestActorHandler_DeleteActor(t *testing.T) {
 			}
 			rr := httptest.NewRecorder()
 
-			actorHandler.DeleteActor(rr, req)
+			rt := router.New()
+			rt.HandleFunc(http.MethodDelete, "/api/actors/{id}", actorHandler.DeleteActor)
+			rt.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.expectedStatusCode, rr.Code)
 			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
@@ -217,6 +355,7 @@ func TestActorHandler_GetAllActors(t *testing.T) {
 	tests := []struct {
 		name                 string
 		requestMethod        string
+		requestURL           string
 		mockBehavior         func(r *mock_handler.MockActorService) *gomock.Call
 		expectedStatusCode   int
 		expectedResponseBody string
@@ -224,19 +363,55 @@ func TestActorHandler_GetAllActors(t *testing.T) {
 		{
 			name:          "Ok",
 			requestMethod: http.MethodGet,
+			requestURL:    "/api/actors",
 			mockBehavior: func(r *mock_handler.MockActorService) *gomock.Call {
-				return r.EXPECT().GetAllActors(gomock.Any()).Return(mockActors, nil)
+				return r.EXPECT().GetAllActors(gomock.Any(), []domain.ActorSort{}, domain.ActorFilter{}, domain.PageRequest{Limit: 20}).Return(mockActors, &domain.PageInfo{Total: 2, Limit: 20}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":0,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]},{"id":0,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]}]}`,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":0,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]},{"id":0,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]}],"pagination":{"total":2,"limit":20,"offset":0}}`,
 		},
 		{
-			name:          "Get all actors error",
+			name:          "Search And Sort",
 			requestMethod: http.MethodGet,
+			requestURL:    "/api/actors?name=bo&gender=male&born_from=2000-01-01&sort_by=film_count,name&order=desc,asc",
 			mockBehavior: func(r *mock_handler.MockActorService) *gomock.Call {
-				return r.EXPECT().GetAllActors(gomock.Any()).Return(nil, errors.New("error"))
+				from := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+				sort := []domain.ActorSort{{Field: domain.ActorSortFilmCount, Desc: true}, {Field: domain.ActorSortName}}
+				filter := domain.ActorFilter{Name: "bo", Gender: "male", BornFrom: &from}
+				return r.EXPECT().GetAllActors(gomock.Any(), sort, filter, domain.PageRequest{Limit: 20}).Return(mockActors, &domain.PageInfo{Total: 2, Limit: 20}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":0,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]},{"id":0,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]}],"pagination":{"total":2,"limit":20,"offset":0}}`,
+		},
+		{
+			name:          "Invalid Sort Field",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/actors?sort_by=rating",
+			mockBehavior: func(r *mock_handler.MockActorService) *gomock.Call {
+				return r.EXPECT().GetAllActors(gomock.Any(), []domain.ActorSort{{Field: "rating", Desc: true}}, domain.ActorFilter{}, domain.PageRequest{Limit: 20}).
+					Return(nil, nil, fmt.Errorf("%w: unknown field %q", domain.ErrInvalidSort, "rating"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid sort: unknown field \"rating\"","payload":""}`,
+		},
+		{
+			name:          "Invalid Filter",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/actors?born_to=tomorrow",
+			mockBehavior: func(r *mock_handler.MockActorService) *gomock.Call {
+				return nil
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid born_to","payload":""}`,
+		},
+		{
+			name:          "Get all actors error",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/actors",
+			mockBehavior: func(r *mock_handler.MockActorService) *gomock.Call {
+				return r.EXPECT().GetAllActors(gomock.Any(), []domain.ActorSort{}, domain.ActorFilter{}, domain.PageRequest{Limit: 20}).Return(nil, nil, errors.New("error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"error","payload":""}`,
 		},
 	}
@@ -252,7 +427,7 @@ func TestActorHandler_GetAllActors(t *testing.T) {
 			logger := zap.NewNop()
 			actorHandler := NewActorHandler(logger, mockActorService)
 
-			req, err := http.NewRequest(test.requestMethod, "/api/actors", nil)
+			req, err := http.NewRequest(test.requestMethod, test.requestURL, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -265,3 +440,77 @@ func TestActorHandler_GetAllActors(t *testing.T) {
 		})
 	}
 }
+
+func TestActorHandler_ChangeActorFilm(t *testing.T) {
+	mockActor, _ := domain.NewActor(1, "Bob", "male", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), nil)
+	tests := []struct {
+		name                 string
+		requestMethod        string
+		requestURL           string
+		mockBehavior         func(r *mock_handler.MockActorService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "Add",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/actors/1/films/2",
+			mockBehavior: func(r *mock_handler.MockActorService) {
+				r.EXPECT().AddActorFilm(gomock.Any(), domain.Casting{FilmID: 2, ActorID: 1}).Return(mockActor, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]}}`,
+		},
+		{
+			name:          "Remove",
+			requestMethod: http.MethodDelete,
+			requestURL:    "/api/actors/1/films/2",
+			mockBehavior: func(r *mock_handler.MockActorService) {
+				r.EXPECT().RemoveActorFilm(gomock.Any(), 1, 2).Return(mockActor, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"name":"Bob","gender":"male","birth_date":"2024-03-18T00:00:00Z","films":[]}}`,
+		},
+		{
+			name:                 "Invalid film ID",
+			requestMethod:        http.MethodPost,
+			requestURL:           "/api/actors/1/films/abc",
+			mockBehavior:         func(r *mock_handler.MockActorService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid film ID","payload":""}`,
+		},
+		{
+			name:          "Film not found",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/actors/1/films/2",
+			mockBehavior: func(r *mock_handler.MockActorService) {
+				r.EXPECT().AddActorFilm(gomock.Any(), domain.Casting{FilmID: 2, ActorID: 1}).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockActorService := mock_handler.NewMockActorService(mockCtrl)
+			test.mockBehavior(mockActorService)
+
+			actorHandler := NewActorHandler(zap.NewNop(), mockActorService)
+
+			req := httptest.NewRequest(test.requestMethod, test.requestURL, nil)
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/actors/{id}/films/{filmId}", actorHandler.AddActorFilm)
+			rt.HandleFunc(http.MethodDelete, "/api/actors/{id}/films/{filmId}", actorHandler.RemoveActorFilm)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/Max425/film-library.git/internal/common"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+type AdminService interface {
+	GetUsers(ctx context.Context, filter domain.UserFilter, page domain.PageRequest) ([]*domain.User, *domain.PageInfo, error)
+	GetUserAccount(ctx context.Context, id int) (*domain.User, error)
+	ChangeUserRole(ctx context.Context, admin *domain.Session, id, role int) (*domain.User, error)
+	DisableUser(ctx context.Context, admin *domain.Session, id int) (*domain.User, error)
+	EnableUser(ctx context.Context, admin *domain.Session, id int) (*domain.User, error)
+	LogoutUser(ctx context.Context, admin *domain.Session, id int) error
+	DeleteUser(ctx context.Context, admin *domain.Session, id int) error
+}
+
+type AdminHandler struct {
+	log          *zap.Logger
+	adminService AdminService
+}
+
+func NewAdminHandler(log *zap.Logger, adminService AdminService) *AdminHandler {
+	return &AdminHandler{
+		log:          log,
+		adminService: adminService,
+	}
+}
+
+// GetUsers retrieves the users in the order they signed up.
+// @Summary Retrieve all users
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param q query string false "Name or mail fragment, case-insensitive"
+// @Param role query int false "Role: 0 user, 1 admin, 2 editor, 3 moderator"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Param offset query int false "Number of users to skip, ignored with cursor"
+// @Param cursor query string false "Cursor from a previous page"
+// @Success 200 {array} []dto.User "List of users"
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {string} string "Not an admin"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/users [get]
+func (h *AdminHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseUserFilter(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := parsePageRequest(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	users, info, err := h.adminService.GetUsers(r.Context(), filter, page)
+	if err != nil {
+		h.log.Error("Failed to get users", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidCursor) || errors.Is(err, domain.ErrInvalidFilter) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	data := make([]*dto.User, len(users))
+	for i, user := range users {
+		data[i] = dto.UserDomainToDto(user)
+	}
+
+	dto.NewPageClientResponseDto(r.Context(), w, data, info)
+}
+
+// GetUser retrieves the account of a user.
+// @Summary Retrieve a user by ID
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.User "User"
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {string} string "Not an admin"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/users/{id} [get]
+func (h *AdminHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	user, err := h.adminService.GetUserAccount(r.Context(), userID)
+	if err != nil {
+		h.log.Error("Failed to get user", zap.Error(err))
+		h.adminError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.UserDomainToDto(user))
+}
+
+// ChangeUserRole gives a user another role, admins can not change their own role.
+// @Summary Change the role of a user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param input body dto.RoleInput true "Role: 0 user, 1 admin, 2 editor, 3 moderator"
+// @Success 200 {object} dto.User "User with the new role"
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {string} string "Not an admin or own account"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/users/{id}/role [put]
+func (h *AdminHandler) ChangeUserRole(w http.ResponseWriter, r *http.Request) {
+	userID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var input dto.RoleInput
+	body, _ := io.ReadAll(r.Body)
+	if err = input.UnmarshalJSON(body); err != nil || input.Role == nil {
+		h.log.Error("Failed to decode role", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+
+	user, err := h.adminService.ChangeUserRole(r.Context(), sessionFromContext(r.Context()), userID, *input.Role)
+	if err != nil {
+		h.log.Error("Failed to change user role", zap.Error(err))
+		h.adminError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.UserDomainToDto(user))
+}
+
+// DisableUser keeps a user from signing in and ends every session of the user.
+// @Summary Disable a user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.User "Disabled user"
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {string} string "Not an admin or own account"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/users/{id}/disable [post]
+func (h *AdminHandler) DisableUser(w http.ResponseWriter, r *http.Request) {
+	h.setDisabled(w, r, h.adminService.DisableUser)
+}
+
+// EnableUser lets a disabled user sign in again.
+// @Summary Enable a user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.User "Enabled user"
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {string} string "Not an admin"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/users/{id}/enable [post]
+func (h *AdminHandler) EnableUser(w http.ResponseWriter, r *http.Request) {
+	h.setDisabled(w, r, h.adminService.EnableUser)
+}
+
+func (h *AdminHandler) setDisabled(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, admin *domain.Session, id int) (*domain.User, error)) {
+	userID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	user, err := change(r.Context(), sessionFromContext(r.Context()), userID)
+	if err != nil {
+		h.log.Error("Failed to change user state", zap.Error(err))
+		h.adminError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.UserDomainToDto(user))
+}
+
+// LogoutUser ends every session of a user.
+// @Summary Sign a user out everywhere
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {string} string "Sessions ended"
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {string} string "Not an admin"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/users/{id}/sessions [delete]
+func (h *AdminHandler) LogoutUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if err = h.adminService.LogoutUser(r.Context(), sessionFromContext(r.Context()), userID); err != nil {
+		h.log.Error("Failed to log out user", zap.Error(err))
+		h.adminError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Sessions ended")
+}
+
+// DeleteUser deletes a user with the reviews and the lists of the user,
+// admins can not delete themselves.
+// @Summary Delete a user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {string} string "User deleted successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {string} string "Not an admin or own account"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/users/{id} [delete]
+func (h *AdminHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if err = h.adminService.DeleteUser(r.Context(), sessionFromContext(r.Context()), userID); err != nil {
+		h.log.Error("Failed to delete user", zap.Error(err))
+		h.adminError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "User deleted successfully")
+}
+
+func (h *AdminHandler) adminError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+	case errors.Is(err, domain.ErrForbidden):
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrInvalidRole):
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+	default:
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+	}
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"github.com/Max425/film-library.git/mocks/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAdminHandler_GetUsers(t *testing.T) {
+	user, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.EditorRole)
+	user.SetCreatedAt(time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC))
+	user.SetDisabledAt(time.Date(2024, time.March, 19, 0, 0, 0, 0, time.UTC))
+	editor := constants.EditorRole
+
+	tests := []struct {
+		name                 string
+		query                string
+		mockBehavior         func(r *mock_handler.MockAdminService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?q=bob&role=2",
+			mockBehavior: func(r *mock_handler.MockAdminService) {
+				r.EXPECT().GetUsers(gomock.Any(), domain.UserFilter{Search: "bob", Role: &editor}, domain.PageRequest{Limit: 20}).
+					Return([]*domain.User{user}, &domain.PageInfo{Total: 1, Limit: 20}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":7,"name":"bob","mail":"bob@example.com","role":2,"created_at":"2024-03-18T00:00:00Z","verified":false,"disabled":true}],"pagination":{"total":1,"limit":20,"offset":0}}`,
+		},
+		{
+			name:                 "Role not a number",
+			query:                "?role=editor",
+			mockBehavior:         func(r *mock_handler.MockAdminService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid role","payload":""}`,
+		},
+		{
+			name:  "Unknown role",
+			query: "?role=9",
+			mockBehavior: func(r *mock_handler.MockAdminService) {
+				r.EXPECT().GetUsers(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, fmt.Errorf("%w: unknown role 9", domain.ErrInvalidFilter))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid filter: unknown role 9","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAdminService := mock_handler.NewMockAdminService(mockCtrl)
+			test.mockBehavior(mockAdminService)
+
+			adminHandler := NewAdminHandler(zap.NewNop(), mockAdminService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/users"+test.query, nil)
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodGet, "/api/users", adminHandler.GetUsers)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAdminHandler_ChangeUserRole(t *testing.T) {
+	session := &domain.Session{UserID: 1, Role: constants.AdminRole}
+	moderator, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.ModeratorRole)
+	moderator.SetCreatedAt(time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name                 string
+		path                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockAdminService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			path:        "/api/users/7/role",
+			requestBody: `{"role":3}`,
+			mockBehavior: func(r *mock_handler.MockAdminService) {
+				r.EXPECT().ChangeUserRole(gomock.Any(), session, 7, constants.ModeratorRole).Return(moderator, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":7,"name":"bob","mail":"bob@example.com","role":3,"created_at":"2024-03-18T00:00:00Z","verified":false,"disabled":false}}`,
+		},
+		{
+			name:                 "No role",
+			path:                 "/api/users/7/role",
+			requestBody:          `{}`,
+			mockBehavior:         func(r *mock_handler.MockAdminService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
+		},
+		{
+			name:        "Unknown role",
+			path:        "/api/users/7/role",
+			requestBody: `{"role":9}`,
+			mockBehavior: func(r *mock_handler.MockAdminService) {
+				r.EXPECT().ChangeUserRole(gomock.Any(), session, 7, 9).Return(nil, fmt.Errorf("%w: 9", domain.ErrInvalidRole))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid user role: 9","payload":""}`,
+		},
+		{
+			name:        "Own role",
+			path:        "/api/users/1/role",
+			requestBody: `{"role":0}`,
+			mockBehavior: func(r *mock_handler.MockAdminService) {
+				r.EXPECT().ChangeUserRole(gomock.Any(), session, 1, constants.UserRole).
+					Return(nil, fmt.Errorf("%w: admins can not change their own role", domain.ErrForbidden))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"forbidden: admins can not change their own role","payload":""}`,
+		},
+		{
+			name:        "No such user",
+			path:        "/api/users/8/role",
+			requestBody: `{"role":2}`,
+			mockBehavior: func(r *mock_handler.MockAdminService) {
+				r.EXPECT().ChangeUserRole(gomock.Any(), session, 8, constants.EditorRole).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAdminService := mock_handler.NewMockAdminService(mockCtrl)
+			test.mockBehavior(mockAdminService)
+
+			adminHandler := NewAdminHandler(zap.NewNop(), mockAdminService)
+
+			req := httptest.NewRequest(http.MethodPut, test.path, bytes.NewBufferString(test.requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, session))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPut, "/api/users/{id}/role", adminHandler.ChangeUserRole)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAdminHandler_DeleteUser(t *testing.T) {
+	session := &domain.Session{UserID: 1, Role: constants.AdminRole}
+
+	tests := []struct {
+		name                 string
+		path                 string
+		mockBehavior         func(r *mock_handler.MockAdminService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			path: "/api/users/7",
+			mockBehavior: func(r *mock_handler.MockAdminService) {
+				r.EXPECT().DeleteUser(gomock.Any(), session, 7).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"User deleted successfully"}`,
+		},
+		{
+			name: "Own account",
+			path: "/api/users/1",
+			mockBehavior: func(r *mock_handler.MockAdminService) {
+				r.EXPECT().DeleteUser(gomock.Any(), session, 1).
+					Return(fmt.Errorf("%w: admins can not delete their own account", domain.ErrForbidden))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"forbidden: admins can not delete their own account","payload":""}`,
+		},
+		{
+			name:                 "Invalid ID",
+			path:                 "/api/users/bob",
+			mockBehavior:         func(r *mock_handler.MockAdminService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid user ID","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAdminService := mock_handler.NewMockAdminService(mockCtrl)
+			test.mockBehavior(mockAdminService)
+
+			adminHandler := NewAdminHandler(zap.NewNop(), mockAdminService)
+
+			req := httptest.NewRequest(http.MethodDelete, test.path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, session))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodDelete, "/api/users/{id}", adminHandler.DeleteUser)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
@@ -7,18 +7,37 @@ import (
 	"github.com/Max425/film-library.git/internal/common/constants"
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
 	"go.uber.org/zap"
 	"io"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 type AuthService interface {
-	GenerateCookie(ctx context.Context, role int) (string, error)
+	GenerateCookie(ctx context.Context, session *domain.Session) (string, error)
 	DeleteCookie(ctx context.Context, session string) error
-	GetSessionValue(ctx context.Context, session string) (int, error)
+	GetSessionValue(ctx context.Context, session string) (*domain.Session, error)
 	CreateUser(ctx context.Context, user *domain.User) (int, error)
-	GetUser(ctx context.Context, mail, password string) (*domain.User, error)
+	SignIn(ctx context.Context, mail, password, ip string) (*domain.User, error)
+	UnlockUser(ctx context.Context, userID int) error
+	GetUserByID(ctx context.Context, id int) (*domain.User, error)
+	ChangePassword(ctx context.Context, userID int, oldPassword, newPassword string) error
+	GetSessions(ctx context.Context, userID int) ([]*domain.Session, error)
+	RevokeSession(ctx context.Context, userID int, id string) error
+	RevokeSessions(ctx context.Context, userID int) error
+	MailVerificationRequired() bool
+	RequestVerification(ctx context.Context, mail string) error
+	VerifyMail(ctx context.Context, token string) error
+	RequestPasswordReset(ctx context.Context, mail string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	IssueTokens(ctx context.Context, session *domain.Session) (*domain.TokenPair, error)
+	RefreshTokens(ctx context.Context, refreshToken, userAgent, ip string) (*domain.TokenPair, error)
+	RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	ParseAccessToken(ctx context.Context, token string) (*domain.Session, error)
 }
 
 type AuthHandler struct {
@@ -41,14 +60,12 @@ func NewAuthHandler(log *zap.Logger, authService AuthService) *AuthHandler {
 // @Produce  json
 // @Param input body dto.SignInInput true "Sign-in input parameters"
 // @Success 200 {object} string
-// @Failure 400,404 {object} string
+// @Failure 400 {object} string
+// @Failure 401 {object} string "Invalid mail or password"
+// @Failure 403 {object} string "Mail is not verified or account is disabled"
+// @Failure 429 {object} string "Too many failed attempts, see Retry-After"
 // @Router /api/auth/login [post]
 func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
-		return
-	}
-
 	var input dto.SignInInput
 	body, _ := io.ReadAll(r.Body)
 	if err := input.UnmarshalJSON(body); err != nil {
@@ -57,19 +74,13 @@ func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.authService.GetUser(r.Context(), input.Mail, input.Password)
+	user, err := h.authService.SignIn(r.Context(), input.Mail, input.Password, clientIP(r))
 	if err != nil {
 		h.log.Error("Failed to get user", zap.Error(err))
-		if errors.Is(err, domain.ErrNotFound) {
-			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusUnauthorized, common.InvalidMailOrPassword.String())
-		} else if errors.Is(err, domain.ErrRequired) {
-			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
-		} else {
-			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
-		}
+		signInError(w, r, err)
 		return
 	}
-	SID, err := h.authService.GenerateCookie(r.Context(), user.Role())
+	SID, err := h.authService.GenerateCookie(r.Context(), newSession(r, user.ID(), user.Role()))
 	if err != nil {
 		h.log.Error("Failed to generate cookie", zap.Error(err))
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
@@ -80,18 +91,131 @@ func (h *AuthHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 	dto.NewSuccessClientResponseDto(r.Context(), w, "login :)")
 }
 
+// signInError answers a failed sign in, a locked sign in tells when to retry.
+func signInError(w http.ResponseWriter, r *http.Request, err error) {
+	var locked *domain.LockedError
+	if errors.As(err, &locked) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusTooManyRequests, err.Error())
+	} else if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrInvalidPassword) {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusUnauthorized, common.InvalidMailOrPassword.String())
+	} else if errors.Is(err, domain.ErrRequired) || errors.Is(err, domain.ErrWeakPassword) {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+	} else if errors.Is(err, domain.ErrUnverified) || errors.Is(err, domain.ErrDisabled) {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusForbidden, err.Error())
+	} else {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+	}
+}
+
+// IssueToken
+// @Summary sign in without cookies
+// @Description Answers a bearer access token for the Authorization header and a refresh token to get the next pair with. The refresh session is listed and revoked like cookie sessions.
+// @Tags auth
+// @ID token
+// @Accept  json
+// @Produce  json
+// @Param input body dto.SignInInput true "Sign-in input parameters"
+// @Success 200 {object} dto.TokenPair
+// @Failure 400 {object} string
+// @Failure 401 {object} string "Invalid mail or password"
+// @Failure 403 {object} string "Mail is not verified or account is disabled"
+// @Failure 429 {object} string "Too many failed attempts, see Retry-After"
+// @Router /api/auth/token [post]
+func (h *AuthHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	var input dto.SignInInput
+	body, _ := io.ReadAll(r.Body)
+	if err := input.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode user", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+
+	user, err := h.authService.SignIn(r.Context(), input.Mail, input.Password, clientIP(r))
+	if err != nil {
+		h.log.Error("Failed to get user", zap.Error(err))
+		signInError(w, r, err)
+		return
+	}
+	pair, err := h.authService.IssueTokens(r.Context(), newSession(r, user.ID(), user.Role()))
+	if err != nil {
+		h.log.Error("Failed to issue tokens", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.TokenPairDomainToDto(pair, time.Now()))
+}
+
+// RefreshToken
+// @Summary swap a refresh token for a new token pair
+// @Description The refresh token works once, the answer carries the next one.
+// @Tags auth
+// @ID refresh-token
+// @Accept  json
+// @Produce  json
+// @Param input body dto.RefreshInput true "Refresh token"
+// @Success 200 {object} dto.TokenPair
+// @Failure 400 {object} string
+// @Failure 401 {object} string "Invalid or expired token"
+// @Failure 403 {object} string "Account is disabled"
+// @Router /api/auth/refresh [post]
+func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var input dto.RefreshInput
+	body, _ := io.ReadAll(r.Body)
+	if err := input.UnmarshalJSON(body); err != nil || input.RefreshToken == "" {
+		h.log.Error("Failed to decode refresh token", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+
+	pair, err := h.authService.RefreshTokens(r.Context(), input.RefreshToken, r.UserAgent(), clientIP(r))
+	if err != nil {
+		h.log.Error("Failed to refresh tokens", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusUnauthorized, domain.ErrInvalidToken.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrDisabled) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusForbidden, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.TokenPairDomainToDto(pair, time.Now()))
+}
+
 // Logout
 // @Summary log out of account
+// @Description Clients of bearer tokens send the refresh token, it is revoked along with its access tokens. Otherwise the session cookie is ended.
 // @Tags auth
 // @ID logout
 // @Accept  json
 // @Produce  json
+// @Param input body dto.RefreshInput false "Refresh token"
 // @Success 200 {object} string
-// @Failure 400,404 {object} string
+// @Failure 400,401,500 {object} string
 // @Router /api/auth/logout [delete]
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	var input dto.RefreshInput
+	if body, _ := io.ReadAll(r.Body); len(body) > 0 {
+		if err := input.UnmarshalJSON(body); err != nil {
+			h.log.Error("Failed to decode refresh token", zap.Error(err))
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+			return
+		}
+	}
+	if input.RefreshToken != "" {
+		if err := h.authService.RevokeRefreshToken(r.Context(), input.RefreshToken); err != nil {
+			h.log.Error("Failed to revoke refresh token", zap.Error(err))
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+			return
+		}
+		dto.NewSuccessClientResponseDto(r.Context(), w, "Logout :)")
 		return
 	}
 
@@ -107,29 +231,24 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session.Expires = time.Now().AddDate(0, 0, -1)
-	session.Path = "/"
-
-	http.SetCookie(w, session)
+	http.SetCookie(w, expireCookie(session))
 	dto.NewSuccessClientResponseDto(r.Context(), w, "Logout :)")
 }
 
 // SignUp
 // @Summary sign up account
+// @Description A link to verify the mail is sent to it. No session starts while the mail has to be verified first.
 // @Tags auth
 // @ID create-account
 // @Accept  json
 // @Produce  json
 // @Param input body dto.SignUpInput true "Sign-up input user"
 // @Success 200 {object} map[string]int
-// @Failure 400,404 {object} string
+// @Failure 400 {object} string "Invalid mail or weak password"
+// @Failure 409 {object} string "Mail already taken"
+// @Failure 500 {object} string
 // @Router /api/auth/sign-up [post]
 func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
-		return
-	}
-
 	var input dto.SignUpInput
 	body, _ := io.ReadAll(r.Body)
 	if err := input.UnmarshalJSON(body); err != nil {
@@ -141,17 +260,28 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	domainUser, err := dto.SignUpInputToDomainUser(&input)
 	if err != nil {
 		h.log.Error("Failed to convert user", zap.Error(err))
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
 		return
 	}
 	userId, err := h.authService.CreateUser(r.Context(), domainUser)
 	if err != nil {
 		h.log.Error("Failed to create user", zap.Error(err))
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		switch {
+		case errors.Is(err, domain.ErrInvalidMail), errors.Is(err, domain.ErrWeakPassword):
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, domain.ErrAlreadyExists):
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusConflict, err.Error())
+		default:
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		}
+		return
+	}
+	if h.authService.MailVerificationRequired() {
+		dto.NewSuccessClientResponseDto(r.Context(), w, map[string]int{"id": userId})
 		return
 	}
 
-	cookie, err := h.authService.GenerateCookie(r.Context(), constants.UserRole)
+	cookie, err := h.authService.GenerateCookie(r.Context(), newSession(r, userId, constants.UserRole))
 	if err != nil {
 		h.log.Error("Failed to generate cookie", zap.Error(err))
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
@@ -162,6 +292,334 @@ func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
 	dto.NewSuccessClientResponseDto(r.Context(), w, map[string]int{"id": userId})
 }
 
+// Me
+// @Summary profile of the signed in user
+// @Tags auth
+// @ID me
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.User
+// @Failure 401,404 {object} string
+// @Router /api/auth/me [get]
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	user, err := h.authService.GetUserByID(r.Context(), sessionFromContext(r.Context()).UserID)
+	if err != nil {
+		h.log.Error("Failed to get user", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.UserDomainToDto(user))
+}
+
+// ChangePassword
+// @Summary change the password, every session of the user ends and a new one starts for the caller
+// @Description The new session is of the kind the caller signed in with: a caller of a bearer token gets a new token pair, a caller of the session cookie gets a new cookie.
+// @Tags auth
+// @ID change-password
+// @Accept  json
+// @Produce  json
+// @Param input body dto.ChangePasswordInput true "Old and new password"
+// @Success 200 {object} dto.TokenPair "For bearer token callers, others get a message"
+// @Failure 400,401,403 {object} string
+// @Router /api/auth/password [put]
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var input dto.ChangePasswordInput
+	body, _ := io.ReadAll(r.Body)
+	if err := input.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode password", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+
+	session := sessionFromContext(r.Context())
+	if err := h.authService.ChangePassword(r.Context(), session.UserID, input.OldPassword, input.NewPassword); err != nil {
+		h.log.Error("Failed to change password", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidPassword) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusForbidden, err.Error())
+		} else if errors.Is(err, domain.ErrRequired) || errors.Is(err, domain.ErrWeakPassword) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		} else {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		}
+		return
+	}
+
+	// sessionMiddleware takes a bearer token over the cookie, so does this
+	if _, ok := bearerToken(r); ok {
+		pair, err := h.authService.IssueTokens(r.Context(), newSession(r, session.UserID, session.Role))
+		if err != nil {
+			h.log.Error("Failed to issue tokens", zap.Error(err))
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+			return
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		dto.NewSuccessClientResponseDto(r.Context(), w, dto.TokenPairDomainToDto(pair, time.Now()))
+		return
+	}
+
+	SID, err := h.authService.GenerateCookie(r.Context(), newSession(r, session.UserID, session.Role))
+	if err != nil {
+		h.log.Error("Failed to generate cookie", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	http.SetCookie(w, createCookie("session_id", SID))
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Password changed")
+}
+
+// RequestPasswordReset
+// @Summary mail a token to set a new password, the answer is the same whether the account exists or not
+// @Tags auth
+// @ID request-password-reset
+// @Accept  json
+// @Produce  json
+// @Param input body dto.MailInput true "Mail of the account"
+// @Success 200 {object} string
+// @Failure 400,500 {object} string
+// @Router /api/auth/password/reset [post]
+func (h *AuthHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var input dto.MailInput
+	body, _ := io.ReadAll(r.Body)
+	if err := input.UnmarshalJSON(body); err != nil || input.Mail == "" {
+		h.log.Error("Failed to decode mail", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(r.Context(), input.Mail); err != nil {
+		h.log.Error("Failed to request password reset", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "If the account exists, a mail is on its way")
+}
+
+// ResetPassword
+// @Summary set a new password with the mailed token, every session of the user ends
+// @Tags auth
+// @ID reset-password
+// @Accept  json
+// @Produce  json
+// @Param input body dto.ResetPasswordInput true "Token from the mail and new password"
+// @Success 200 {object} string
+// @Failure 400,500 {object} string
+// @Router /api/auth/password/reset/confirm [post]
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var input dto.ResetPasswordInput
+	body, _ := io.ReadAll(r.Body)
+	if err := input.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode password reset", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+
+	if err := h.authService.ResetPassword(r.Context(), input.Token, input.NewPassword); err != nil {
+		h.log.Error("Failed to reset password", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrRequired) || errors.Is(err, domain.ErrWeakPassword) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		} else {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		}
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Password changed")
+}
+
+// RequestVerification
+// @Summary mail a new link to verify the mail, the answer is the same whether the account exists or not
+// @Tags auth
+// @ID request-verification
+// @Accept  json
+// @Produce  json
+// @Param input body dto.MailInput true "Mail of the account"
+// @Success 200 {object} string
+// @Failure 400,500 {object} string
+// @Router /api/auth/verify [post]
+func (h *AuthHandler) RequestVerification(w http.ResponseWriter, r *http.Request) {
+	var input dto.MailInput
+	body, _ := io.ReadAll(r.Body)
+	if err := input.UnmarshalJSON(body); err != nil || input.Mail == "" {
+		h.log.Error("Failed to decode mail", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+
+	if err := h.authService.RequestVerification(r.Context(), input.Mail); err != nil {
+		h.log.Error("Failed to request verification", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "If the account exists and is not verified, a mail is on its way")
+}
+
+// VerifyMail
+// @Summary verify the mail with the link sent to it
+// @Tags auth
+// @ID verify-mail
+// @Accept  json
+// @Produce  json
+// @Param token query string true "Token from the link"
+// @Success 200 {object} string
+// @Failure 400,500 {object} string
+// @Router /api/auth/verify [get]
+func (h *AuthHandler) VerifyMail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, domain.ErrInvalidToken.Error())
+		return
+	}
+
+	if err := h.authService.VerifyMail(r.Context(), token); err != nil {
+		h.log.Error("Failed to verify mail", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidToken) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		} else {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		}
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Mail verified")
+}
+
+// UnlockUser
+// @Summary lift the sign in lock of a user after too many failed attempts
+// @Tags auth
+// @ID unlock-user
+// @Accept  json
+// @Produce  json
+// @Param id path int true "User ID"
+// @Success 200 {object} string
+// @Failure 400,401,403,404,500 {object} string
+// @Router /api/users/{id}/unlock [post]
+func (h *AuthHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if err = h.authService.UnlockUser(r.Context(), userID); err != nil {
+		h.log.Error("Failed to unlock user", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "User unlocked")
+}
+
+// GetSessions
+// @Summary active sessions of the signed in user, the latest started first
+// @Tags auth
+// @ID sessions
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} []dto.Session
+// @Failure 401 {object} string
+// @Router /api/auth/sessions [get]
+func (h *AuthHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	current := sessionFromContext(r.Context())
+	sessions, err := h.authService.GetSessions(r.Context(), current.UserID)
+	if err != nil {
+		h.log.Error("Failed to get sessions", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	data := make([]*dto.Session, len(sessions))
+	for i, session := range sessions {
+		data[i] = dto.SessionDomainToDto(session, session.ID == current.ID)
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, data)
+}
+
+// RevokeSession
+// @Summary end a session of the signed in user
+// @Tags auth
+// @ID revoke-session
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Session ID"
+// @Success 200 {object} string
+// @Failure 401,404 {object} string
+// @Router /api/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	current := sessionFromContext(r.Context())
+	id := router.Param(r, "id")
+	if err := h.authService.RevokeSession(r.Context(), current.UserID, id); err != nil {
+		h.log.Error("Failed to revoke session", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	if id == current.ID {
+		if cookie, err := r.Cookie("session_id"); err == nil {
+			http.SetCookie(w, expireCookie(cookie))
+		}
+	}
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Session revoked")
+}
+
+// RevokeSessions
+// @Summary log out everywhere, every session of the signed in user ends
+// @Tags auth
+// @ID revoke-sessions
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} string
+// @Failure 401 {object} string
+// @Router /api/auth/sessions [delete]
+func (h *AuthHandler) RevokeSessions(w http.ResponseWriter, r *http.Request) {
+	if err := h.authService.RevokeSessions(r.Context(), sessionFromContext(r.Context()).UserID); err != nil {
+		h.log.Error("Failed to revoke sessions", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	if cookie, err := r.Cookie("session_id"); err == nil {
+		http.SetCookie(w, expireCookie(cookie))
+	}
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Logged out everywhere")
+}
+
+// newSession describes the session of the user signing in with the request.
+func newSession(r *http.Request, userID, role int) *domain.Session {
+	return &domain.Session{
+		UserID:    userID,
+		Role:      role,
+		UserAgent: r.UserAgent(),
+		IP:        clientIP(r),
+	}
+}
+
+// clientIP returns the address of the client, loggingMiddleware has already
+// taken it from a trusted proxy.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func createCookie(name, SID string) *http.Cookie {
 	return &http.Cookie{
 		Name:     name,
@@ -171,3 +629,9 @@ func createCookie(name, SID string) *http.Cookie {
 		HttpOnly: true,
 	}
 }
+
+func expireCookie(cookie *http.Cookie) *http.Cookie {
+	cookie.Expires = time.Now().AddDate(0, 0, -1)
+	cookie.Path = "/"
+	return cookie
+}
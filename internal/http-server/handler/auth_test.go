@@ -2,9 +2,13 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/common/constants"
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
 	"github.com/Max425/film-library.git/mocks/service"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +16,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestAuthHandler_SignIn(t *testing.T) {
@@ -27,14 +32,15 @@ func TestAuthHandler_SignIn(t *testing.T) {
 		mockBehavior         func(r *mock_handler.MockAuthService, input dto.SignInInput)
 		expectedStatusCode   int
 		expectedResponseBody string
+		expectedRetryAfter   string
 	}{
 		{
 			name:          "Ok",
 			requestMethod: http.MethodPost,
 			requestBody:   `{"mail": "user@mail.ru", "password": "qwerty"}`,
 			mockBehavior: func(r *mock_handler.MockAuthService, input dto.SignInInput) {
-				r.EXPECT().GetUser(gomock.Any(), input.Mail, input.Password).Return(mockUser, nil)
-				r.EXPECT().GenerateCookie(gomock.Any(), mockUser.Role()).Return("sessionID", nil)
+				r.EXPECT().SignIn(gomock.Any(), input.Mail, input.Password, gomock.Any()).Return(mockUser, nil)
+				r.EXPECT().GenerateCookie(gomock.Any(), &domain.Session{UserID: mockUser.ID(), Role: mockUser.Role()}).Return("sessionID", nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"status":200,"message":"success","payload":"login :)"}`,
@@ -43,7 +49,7 @@ func TestAuthHandler_SignIn(t *testing.T) {
 			name:                 "Wrong Method",
 			requestMethod:        http.MethodGet,
 			mockBehavior:         func(r *mock_handler.MockAuthService, input dto.SignInInput) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusMethodNotAllowed,
 			expectedResponseBody: `{"status":405,"message":"Method Not Allowed","payload":""}`,
 		},
 		{
@@ -51,9 +57,41 @@ func TestAuthHandler_SignIn(t *testing.T) {
 			requestMethod:        http.MethodPost,
 			requestBody:          `{"mail": "user@mail.ru"`,
 			mockBehavior:         func(r *mock_handler.MockAuthService, input dto.SignInInput) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
 		},
+		{
+			name:          "Unverified",
+			requestMethod: http.MethodPost,
+			requestBody:   `{"mail": "user@mail.ru", "password": "qwerty"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService, input dto.SignInInput) {
+				r.EXPECT().SignIn(gomock.Any(), input.Mail, input.Password, gomock.Any()).Return(nil, domain.ErrUnverified)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"mail is not verified","payload":""}`,
+		},
+		{
+			name:          "Wrong password",
+			requestMethod: http.MethodPost,
+			requestBody:   `{"mail": "user@mail.ru", "password": "qwerty"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService, input dto.SignInInput) {
+				r.EXPECT().SignIn(gomock.Any(), input.Mail, input.Password, gomock.Any()).Return(nil, domain.ErrInvalidPassword)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":401,"message":"invalid mail or password","payload":""}`,
+		},
+		{
+			name:          "Locked",
+			requestMethod: http.MethodPost,
+			requestBody:   `{"mail": "user@mail.ru", "password": "qwerty"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService, input dto.SignInInput) {
+				r.EXPECT().SignIn(gomock.Any(), input.Mail, input.Password, gomock.Any()).
+					Return(nil, &domain.LockedError{RetryAfter: 90*time.Second + 300*time.Millisecond})
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedResponseBody: `{"status":429,"message":"too many failed attempts, retry in 1m30s","payload":""}`,
+			expectedRetryAfter:   "91",
+		},
 	}
 
 	for _, test := range tests {
@@ -73,18 +111,44 @@ func TestAuthHandler_SignIn(t *testing.T) {
 			}
 			rr := httptest.NewRecorder()
 
-			authHandler.SignIn(rr, req)
+			rt := router.New()
+			rt.MethodNotAllowed = http.HandlerFunc((&Handler{}).MethodNotAllowed)
+			rt.HandleFunc(http.MethodPost, "/api/auth/login", authHandler.SignIn)
+			rt.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.expectedStatusCode, rr.Code)
 			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+			assert.Equal(t, test.expectedRetryAfter, rr.Header().Get("Retry-After"))
 		})
 	}
 }
 
+func TestAuthHandler_SignIn_SpoofedAddress(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+	mockAuthService.EXPECT().SignIn(gomock.Any(), "user@mail.ru", "qwerty", "192.0.2.1").Return(nil, domain.ErrInvalidPassword)
+
+	m := NewMiddleware(zap.NewNop(), mockAuthService, false, nil)
+	authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewBufferString(`{"mail": "user@mail.ru", "password": "qwerty"}`))
+	req.RemoteAddr = "192.0.2.1:54321"
+	req.Header.Set("X-Real-IP", "198.51.100.7")
+	req.Header.Set("X-Forwarded-For", "198.51.100.8")
+	rr := httptest.NewRecorder()
+
+	m.loggingMiddleware(http.HandlerFunc(authHandler.SignIn)).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
 func TestAuthHandler_Logout(t *testing.T) {
 	tests := []struct {
 		name                 string
 		requestMethod        string
+		requestBody          string
 		cookie               *http.Cookie
 		mockBehavior         func(r *mock_handler.MockAuthService, cookie *http.Cookie)
 		expectedStatusCode   int
@@ -113,15 +177,43 @@ func TestAuthHandler_Logout(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockAuthService, cookie *http.Cookie) {
 				r.EXPECT().DeleteCookie(gomock.Any(), cookie.Value).Return(errors.New("error"))
 			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
+		},
+		{
+			name:          "Refresh token",
+			requestMethod: http.MethodDelete,
+			requestBody:   `{"refresh_token":"token"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService, cookie *http.Cookie) {
+				r.EXPECT().RevokeRefreshToken(gomock.Any(), "token").Return(nil)
+			},
 			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"Logout :)"}`,
+		},
+		{
+			name:          "Refresh token not revoked",
+			requestMethod: http.MethodDelete,
+			requestBody:   `{"refresh_token":"token"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService, cookie *http.Cookie) {
+				r.EXPECT().RevokeRefreshToken(gomock.Any(), "token").Return(errors.New("error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
+		{
+			name:                 "Broken body",
+			requestMethod:        http.MethodDelete,
+			requestBody:          `{"refresh_token":`,
+			mockBehavior:         func(r *mock_handler.MockAuthService, cookie *http.Cookie) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
+		},
 		{
 			name:                 "No cookie",
 			requestMethod:        http.MethodDelete,
 			cookie:               nil,
 			mockBehavior:         func(r *mock_handler.MockAuthService, cookie *http.Cookie) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusUnauthorized,
 			expectedResponseBody: `{"status":401,"message":"no session","payload":""}`,
 		},
 	}
@@ -137,7 +229,7 @@ func TestAuthHandler_Logout(t *testing.T) {
 			logger := zap.NewNop()
 			authHandler := NewAuthHandler(logger, mockAuthService)
 
-			req, err := http.NewRequest(test.requestMethod, "/api/auth/logout", nil)
+			req, err := http.NewRequest(test.requestMethod, "/api/auth/logout", bytes.NewBufferString(test.requestBody))
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -155,3 +247,704 @@ func TestAuthHandler_Logout(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandler_SignUp(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"name":"bob","mail":"bob@example.com","password":"Popcorn-2024"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(3, nil)
+				r.EXPECT().MailVerificationRequired().Return(false)
+				r.EXPECT().GenerateCookie(gomock.Any(), &domain.Session{UserID: 3, Role: constants.UserRole, IP: "192.0.2.1"}).Return("sessionID", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":3}}`,
+		},
+		{
+			name:        "Ok, verification required",
+			requestBody: `{"name":"bob","mail":"bob@example.com","password":"Popcorn-2024"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(3, nil)
+				r.EXPECT().MailVerificationRequired().Return(true)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":3}}`,
+		},
+		{
+			name:                 "Missing name",
+			requestBody:          `{"mail":"bob@example.com","password":"Popcorn-2024"}`,
+			mockBehavior:         func(r *mock_handler.MockAuthService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"required parameter is omitted: name is required","payload":""}`,
+		},
+		{
+			name:        "Invalid mail",
+			requestBody: `{"name":"bob","mail":"bob","password":"Popcorn-2024"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(0, domain.ValidateMail("bob"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid mail: \"bob\" is not an email address","payload":""}`,
+		},
+		{
+			name:        "Weak password",
+			requestBody: `{"name":"bob","mail":"bob@example.com","password":"qwerty"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).
+					Return(0, fmt.Errorf("%w: password should be at least 8 characters long", domain.ErrWeakPassword))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"weak password: password should be at least 8 characters long","payload":""}`,
+		},
+		{
+			name:        "Mail taken",
+			requestBody: `{"name":"bob","mail":"bob@example.com","password":"Popcorn-2024"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).
+					Return(0, fmt.Errorf("%w: user with this mail", domain.ErrAlreadyExists))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":409,"message":"already exists: user with this mail","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/sign-up", bytes.NewBufferString(test.requestBody))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/auth/sign-up", authHandler.SignUp)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAuthHandler_Me(t *testing.T) {
+	mockUser, _ := domain.NewUser(7, "bob", "bob@example.com", "password", "", 0)
+	mockUser.Sanitize()
+	mockUser.SetCreatedAt(time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name                 string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().GetUserByID(gomock.Any(), 7).Return(mockUser, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":7,"name":"bob","mail":"bob@example.com","role":0,"created_at":"2024-03-18T12:00:00Z","verified":false,"disabled":false}}`,
+		},
+		{
+			name: "User deleted",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().GetUserByID(gomock.Any(), 7).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/me", nil)
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, &domain.Session{UserID: 7, Role: constants.UserRole}))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodGet, "/api/auth/me", authHandler.Me)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+	req.RemoteAddr = "192.0.2.1:54321"
+	assert.Equal(t, "192.0.2.1", clientIP(req))
+
+	req.RemoteAddr = "2001:db8::1"
+	assert.Equal(t, "2001:db8::1", clientIP(req))
+}
+
+func TestAuthHandler_GetSessions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	createdAt := time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)
+	current := &domain.Session{ID: "aaaa", UserID: 7, CreatedAt: createdAt, UserAgent: "Firefox", IP: "10.0.0.1"}
+	other := &domain.Session{ID: "bbbb", UserID: 7, CreatedAt: createdAt.Add(-time.Hour), UserAgent: "curl/8.0", IP: "10.0.0.2"}
+
+	mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+	mockAuthService.EXPECT().GetSessions(gomock.Any(), 7).Return([]*domain.Session{current, other}, nil)
+
+	authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/sessions", nil)
+	req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, current))
+	rr := httptest.NewRecorder()
+
+	rt := router.New()
+	rt.HandleFunc(http.MethodGet, "/api/auth/sessions", authHandler.GetSessions)
+	rt.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"status":200,"message":"success","payload":[{"id":"aaaa","created_at":"2024-03-18T12:00:00Z","user_agent":"Firefox","ip":"10.0.0.1","current":true},{"id":"bbbb","created_at":"2024-03-18T11:00:00Z","user_agent":"curl/8.0","ip":"10.0.0.2","current":false}]}`, rr.Body.String())
+}
+
+func TestAuthHandler_RevokeSession(t *testing.T) {
+	tests := []struct {
+		name                 string
+		id                   string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedCookie       bool
+	}{
+		{
+			name: "Other session",
+			id:   "bbbb",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().RevokeSession(gomock.Any(), 7, "bbbb").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"Session revoked"}`,
+		},
+		{
+			name: "Current session",
+			id:   "aaaa",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().RevokeSession(gomock.Any(), 7, "aaaa").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"Session revoked"}`,
+			expectedCookie:       true,
+		},
+		{
+			name: "Unknown session",
+			id:   "cccc",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().RevokeSession(gomock.Any(), 7, "cccc").Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/auth/sessions/"+test.id, nil)
+			req.AddCookie(&http.Cookie{Name: "session_id", Value: "sid"})
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, &domain.Session{ID: "aaaa", UserID: 7}))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodDelete, "/api/auth/sessions/{id}", authHandler.RevokeSession)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+			assert.Equal(t, test.expectedCookie, rr.Header().Get("Set-Cookie") != "")
+		})
+	}
+}
+
+func TestAuthHandler_ChangePassword(t *testing.T) {
+	tests := []struct {
+		name                 string
+		authorization        string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedCookie       string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"old_password":"old","new_password":"new"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().ChangePassword(gomock.Any(), 7, "old", "new").Return(nil)
+				r.EXPECT().GenerateCookie(gomock.Any(), gomock.Any()).Return("newSID", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"Password changed"}`,
+			expectedCookie:       "newSID",
+		},
+		{
+			name:          "Ok with bearer token",
+			authorization: "Bearer access",
+			requestBody:   `{"old_password":"old","new_password":"new"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().ChangePassword(gomock.Any(), 7, "old", "new").Return(nil)
+				r.EXPECT().IssueTokens(gomock.Any(), &domain.Session{UserID: 7, IP: "192.0.2.1"}).
+					DoAndReturn(func(ctx context.Context, session *domain.Session) (*domain.TokenPair, error) {
+						return &domain.TokenPair{AccessToken: "newAccess", ExpiresAt: time.Now().Add(15 * time.Minute), RefreshToken: "newRefresh"}, nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"access_token":"newAccess","token_type":"Bearer","expires_in":900,"refresh_token":"newRefresh"}}`,
+		},
+		{
+			name:        "Wrong old password",
+			requestBody: `{"old_password":"wrong","new_password":"new"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().ChangePassword(gomock.Any(), 7, "wrong", "new").Return(domain.ErrInvalidPassword)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"invalid password","payload":""}`,
+		},
+		{
+			name:        "Weak new password",
+			requestBody: `{"old_password":"old","new_password":"new"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().ChangePassword(gomock.Any(), 7, "old", "new").
+					Return(fmt.Errorf("%w: password is too common", domain.ErrWeakPassword))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"weak password: password is too common","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/auth/password", bytes.NewBufferString(test.requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, &domain.Session{ID: "aaaa", UserID: 7}))
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPut, "/api/auth/password", authHandler.ChangePassword)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+			var cookie string
+			for _, c := range rr.Result().Cookies() {
+				if c.Name == "session_id" {
+					cookie = c.Value
+				}
+			}
+			assert.Equal(t, test.expectedCookie, cookie)
+		})
+	}
+}
+
+func TestAuthHandler_RequestPasswordReset(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"mail":"bob@example.com"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().RequestPasswordReset(gomock.Any(), "bob@example.com").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"If the account exists, a mail is on its way"}`,
+		},
+		{
+			name:                 "No mail",
+			requestBody:          `{}`,
+			mockBehavior:         func(r *mock_handler.MockAuthService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/password/reset", bytes.NewBufferString(test.requestBody))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/auth/password/reset", authHandler.RequestPasswordReset)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAuthHandler_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"token":"abc","new_password":"Popcorn-2024"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().ResetPassword(gomock.Any(), "abc", "Popcorn-2024").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"Password changed"}`,
+		},
+		{
+			name:        "Invalid token",
+			requestBody: `{"token":"abc","new_password":"Popcorn-2024"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().ResetPassword(gomock.Any(), "abc", "Popcorn-2024").Return(domain.ErrInvalidToken)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid or expired token","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/password/reset/confirm", bytes.NewBufferString(test.requestBody))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/auth/password/reset/confirm", authHandler.ResetPassword)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAuthHandler_VerifyMail(t *testing.T) {
+	tests := []struct {
+		name                 string
+		target               string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Ok",
+			target: "/api/auth/verify?token=abc",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().VerifyMail(gomock.Any(), "abc").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"Mail verified"}`,
+		},
+		{
+			name:   "Used token",
+			target: "/api/auth/verify?token=abc",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().VerifyMail(gomock.Any(), "abc").Return(domain.ErrInvalidToken)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid or expired token","payload":""}`,
+		},
+		{
+			name:                 "No token",
+			target:               "/api/auth/verify",
+			mockBehavior:         func(r *mock_handler.MockAuthService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid or expired token","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodGet, test.target, nil)
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodGet, "/api/auth/verify", authHandler.VerifyMail)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAuthHandler_UnlockUser(t *testing.T) {
+	tests := []struct {
+		name                 string
+		target               string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Ok",
+			target: "/api/users/7/unlock",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().UnlockUser(gomock.Any(), 7).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"User unlocked"}`,
+		},
+		{
+			name:   "No such user",
+			target: "/api/users/7/unlock",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().UnlockUser(gomock.Any(), 7).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:                 "Invalid ID",
+			target:               "/api/users/abc/unlock",
+			mockBehavior:         func(r *mock_handler.MockAuthService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid user ID","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodPost, test.target, nil)
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/users/{id}/unlock", authHandler.UnlockUser)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAuthHandler_IssueToken(t *testing.T) {
+	mockUser, _ := domain.NewUser(1, "bob", "user@mail.ru", "password", "", constants.EditorRole)
+
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"mail": "user@mail.ru", "password": "qwerty"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().SignIn(gomock.Any(), "user@mail.ru", "qwerty", gomock.Any()).Return(mockUser, nil)
+				r.EXPECT().IssueTokens(gomock.Any(), &domain.Session{UserID: 1, Role: constants.EditorRole, UserAgent: "app/1.0", IP: "192.0.2.1"}).
+					DoAndReturn(func(ctx context.Context, session *domain.Session) (*domain.TokenPair, error) {
+						return &domain.TokenPair{AccessToken: "access", ExpiresAt: time.Now().Add(15 * time.Minute), RefreshToken: "refresh"}, nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"access_token":"access","token_type":"Bearer","expires_in":900,"refresh_token":"refresh"}}`,
+		},
+		{
+			name:        "Wrong password",
+			requestBody: `{"mail": "user@mail.ru", "password": "qwerty"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().SignIn(gomock.Any(), "user@mail.ru", "qwerty", gomock.Any()).Return(nil, domain.ErrInvalidPassword)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":401,"message":"invalid mail or password","payload":""}`,
+		},
+		{
+			name:        "Disabled",
+			requestBody: `{"mail": "user@mail.ru", "password": "qwerty"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().SignIn(gomock.Any(), "user@mail.ru", "qwerty", gomock.Any()).Return(nil, domain.ErrDisabled)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"account is disabled","payload":""}`,
+		},
+		{
+			name:                 "Invalid JSON",
+			requestBody:          `{"mail": "user@mail.ru"`,
+			mockBehavior:         func(r *mock_handler.MockAuthService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/token", bytes.NewBufferString(test.requestBody))
+			req.Header.Set("User-Agent", "app/1.0")
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/auth/token", authHandler.IssueToken)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"refresh_token": "old"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().RefreshTokens(gomock.Any(), "old", "app/1.0", "192.0.2.1").
+					DoAndReturn(func(ctx context.Context, refreshToken, userAgent, ip string) (*domain.TokenPair, error) {
+						return &domain.TokenPair{AccessToken: "access", ExpiresAt: time.Now().Add(15 * time.Minute), RefreshToken: "new"}, nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"access_token":"access","token_type":"Bearer","expires_in":900,"refresh_token":"new"}}`,
+		},
+		{
+			name:        "Used token",
+			requestBody: `{"refresh_token": "old"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().RefreshTokens(gomock.Any(), "old", "app/1.0", "192.0.2.1").Return(nil, domain.ErrInvalidToken)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":401,"message":"invalid or expired token","payload":""}`,
+		},
+		{
+			name:        "Deleted user",
+			requestBody: `{"refresh_token": "old"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().RefreshTokens(gomock.Any(), "old", "app/1.0", "192.0.2.1").Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":401,"message":"invalid or expired token","payload":""}`,
+		},
+		{
+			name:        "Disabled user",
+			requestBody: `{"refresh_token": "old"}`,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().RefreshTokens(gomock.Any(), "old", "app/1.0", "192.0.2.1").Return(nil, domain.ErrDisabled)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"account is disabled","payload":""}`,
+		},
+		{
+			name:                 "No token",
+			requestBody:          `{}`,
+			mockBehavior:         func(r *mock_handler.MockAuthService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			authHandler := NewAuthHandler(zap.NewNop(), mockAuthService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", bytes.NewBufferString(test.requestBody))
+			req.Header.Set("User-Agent", "app/1.0")
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/auth/refresh", authHandler.RefreshToken)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"io"
+	"net/http"
+)
+
+// parseCasting reads the film and the actor from the path parameters and the
+// optional role details from the body. An empty body keeps the role as it is.
+func parseCasting(r *http.Request, filmParam, actorParam string) (domain.Casting, error) {
+	filmID, err := router.IntParam(r, filmParam)
+	if err != nil {
+		return domain.Casting{}, errors.New("invalid film ID")
+	}
+	actorID, err := router.IntParam(r, actorParam)
+	if err != nil {
+		return domain.Casting{}, errors.New("invalid actor ID")
+	}
+
+	var role dto.Casting
+	body, _ := io.ReadAll(r.Body)
+	if len(body) > 0 {
+		if err = role.UnmarshalJSON(body); err != nil {
+			return domain.Casting{}, errors.New("invalid role")
+		}
+	}
+	role.FilmID, role.ActorID = filmID, actorID
+
+	return dto.CastingDtoToDomain(&role), nil
+}
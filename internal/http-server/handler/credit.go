@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"io"
+	"net/http"
+)
+
+// parseCredit reads the film and the person from the path parameters. The job
+// comes from the body when there is one, otherwise from the job query parameter.
+func parseCredit(r *http.Request, filmParam, personParam string) (domain.Credit, error) {
+	filmID, err := router.IntParam(r, filmParam)
+	if err != nil {
+		return domain.Credit{}, errors.New("invalid film ID")
+	}
+	personID, err := router.IntParam(r, personParam)
+	if err != nil {
+		return domain.Credit{}, errors.New("invalid person ID")
+	}
+
+	credit := dto.Credit{Job: r.URL.Query().Get("job")}
+	body, _ := io.ReadAll(r.Body)
+	if len(body) > 0 {
+		if err = credit.UnmarshalJSON(body); err != nil {
+			return domain.Credit{}, errors.New("invalid credit")
+		}
+	}
+
+	return domain.Credit{FilmID: filmID, PersonID: personID, Job: domain.Job(credit.Job)}, nil
+}
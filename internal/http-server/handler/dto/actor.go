@@ -6,11 +6,12 @@ import (
 )
 
 type Actor struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Gender    string    `json:"gender"`
-	BirthDate time.Time `json:"birth_date"`
-	Films     []*Film   `json:"films" swaggerignore:"true"`
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Gender    string     `json:"gender"`
+	BirthDate time.Time  `json:"birth_date"`
+	Films     []*Film    `json:"films" swaggerignore:"true"`
+	Roles     []*Casting `json:"roles,omitempty" swaggerignore:"true"`
 }
 
 func ActorDtoToDomain(dtoActor *Actor) (*domain.Actor, error) {
@@ -23,11 +24,17 @@ func ActorDomainToDto(domainActor *domain.Actor) *Actor {
 		filmDTOs[i] = FilmDomainToDto(film)
 	}
 
+	var roleDTOs []*Casting
+	for _, casting := range domainActor.GetRoles() {
+		roleDTOs = append(roleDTOs, CastingDomainToDto(casting))
+	}
+
 	return &Actor{
 		ID:        domainActor.GetId(),
 		Name:      domainActor.GetName(),
 		Gender:    domainActor.GetGender(),
 		BirthDate: domainActor.GetBirthDate(),
 		Films:     filmDTOs,
+		Roles:     roleDTOs,
 	}
 }
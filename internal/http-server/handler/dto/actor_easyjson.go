@@ -73,13 +73,44 @@ func easyjson1a61c37dDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 						if v1 == nil {
 							v1 = new(Film)
 						}
-						easyjson1a61c37dDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(in, v1)
+						(*v1).UnmarshalEasyJSON(in)
 					}
 					out.Films = append(out.Films, v1)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]*Casting, 0, 8)
+					} else {
+						out.Roles = []*Casting{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 *Casting
+					if in.IsNull() {
+						in.Skip()
+						v2 = nil
+					} else {
+						if v2 == nil {
+							v2 = new(Casting)
+						}
+						(*v2).UnmarshalEasyJSON(in)
+					}
+					out.Roles = append(out.Roles, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -121,14 +152,32 @@ func easyjson1a61c37dEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v2, v3 := range in.Films {
-				if v2 > 0 {
+			for v3, v4 := range in.Films {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				if v4 == nil {
+					out.RawString("null")
+				} else {
+					(*v4).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Roles) != 0 {
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Roles {
+				if v5 > 0 {
 					out.RawByte(',')
 				}
-				if v3 == nil {
+				if v6 == nil {
 					out.RawString("null")
 				} else {
-					easyjson1a61c37dEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(out, *v3)
+					(*v6).MarshalEasyJSON(out)
 				}
 			}
 			out.RawByte(']')
@@ -160,126 +209,3 @@ func (v *Actor) UnmarshalJSON(data []byte) error {
 func (v *Actor) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson1a61c37dDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(l, v)
 }
-func easyjson1a61c37dDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(in *jlexer.Lexer, out *Film) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "id":
-			out.ID = int(in.Int())
-		case "title":
-			out.Title = string(in.String())
-		case "description":
-			out.Description = string(in.String())
-		case "release_date":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.ReleaseDate).UnmarshalJSON(data))
-			}
-		case "rating":
-			out.Rating = float64(in.Float64())
-		case "actors":
-			if in.IsNull() {
-				in.Skip()
-				out.Actors = nil
-			} else {
-				in.Delim('[')
-				if out.Actors == nil {
-					if !in.IsDelim(']') {
-						out.Actors = make([]*Actor, 0, 8)
-					} else {
-						out.Actors = []*Actor{}
-					}
-				} else {
-					out.Actors = (out.Actors)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v4 *Actor
-					if in.IsNull() {
-						in.Skip()
-						v4 = nil
-					} else {
-						if v4 == nil {
-							v4 = new(Actor)
-						}
-						(*v4).UnmarshalEasyJSON(in)
-					}
-					out.Actors = append(out.Actors, v4)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson1a61c37dEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(out *jwriter.Writer, in Film) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"id\":"
-		out.RawString(prefix[1:])
-		out.Int(int(in.ID))
-	}
-	{
-		const prefix string = ",\"title\":"
-		out.RawString(prefix)
-		out.String(string(in.Title))
-	}
-	{
-		const prefix string = ",\"description\":"
-		out.RawString(prefix)
-		out.String(string(in.Description))
-	}
-	{
-		const prefix string = ",\"release_date\":"
-		out.RawString(prefix)
-		out.Raw((in.ReleaseDate).MarshalJSON())
-	}
-	{
-		const prefix string = ",\"rating\":"
-		out.RawString(prefix)
-		out.Float64(float64(in.Rating))
-	}
-	{
-		const prefix string = ",\"actors\":"
-		out.RawString(prefix)
-		if in.Actors == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v5, v6 := range in.Actors {
-				if v5 > 0 {
-					out.RawByte(',')
-				}
-				if v6 == nil {
-					out.RawString("null")
-				} else {
-					(*v6).MarshalEasyJSON(out)
-				}
-			}
-			out.RawByte(']')
-		}
-	}
-	out.RawByte('}')
-}
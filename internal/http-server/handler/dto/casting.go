@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/Max425/film-library.git/internal/domain"
+
+// Casting is the part an actor plays in a film. As a request body only the
+// role details are read, the film and the actor come from the path.
+type Casting struct {
+	FilmID   int    `json:"film_id"`
+	ActorID  int    `json:"actor_id"`
+	RoleName string `json:"role_name,omitempty"`
+	Billing  *int   `json:"billing,omitempty"`
+	RoleType string `json:"role_type,omitempty"`
+}
+
+func CastingDtoToDomain(dtoCasting *Casting) domain.Casting {
+	return domain.Casting{
+		FilmID:   dtoCasting.FilmID,
+		ActorID:  dtoCasting.ActorID,
+		RoleName: dtoCasting.RoleName,
+		Billing:  dtoCasting.Billing,
+		RoleType: domain.RoleType(dtoCasting.RoleType),
+	}
+}
+
+func CastingDomainToDto(domainCasting *domain.Casting) *Casting {
+	return &Casting{
+		FilmID:   domainCasting.FilmID,
+		ActorID:  domainCasting.ActorID,
+		RoleName: domainCasting.RoleName,
+		Billing:  domainCasting.Billing,
+		RoleType: string(domainCasting.RoleType),
+	}
+}
@@ -0,0 +1,121 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson6db6d1e9DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(in *jlexer.Lexer, out *Casting) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "film_id":
+			out.FilmID = int(in.Int())
+		case "actor_id":
+			out.ActorID = int(in.Int())
+		case "role_name":
+			out.RoleName = string(in.String())
+		case "billing":
+			if in.IsNull() {
+				in.Skip()
+				out.Billing = nil
+			} else {
+				if out.Billing == nil {
+					out.Billing = new(int)
+				}
+				*out.Billing = int(in.Int())
+			}
+		case "role_type":
+			out.RoleType = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6db6d1e9EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(out *jwriter.Writer, in Casting) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"film_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.FilmID))
+	}
+	{
+		const prefix string = ",\"actor_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.ActorID))
+	}
+	if in.RoleName != "" {
+		const prefix string = ",\"role_name\":"
+		out.RawString(prefix)
+		out.String(string(in.RoleName))
+	}
+	if in.Billing != nil {
+		const prefix string = ",\"billing\":"
+		out.RawString(prefix)
+		out.Int(int(*in.Billing))
+	}
+	if in.RoleType != "" {
+		const prefix string = ",\"role_type\":"
+		out.RawString(prefix)
+		out.String(string(in.RoleType))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Casting) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6db6d1e9EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Casting) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6db6d1e9EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Casting) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6db6d1e9DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Casting) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6db6d1e9DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(l, v)
+}
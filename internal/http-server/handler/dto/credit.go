@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/Max425/film-library.git/internal/domain"
+
+// Credit is a job a person did on a film. As a request body only the job is
+// read, the film and the person come from the path.
+type Credit struct {
+	FilmID     int    `json:"film_id"`
+	PersonID   int    `json:"person_id"`
+	Job        string `json:"job"`
+	FilmTitle  string `json:"film_title,omitempty"`
+	PersonName string `json:"person_name,omitempty"`
+}
+
+func CreditDomainToDto(domainCredit *domain.Credit) *Credit {
+	return &Credit{
+		FilmID:     domainCredit.FilmID,
+		PersonID:   domainCredit.PersonID,
+		Job:        string(domainCredit.Job),
+		FilmTitle:  domainCredit.FilmTitle,
+		PersonName: domainCredit.PersonName,
+	}
+}
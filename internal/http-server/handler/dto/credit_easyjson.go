@@ -0,0 +1,113 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson6c34121fDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(in *jlexer.Lexer, out *Credit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "film_id":
+			out.FilmID = int(in.Int())
+		case "person_id":
+			out.PersonID = int(in.Int())
+		case "job":
+			out.Job = string(in.String())
+		case "film_title":
+			out.FilmTitle = string(in.String())
+		case "person_name":
+			out.PersonName = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6c34121fEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(out *jwriter.Writer, in Credit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"film_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.FilmID))
+	}
+	{
+		const prefix string = ",\"person_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.PersonID))
+	}
+	{
+		const prefix string = ",\"job\":"
+		out.RawString(prefix)
+		out.String(string(in.Job))
+	}
+	if in.FilmTitle != "" {
+		const prefix string = ",\"film_title\":"
+		out.RawString(prefix)
+		out.String(string(in.FilmTitle))
+	}
+	if in.PersonName != "" {
+		const prefix string = ",\"person_name\":"
+		out.RawString(prefix)
+		out.String(string(in.PersonName))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Credit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6c34121fEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Credit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6c34121fEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Credit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6c34121fDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Credit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6c34121fDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(l, v)
+}
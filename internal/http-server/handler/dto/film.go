@@ -6,12 +6,25 @@ import (
 )
 
 type Film struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	ReleaseDate time.Time `json:"release_date"`
-	Rating      float64   `json:"rating"`
-	Actors      []*Actor  `json:"actors" swaggerignore:"true"`
+	ID          int        `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	ReleaseDate time.Time  `json:"release_date"`
+	Rating      float64    `json:"rating"`
+	UserRating  float64    `json:"user_rating,omitempty" swaggerignore:"true"`
+	VoteCount   int        `json:"vote_count,omitempty" swaggerignore:"true"`
+	Actors      []*Actor   `json:"actors" swaggerignore:"true"`
+	Cast        []*Casting `json:"cast,omitempty" swaggerignore:"true"`
+	Crew        []*Credit  `json:"crew,omitempty" swaggerignore:"true"`
+	Genres      []*Genre   `json:"genres,omitempty" swaggerignore:"true"`
+	UserState   *UserState `json:"user_state,omitempty" swaggerignore:"true"`
+}
+
+// UserState tells where the signed in user keeps the film.
+type UserState struct {
+	InWatchlist bool  `json:"in_watchlist"`
+	WatchCount  int   `json:"watch_count"`
+	ListIDs     []int `json:"list_ids"`
 }
 
 func FilmDtoToDomain(dtoFilm *Film) (*domain.Film, error) {
@@ -24,12 +37,42 @@ func FilmDomainToDto(domainFilm *domain.Film) *Film {
 		actorsDTOs[i] = ActorDomainToDto(actor)
 	}
 
+	var castDTOs []*Casting
+	for _, casting := range domainFilm.GetCast() {
+		castDTOs = append(castDTOs, CastingDomainToDto(casting))
+	}
+
+	var crewDTOs []*Credit
+	for _, credit := range domainFilm.GetCrew() {
+		crewDTOs = append(crewDTOs, CreditDomainToDto(credit))
+	}
+
+	var genreDTOs []*Genre
+	for _, genre := range domainFilm.GetGenres() {
+		genreDTOs = append(genreDTOs, GenreDomainToDto(genre))
+	}
+
+	var userState *UserState
+	if state := domainFilm.GetUserState(); state != nil {
+		userState = &UserState{
+			InWatchlist: state.InWatchlist,
+			WatchCount:  state.WatchCount,
+			ListIDs:     state.ListIDs,
+		}
+	}
+
 	return &Film{
 		ID:          domainFilm.GetId(),
 		Title:       domainFilm.GetTitle(),
 		Description: domainFilm.GetDescription(),
 		ReleaseDate: domainFilm.GetReleaseDate(),
 		Rating:      domainFilm.GetRating(),
+		UserRating:  domainFilm.GetUserRating(),
+		VoteCount:   domainFilm.GetVoteCount(),
 		Actors:      actorsDTOs,
+		Cast:        castDTOs,
+		Crew:        crewDTOs,
+		Genres:      genreDTOs,
+		UserState:   userState,
 	}
 }
@@ -18,7 +18,119 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(in *jlexer.Lexer, out *Film) {
+func easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(in *jlexer.Lexer, out *UserState) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "in_watchlist":
+			out.InWatchlist = bool(in.Bool())
+		case "watch_count":
+			out.WatchCount = int(in.Int())
+		case "list_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.ListIDs = nil
+			} else {
+				in.Delim('[')
+				if out.ListIDs == nil {
+					if !in.IsDelim(']') {
+						out.ListIDs = make([]int, 0, 8)
+					} else {
+						out.ListIDs = []int{}
+					}
+				} else {
+					out.ListIDs = (out.ListIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 int
+					v1 = int(in.Int())
+					out.ListIDs = append(out.ListIDs, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(out *jwriter.Writer, in UserState) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"in_watchlist\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.InWatchlist))
+	}
+	{
+		const prefix string = ",\"watch_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.WatchCount))
+	}
+	{
+		const prefix string = ",\"list_ids\":"
+		out.RawString(prefix)
+		if in.ListIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.ListIDs {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserState) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserState) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserState) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserState) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(l, v)
+}
+func easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(in *jlexer.Lexer, out *Film) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -51,6 +163,10 @@ func easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 			}
 		case "rating":
 			out.Rating = float64(in.Float64())
+		case "user_rating":
+			out.UserRating = float64(in.Float64())
+		case "vote_count":
+			out.VoteCount = int(in.Int())
 		case "actors":
 			if in.IsNull() {
 				in.Skip()
@@ -67,21 +183,124 @@ func easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 					out.Actors = (out.Actors)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 *Actor
+					var v4 *Actor
 					if in.IsNull() {
 						in.Skip()
-						v1 = nil
+						v4 = nil
 					} else {
-						if v1 == nil {
-							v1 = new(Actor)
+						if v4 == nil {
+							v4 = new(Actor)
 						}
-						(*v1).UnmarshalEasyJSON(in)
+						(*v4).UnmarshalEasyJSON(in)
 					}
-					out.Actors = append(out.Actors, v1)
+					out.Actors = append(out.Actors, v4)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "cast":
+			if in.IsNull() {
+				in.Skip()
+				out.Cast = nil
+			} else {
+				in.Delim('[')
+				if out.Cast == nil {
+					if !in.IsDelim(']') {
+						out.Cast = make([]*Casting, 0, 8)
+					} else {
+						out.Cast = []*Casting{}
+					}
+				} else {
+					out.Cast = (out.Cast)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 *Casting
+					if in.IsNull() {
+						in.Skip()
+						v5 = nil
+					} else {
+						if v5 == nil {
+							v5 = new(Casting)
+						}
+						(*v5).UnmarshalEasyJSON(in)
+					}
+					out.Cast = append(out.Cast, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "crew":
+			if in.IsNull() {
+				in.Skip()
+				out.Crew = nil
+			} else {
+				in.Delim('[')
+				if out.Crew == nil {
+					if !in.IsDelim(']') {
+						out.Crew = make([]*Credit, 0, 8)
+					} else {
+						out.Crew = []*Credit{}
+					}
+				} else {
+					out.Crew = (out.Crew)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 *Credit
+					if in.IsNull() {
+						in.Skip()
+						v6 = nil
+					} else {
+						if v6 == nil {
+							v6 = new(Credit)
+						}
+						(*v6).UnmarshalEasyJSON(in)
+					}
+					out.Crew = append(out.Crew, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "genres":
+			if in.IsNull() {
+				in.Skip()
+				out.Genres = nil
+			} else {
+				in.Delim('[')
+				if out.Genres == nil {
+					if !in.IsDelim(']') {
+						out.Genres = make([]*Genre, 0, 8)
+					} else {
+						out.Genres = []*Genre{}
+					}
+				} else {
+					out.Genres = (out.Genres)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 *Genre
+					if in.IsNull() {
+						in.Skip()
+						v7 = nil
+					} else {
+						if v7 == nil {
+							v7 = new(Genre)
+						}
+						(*v7).UnmarshalEasyJSON(in)
+					}
+					out.Genres = append(out.Genres, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "user_state":
+			if in.IsNull() {
+				in.Skip()
+				out.UserState = nil
+			} else {
+				if out.UserState == nil {
+					out.UserState = new(UserState)
+				}
+				(*out.UserState).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -92,7 +311,7 @@ func easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 		in.Consumed()
 	}
 }
-func easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(out *jwriter.Writer, in Film) {
+func easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(out *jwriter.Writer, in Film) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -121,6 +340,16 @@ func easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 		out.RawString(prefix)
 		out.Float64(float64(in.Rating))
 	}
+	if in.UserRating != 0 {
+		const prefix string = ",\"user_rating\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.UserRating))
+	}
+	if in.VoteCount != 0 {
+		const prefix string = ",\"vote_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.VoteCount))
+	}
 	{
 		const prefix string = ",\"actors\":"
 		out.RawString(prefix)
@@ -128,42 +357,101 @@ func easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v2, v3 := range in.Actors {
-				if v2 > 0 {
+			for v8, v9 := range in.Actors {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				if v9 == nil {
+					out.RawString("null")
+				} else {
+					(*v9).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Cast) != 0 {
+		const prefix string = ",\"cast\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v10, v11 := range in.Cast {
+				if v10 > 0 {
 					out.RawByte(',')
 				}
-				if v3 == nil {
+				if v11 == nil {
 					out.RawString("null")
 				} else {
-					(*v3).MarshalEasyJSON(out)
+					(*v11).MarshalEasyJSON(out)
 				}
 			}
 			out.RawByte(']')
 		}
 	}
+	if len(in.Crew) != 0 {
+		const prefix string = ",\"crew\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v12, v13 := range in.Crew {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				if v13 == nil {
+					out.RawString("null")
+				} else {
+					(*v13).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Genres) != 0 {
+		const prefix string = ",\"genres\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.Genres {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				if v15 == nil {
+					out.RawString("null")
+				} else {
+					(*v15).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.UserState != nil {
+		const prefix string = ",\"user_state\":"
+		out.RawString(prefix)
+		(*in.UserState).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Film) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&w, v)
+	easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Film) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(w, v)
+	easyjson14b8084aEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Film) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&r, v)
+	easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Film) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(l, v)
+	easyjson14b8084aDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(l, v)
 }
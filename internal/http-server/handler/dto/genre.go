@@ -0,0 +1,43 @@
+package dto
+
+import "github.com/Max425/film-library.git/internal/domain"
+
+type Genre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// GenreCount is a genre with the number of listed films in it.
+type GenreCount struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Facets are counts that help to narrow a list down.
+type Facets struct {
+	Genres []*GenreCount `json:"genres"`
+}
+
+func GenreDtoToDomain(dtoGenre *Genre) (*domain.Genre, error) {
+	return domain.NewGenre(dtoGenre.ID, dtoGenre.Name)
+}
+
+func GenreDomainToDto(domainGenre *domain.Genre) *Genre {
+	return &Genre{
+		ID:   domainGenre.GetId(),
+		Name: domainGenre.GetName(),
+	}
+}
+
+func GenreFacetsDomainToDto(counts []*domain.GenreCount) *Facets {
+	genres := make([]*GenreCount, len(counts))
+	for i, count := range counts {
+		genres[i] = &GenreCount{
+			ID:    count.Genre.GetId(),
+			Name:  count.Genre.GetName(),
+			Count: count.Count,
+		}
+	}
+	return &Facets{Genres: genres}
+}
@@ -0,0 +1,282 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson52fdb84bDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(in *jlexer.Lexer, out *GenreCount) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "name":
+			out.Name = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson52fdb84bEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(out *jwriter.Writer, in GenreCount) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GenreCount) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson52fdb84bEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GenreCount) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson52fdb84bEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GenreCount) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson52fdb84bDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GenreCount) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson52fdb84bDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(l, v)
+}
+func easyjson52fdb84bDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(in *jlexer.Lexer, out *Genre) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson52fdb84bEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(out *jwriter.Writer, in Genre) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Genre) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson52fdb84bEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Genre) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson52fdb84bEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Genre) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson52fdb84bDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Genre) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson52fdb84bDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(l, v)
+}
+func easyjson52fdb84bDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(in *jlexer.Lexer, out *Facets) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "genres":
+			if in.IsNull() {
+				in.Skip()
+				out.Genres = nil
+			} else {
+				in.Delim('[')
+				if out.Genres == nil {
+					if !in.IsDelim(']') {
+						out.Genres = make([]*GenreCount, 0, 8)
+					} else {
+						out.Genres = []*GenreCount{}
+					}
+				} else {
+					out.Genres = (out.Genres)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *GenreCount
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(GenreCount)
+						}
+						(*v1).UnmarshalEasyJSON(in)
+					}
+					out.Genres = append(out.Genres, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson52fdb84bEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(out *jwriter.Writer, in Facets) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"genres\":"
+		out.RawString(prefix[1:])
+		if in.Genres == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Genres {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Facets) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson52fdb84bEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Facets) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson52fdb84bEncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Facets) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson52fdb84bDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Facets) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson52fdb84bDecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(l, v)
+}
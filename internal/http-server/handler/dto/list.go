@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"errors"
+	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type FilmList struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// ShareURL is the read-only link to the list, set only for the owner of a shared list.
+	ShareURL string  `json:"share_url,omitempty"`
+	Films    []*Film `json:"films,omitempty"`
+}
+
+type FilmListInput struct {
+	Name string `json:"name"`
+}
+
+// WatchEntry is an entry of the watched log, watched_at is a date like 2024-03-18.
+type WatchEntry struct {
+	ID        int    `json:"id"`
+	FilmID    int    `json:"film_id"`
+	FilmTitle string `json:"film_title,omitempty"`
+	WatchedAt string `json:"watched_at,omitempty"`
+}
+
+func FilmListInputToDomain(input *FilmListInput, id, userID int) (*domain.FilmList, error) {
+	return domain.NewFilmList(id, userID, input.Name)
+}
+
+// FilmListDomainToDto converts the list, the share link is left out unless withShareURL is set.
+func FilmListDomainToDto(domainList *domain.FilmList, withShareURL bool) *FilmList {
+	var films []*Film
+	for _, film := range domainList.GetFilms() {
+		films = append(films, FilmDomainToDto(film))
+	}
+
+	list := &FilmList{
+		ID:    domainList.GetId(),
+		Name:  domainList.GetName(),
+		Films: films,
+	}
+	if withShareURL && domainList.GetShareToken() != "" {
+		list.ShareURL = constants.Host + "/api/shared/lists/" + domainList.GetShareToken()
+	}
+	return list
+}
+
+func WatchEntryDtoToDomain(dtoEntry *WatchEntry, userID int) (domain.WatchEntry, error) {
+	entry := domain.WatchEntry{UserID: userID, FilmID: dtoEntry.FilmID}
+	if dtoEntry.FilmID < 1 {
+		return entry, errors.New("invalid film ID")
+	}
+	if dtoEntry.WatchedAt != "" {
+		watchedAt, err := time.Parse(dateLayout, dtoEntry.WatchedAt)
+		if err != nil {
+			return entry, errors.New("invalid watched_at")
+		}
+		entry.WatchedAt = watchedAt
+	}
+	return entry, nil
+}
+
+func WatchEntryDomainToDto(domainEntry *domain.WatchEntry) *WatchEntry {
+	return &WatchEntry{
+		ID:        domainEntry.ID,
+		FilmID:    domainEntry.FilmID,
+		FilmTitle: domainEntry.FilmTitle,
+		WatchedAt: domainEntry.WatchedAt.Format(dateLayout),
+	}
+}
@@ -0,0 +1,301 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonEf7cfe30DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(in *jlexer.Lexer, out *WatchEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "film_id":
+			out.FilmID = int(in.Int())
+		case "film_title":
+			out.FilmTitle = string(in.String())
+		case "watched_at":
+			out.WatchedAt = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf7cfe30EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(out *jwriter.Writer, in WatchEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"film_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.FilmID))
+	}
+	if in.FilmTitle != "" {
+		const prefix string = ",\"film_title\":"
+		out.RawString(prefix)
+		out.String(string(in.FilmTitle))
+	}
+	if in.WatchedAt != "" {
+		const prefix string = ",\"watched_at\":"
+		out.RawString(prefix)
+		out.String(string(in.WatchedAt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WatchEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf7cfe30EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WatchEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf7cfe30EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WatchEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf7cfe30DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WatchEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf7cfe30DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(l, v)
+}
+func easyjsonEf7cfe30DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(in *jlexer.Lexer, out *FilmListInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf7cfe30EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(out *jwriter.Writer, in FilmListInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FilmListInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf7cfe30EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FilmListInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf7cfe30EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FilmListInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf7cfe30DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FilmListInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf7cfe30DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(l, v)
+}
+func easyjsonEf7cfe30DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(in *jlexer.Lexer, out *FilmList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "name":
+			out.Name = string(in.String())
+		case "share_url":
+			out.ShareURL = string(in.String())
+		case "films":
+			if in.IsNull() {
+				in.Skip()
+				out.Films = nil
+			} else {
+				in.Delim('[')
+				if out.Films == nil {
+					if !in.IsDelim(']') {
+						out.Films = make([]*Film, 0, 8)
+					} else {
+						out.Films = []*Film{}
+					}
+				} else {
+					out.Films = (out.Films)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *Film
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(Film)
+						}
+						(*v1).UnmarshalEasyJSON(in)
+					}
+					out.Films = append(out.Films, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf7cfe30EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(out *jwriter.Writer, in FilmList) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	if in.ShareURL != "" {
+		const prefix string = ",\"share_url\":"
+		out.RawString(prefix)
+		out.String(string(in.ShareURL))
+	}
+	if len(in.Films) != 0 {
+		const prefix string = ",\"films\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Films {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					(*v3).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FilmList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf7cfe30EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FilmList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf7cfe30EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FilmList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf7cfe30DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FilmList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf7cfe30DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto2(l, v)
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/base64"
+	"github.com/Max425/film-library.git/internal/domain"
+)
+
+type Pagination struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// cursor is the wire form of domain.Cursor, clients get it as an opaque string.
+type cursor struct {
+	Sort     string   `json:"s"`
+	Values   []string `json:"v"`
+	ID       int      `json:"id"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+func PageInfoDomainToDto(info *domain.PageInfo) *Pagination {
+	return &Pagination{
+		Total:      info.Total,
+		Limit:      info.Limit,
+		Offset:     info.Offset,
+		NextCursor: EncodeCursor(info.NextCursor),
+		PrevCursor: EncodeCursor(info.PrevCursor),
+	}
+}
+
+func EncodeCursor(c *domain.Cursor) string {
+	if c == nil {
+		return ""
+	}
+	data, _ := cursor{Sort: c.Sort, Values: c.Values, ID: c.ID, Backward: c.Backward}.MarshalJSON()
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (*domain.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	var c cursor
+	if err = c.UnmarshalJSON(data); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	return &domain.Cursor{Sort: c.Sort, Values: c.Values, ID: c.ID, Backward: c.Backward}, nil
+}
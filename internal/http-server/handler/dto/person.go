@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/Max425/film-library.git/internal/domain"
+
+type Person struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	Credits []*Credit `json:"credits,omitempty" swaggerignore:"true"`
+}
+
+func PersonDtoToDomain(dtoPerson *Person) (*domain.Person, error) {
+	return domain.NewPerson(dtoPerson.ID, dtoPerson.Name, nil)
+}
+
+func PersonDomainToDto(domainPerson *domain.Person) *Person {
+	var creditDTOs []*Credit
+	for _, credit := range domainPerson.GetCredits() {
+		creditDTOs = append(creditDTOs, CreditDomainToDto(credit))
+	}
+
+	return &Person{
+		ID:      domainPerson.GetId(),
+		Name:    domainPerson.GetName(),
+		Credits: creditDTOs,
+	}
+}
@@ -0,0 +1,211 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package dto
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonDb0593a3DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(in *jlexer.Lexer, out *Person) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "name":
+			out.Name = string(in.String())
+		case "credits":
+			if in.IsNull() {
+				in.Skip()
+				out.Credits = nil
+			} else {
+				in.Delim('[')
+				if out.Credits == nil {
+					if !in.IsDelim(']') {
+						out.Credits = make([]*Credit, 0, 8)
+					} else {
+						out.Credits = []*Credit{}
+					}
+				} else {
+					out.Credits = (out.Credits)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 *Credit
+					if in.IsNull() {
+						in.Skip()
+						v1 = nil
+					} else {
+						if v1 == nil {
+							v1 = new(Credit)
+						}
+						easyjsonDb0593a3DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(in, v1)
+					}
+					out.Credits = append(out.Credits, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonDb0593a3EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(out *jwriter.Writer, in Person) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	if len(in.Credits) != 0 {
+		const prefix string = ",\"credits\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Credits {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				if v3 == nil {
+					out.RawString("null")
+				} else {
+					easyjsonDb0593a3EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(out, *v3)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Person) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonDb0593a3EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Person) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonDb0593a3EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Person) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonDb0593a3DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Person) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonDb0593a3DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto(l, v)
+}
+func easyjsonDb0593a3DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(in *jlexer.Lexer, out *Credit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "film_id":
+			out.FilmID = int(in.Int())
+		case "person_id":
+			out.PersonID = int(in.Int())
+		case "job":
+			out.Job = string(in.String())
+		case "film_title":
+			out.FilmTitle = string(in.String())
+		case "person_name":
+			out.PersonName = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonDb0593a3EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandlerDto1(out *jwriter.Writer, in Credit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"film_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.FilmID))
+	}
+	{
+		const prefix string = ",\"person_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.PersonID))
+	}
+	{
+		const prefix string = ",\"job\":"
+		out.RawString(prefix)
+		out.String(string(in.Job))
+	}
+	if in.FilmTitle != "" {
+		const prefix string = ",\"film_title\":"
+		out.RawString(prefix)
+		out.String(string(in.FilmTitle))
+	}
+	if in.PersonName != "" {
+		const prefix string = ",\"person_name\":"
+		out.RawString(prefix)
+		out.String(string(in.PersonName))
+	}
+	out.RawByte('}')
+}
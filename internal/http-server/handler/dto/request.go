@@ -3,6 +3,7 @@ package dto
 import (
 	"context"
 	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
 	"log"
 	"net/http"
 )
@@ -10,12 +11,18 @@ import (
 type RequestInfo struct {
 	Status  int
 	Message string
+	// LegacyStatus keeps the HTTP status at 200 for clients that only read the envelope.
+	LegacyStatus bool
 }
 
 type ClientResponseDto struct {
-	Status  int    `json:"status"`
-	Message string `json:"message"`
-	Payload any    `json:"payload"`
+	Status      int         `json:"status"`
+	Message     string      `json:"message"`
+	Payload     any         `json:"payload"`
+	Pagination  *Pagination `json:"pagination,omitempty"`
+	Fuzzy       bool        `json:"fuzzy,omitempty"`
+	Suggestions []string    `json:"suggestions,omitempty"`
+	Facets      *Facets     `json:"facets,omitempty"`
 }
 
 func NewSuccessClientResponseDto(ctx context.Context, w http.ResponseWriter, payload any) {
@@ -27,6 +34,50 @@ func NewSuccessClientResponseDto(ctx context.Context, w http.ResponseWriter, pay
 	sendData(ctx, w, response, http.StatusOK, "success")
 }
 
+func NewPageClientResponseDto(ctx context.Context, w http.ResponseWriter, payload any, info *domain.PageInfo) {
+	response := ClientResponseDto{
+		Status:     http.StatusOK,
+		Message:    "success",
+		Payload:    payload,
+		Pagination: PageInfoDomainToDto(info),
+	}
+	sendData(ctx, w, response, http.StatusOK, "success")
+}
+
+// NewFacetedPageClientResponseDto sends a page of a list along with the facets
+// of the whole list.
+func NewFacetedPageClientResponseDto(ctx context.Context, w http.ResponseWriter, payload any, info *domain.PageInfo, facets *Facets) {
+	response := ClientResponseDto{
+		Status:     http.StatusOK,
+		Message:    "success",
+		Payload:    payload,
+		Pagination: PageInfoDomainToDto(info),
+		Facets:     facets,
+	}
+	sendData(ctx, w, response, http.StatusOK, "success")
+}
+
+func NewSearchClientResponseDto(ctx context.Context, w http.ResponseWriter, payload any, result *domain.FilmSearchResult) {
+	response := ClientResponseDto{
+		Status:      http.StatusOK,
+		Message:     "success",
+		Payload:     payload,
+		Pagination:  PageInfoDomainToDto(result.Page),
+		Fuzzy:       result.Fuzzy,
+		Suggestions: result.Suggestions,
+	}
+	sendData(ctx, w, response, http.StatusOK, "success")
+}
+
+func NewCreatedClientResponseDto(ctx context.Context, w http.ResponseWriter, payload any) {
+	response := ClientResponseDto{
+		Status:  http.StatusCreated,
+		Message: "success",
+		Payload: payload,
+	}
+	sendData(ctx, w, response, http.StatusCreated, "success")
+}
+
 func NewErrorClientResponseDto(ctx context.Context, w http.ResponseWriter, statusCode int, message string) {
 	response := ClientResponseDto{
 		Status:  statusCode,
@@ -43,15 +94,19 @@ func sendData(ctx context.Context, w http.ResponseWriter, response ClientRespons
 		return
 	}
 
+	httpStatus := statusCode
 	requestInfo, ok := ctx.Value(constants.KeyRequestInfo).(*RequestInfo)
 	if !ok {
 		log.Println("Request info not found in context")
 	} else {
 		requestInfo.Status = statusCode
 		requestInfo.Message = message
+		if requestInfo.LegacyStatus {
+			httpStatus = http.StatusOK
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(httpStatus)
 	w.Write(responseJSON)
 }
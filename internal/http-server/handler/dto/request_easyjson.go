@@ -40,6 +40,8 @@ func easyjson3c9d2b01DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 			out.Status = int(in.Int())
 		case "Message":
 			out.Message = string(in.String())
+		case "LegacyStatus":
+			out.LegacyStatus = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -64,6 +66,11 @@ func easyjson3c9d2b01EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 		out.RawString(prefix)
 		out.String(string(in.Message))
 	}
+	{
+		const prefix string = ",\"LegacyStatus\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.LegacyStatus))
+	}
 	out.RawByte('}')
 }
 
@@ -121,6 +128,51 @@ func easyjson3c9d2b01DecodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 			} else {
 				out.Payload = in.Interface()
 			}
+		case "pagination":
+			if in.IsNull() {
+				in.Skip()
+				out.Pagination = nil
+			} else {
+				if out.Pagination == nil {
+					out.Pagination = new(Pagination)
+				}
+				(*out.Pagination).UnmarshalEasyJSON(in)
+			}
+		case "fuzzy":
+			out.Fuzzy = bool(in.Bool())
+		case "suggestions":
+			if in.IsNull() {
+				in.Skip()
+				out.Suggestions = nil
+			} else {
+				in.Delim('[')
+				if out.Suggestions == nil {
+					if !in.IsDelim(']') {
+						out.Suggestions = make([]string, 0, 4)
+					} else {
+						out.Suggestions = []string{}
+					}
+				} else {
+					out.Suggestions = (out.Suggestions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Suggestions = append(out.Suggestions, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "facets":
+			if in.IsNull() {
+				in.Skip()
+				out.Facets = nil
+			} else {
+				if out.Facets == nil {
+					out.Facets = new(Facets)
+				}
+				(*out.Facets).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -156,6 +208,35 @@ func easyjson3c9d2b01EncodeGithubComMax425FilmLibraryGitInternalHttpServerHandle
 			out.Raw(json.Marshal(in.Payload))
 		}
 	}
+	if in.Pagination != nil {
+		const prefix string = ",\"pagination\":"
+		out.RawString(prefix)
+		(*in.Pagination).MarshalEasyJSON(out)
+	}
+	if in.Fuzzy {
+		const prefix string = ",\"fuzzy\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Fuzzy))
+	}
+	if len(in.Suggestions) != 0 {
+		const prefix string = ",\"suggestions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Suggestions {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Facets != nil {
+		const prefix string = ",\"facets\":"
+		out.RawString(prefix)
+		(*in.Facets).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
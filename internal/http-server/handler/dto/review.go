@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"github.com/Max425/film-library.git/internal/domain"
+	"time"
+)
+
+type Review struct {
+	ID        int       `json:"id"`
+	FilmID    int       `json:"film_id"`
+	UserID    int       `json:"user_id"`
+	Rating    int       `json:"rating"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReviewInput is what the author writes, the rest of the review is set by the server.
+type ReviewInput struct {
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+}
+
+func ReviewInputToDomain(input *ReviewInput, id, filmID, userID int) (*domain.Review, error) {
+	return domain.NewReview(id, filmID, userID, input.Rating, input.Text)
+}
+
+func ReviewDomainToDto(domainReview *domain.Review) *Review {
+	return &Review{
+		ID:        domainReview.GetId(),
+		FilmID:    domainReview.GetFilmID(),
+		UserID:    domainReview.GetUserID(),
+		Rating:    domainReview.GetRating(),
+		Text:      domainReview.GetText(),
+		CreatedAt: domainReview.GetCreatedAt(),
+		UpdatedAt: domainReview.GetUpdatedAt(),
+	}
+}
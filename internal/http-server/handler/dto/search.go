@@ -0,0 +1,19 @@
+package dto
+
+import "github.com/Max425/film-library.git/internal/domain"
+
+type FilmMatch struct {
+	Film
+	Rank                float64 `json:"rank"`
+	TitleHeadline       string  `json:"title_headline"`
+	DescriptionHeadline string  `json:"description_headline"`
+}
+
+func FilmMatchDomainToDto(match *domain.FilmMatch) *FilmMatch {
+	return &FilmMatch{
+		Film:                *FilmDomainToDto(match.Film),
+		Rank:                match.Rank,
+		TitleHeadline:       match.TitleHeadline,
+		DescriptionHeadline: match.DescriptionHeadline,
+	}
+}
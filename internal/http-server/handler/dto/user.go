@@ -3,6 +3,7 @@ package dto
 import (
 	"github.com/Max425/film-library.git/internal/common/constants"
 	"github.com/Max425/film-library.git/internal/domain"
+	"time"
 )
 
 type SignInInput struct {
@@ -16,6 +17,90 @@ type SignUpInput struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// User is the profile of a user, the password never leaves the server.
+type User struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Mail      string    `json:"mail"`
+	Role      int       `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	Verified  bool      `json:"verified"`
+	Disabled  bool      `json:"disabled"`
+}
+
+type ChangePasswordInput struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// MailInput names the account to mail a link or a token to.
+type MailInput struct {
+	Mail string `json:"mail" binding:"required"`
+}
+
+// RoleInput is the role an admin gives a user: 0 user, 1 admin, 2 editor, 3 moderator.
+type RoleInput struct {
+	Role *int `json:"role" binding:"required"`
+}
+
+type ResetPasswordInput struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// TokenPair is the answer to clients signing in without cookies, see RFC 6749 section 5.1.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshInput struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Session is a login of the signed in user, current marks the one the request came with.
+type Session struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+	Current   bool      `json:"current"`
+}
+
 func SignUpInputToDomainUser(signUp *SignUpInput) (*domain.User, error) {
 	return domain.NewUser(0, signUp.Name, signUp.Mail, signUp.Password, "", constants.UserRole)
 }
+
+func UserDomainToDto(domainUser *domain.User) *User {
+	return &User{
+		ID:        domainUser.ID(),
+		Name:      domainUser.Name(),
+		Mail:      domainUser.Mail(),
+		Role:      domainUser.Role(),
+		CreatedAt: domainUser.CreatedAt(),
+		Verified:  domainUser.Verified(),
+		Disabled:  domainUser.Disabled(),
+	}
+}
+
+// TokenPairDomainToDto tells the lifetime of the access token in whole seconds from now.
+func TokenPairDomainToDto(domainPair *domain.TokenPair, now time.Time) *TokenPair {
+	return &TokenPair{
+		AccessToken:  domainPair.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(domainPair.ExpiresAt.Sub(now).Round(time.Second).Seconds()),
+		RefreshToken: domainPair.RefreshToken,
+	}
+}
+
+func SessionDomainToDto(domainSession *domain.Session, current bool) *Session {
+	return &Session{
+		ID:        domainSession.ID,
+		CreatedAt: domainSession.CreatedAt,
+		UserAgent: domainSession.UserAgent,
+		IP:        domainSession.IP,
+		Current:   current,
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/Max425/film-library.git/internal/common"
+	"github.com/Max425/film-library.git/internal/common/constants"
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
 	"go.uber.org/zap"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type FilmService interface {
@@ -18,9 +21,18 @@ type FilmService interface {
 	GetFilmByID(ctx context.Context, id int) (*domain.Film, error)
 	UpdateFilm(ctx context.Context, film *domain.Film) (*domain.Film, error)
 	UpdateFilmActors(ctx context.Context, id int, actorsId []int) (*domain.Film, error)
+	AddFilmActor(ctx context.Context, casting domain.Casting) (*domain.Film, error)
+	RemoveFilmActor(ctx context.Context, filmID, actorID int) (*domain.Film, error)
+	AddFilmCrew(ctx context.Context, credit domain.Credit) (*domain.Film, error)
+	RemoveFilmCrew(ctx context.Context, credit domain.Credit) (*domain.Film, error)
+	UpdateFilmGenres(ctx context.Context, id int, genreIDs []int) (*domain.Film, error)
+	AddFilmGenre(ctx context.Context, filmID, genreID int) (*domain.Film, error)
+	RemoveFilmGenre(ctx context.Context, filmID, genreID int) (*domain.Film, error)
 	DeleteFilm(ctx context.Context, id int) error
-	SearchFilms(ctx context.Context, fragment string) ([]*domain.Film, error)
-	GetAllFilms(ctx context.Context, sortBy, order string) ([]*domain.Film, error)
+	SearchFilms(ctx context.Context, query string, similarity float64, page domain.PageRequest) (*domain.FilmSearchResult, error)
+	GetAllFilms(ctx context.Context, sort []domain.FilmSort, filter domain.FilmFilter, page domain.PageRequest) ([]*domain.Film, *domain.PageInfo, error)
+	GetFilmGenreFacets(ctx context.Context, filter domain.FilmFilter) ([]*domain.GenreCount, error)
+	AddFilmUserStates(ctx context.Context, userID int, films []*domain.Film) error
 }
 
 type FilmHandler struct {
@@ -44,13 +56,9 @@ func NewFilmHandler(log *zap.Logger, filmService FilmService) *FilmHandler {
 // @Success 201 {object} dto.Film "Film created successfully"
 // @Failure 400 {string} string "Bad request"
 // @Failure 500 {string} string "Internal server error"
+// @Router /api/films [post]
 // @Router /api/create_films [post]
 func (h *FilmHandler) CreateFilm(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
-		return
-	}
-
 	var film dto.Film
 	body, _ := io.ReadAll(r.Body)
 	if err := film.UnmarshalJSON(body); err != nil {
@@ -72,25 +80,75 @@ func (h *FilmHandler) CreateFilm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmDomainToDto(createdFilm))
+	dto.NewCreatedClientResponseDto(r.Context(), w, dto.FilmDomainToDto(createdFilm))
+}
+
+// GetFilmByID retrieves a film with its cast.
+// @Summary Retrieve a film by ID
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Success 200 {object} dto.Film "Film with actors"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id} [get]
+func (h *FilmHandler) GetFilmByID(w http.ResponseWriter, r *http.Request) {
+	id, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+		return
+	}
+
+	film, err := h.filmService.GetFilmByID(r.Context(), id)
+	if err != nil {
+		h.log.Error("Failed to get film", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	if !h.addUserStates(w, r, []*domain.Film{film}) {
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmDomainToDto(film))
+}
+
+// addUserStates tells the signed in user where they keep the films. It answers
+// the request itself and returns false when that fails.
+func (h *FilmHandler) addUserStates(w http.ResponseWriter, r *http.Request, films []*domain.Film) bool {
+	session := sessionFromContext(r.Context())
+	if session == nil {
+		return true
+	}
+	if err := h.filmService.AddFilmUserStates(r.Context(), session.UserID, films); err != nil {
+		h.log.Error("Failed to get film user states", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return false
+	}
+	return true
 }
 
 // UpdateFilm updates an existing film.
+// On the legacy route the film ID is taken from the request body.
 // @Summary Update an existing film
 // @Tags films
 // @Accept json
 // @Produce json
+// @Param id path int true "Film ID"
 // @Param input body dto.Film true "Film object to be updated"
 // @Success 200 {object} dto.Film "Film updated successfully"
 // @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
 // @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id} [put]
 // @Router /api/update_films [put]
 func (h *FilmHandler) UpdateFilm(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPut {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
-		return
-	}
-
 	var film dto.Film
 	body, _ := io.ReadAll(r.Body)
 	if err := film.UnmarshalJSON(body); err != nil {
@@ -99,6 +157,15 @@ func (h *FilmHandler) UpdateFilm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if router.Param(r, "id") != "" {
+		id, err := router.IntParam(r, "id")
+		if err != nil {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+			return
+		}
+		film.ID = id
+	}
+
 	domainFilm, err := dto.FilmDtoToDomain(&film)
 	if err != nil {
 		h.log.Error("Failed to convert film", zap.Error(err))
@@ -120,25 +187,83 @@ func (h *FilmHandler) UpdateFilm(w http.ResponseWriter, r *http.Request) {
 	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmDomainToDto(updatedFilm))
 }
 
-// UpdateFilmActors updates an existing film.
-// @Summary Update an existing film
+// PatchFilm partially updates an existing film.
+// Only the fields present in the request body are changed.
+// @Summary Partially update an existing film
 // @Tags films
 // @Accept json
 // @Produce json
 // @Param id path int true "Film ID"
-// @Param input body []int true "id actors for film"
+// @Param input body dto.Film true "Film fields to be updated"
 // @Success 200 {object} dto.Film "Film updated successfully"
 // @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
 // @Failure 500 {string} string "Internal server error"
-// @Router /api/update_films_actors/{id} [post]
-func (h *FilmHandler) UpdateFilmActors(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+// @Router /api/films/{id} [patch]
+func (h *FilmHandler) PatchFilm(w http.ResponseWriter, r *http.Request) {
+	id, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+		return
+	}
+
+	currentFilm, err := h.filmService.GetFilmByID(r.Context(), id)
+	if err != nil {
+		h.log.Error("Failed to get film", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
 		return
 	}
 
-	idStr := r.URL.Path[len("/api/update_films_actors/"):]
-	id, err := strconv.Atoi(idStr)
+	film := dto.FilmDomainToDto(currentFilm)
+	body, _ := io.ReadAll(r.Body)
+	if err = film.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode film", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	film.ID = id
+
+	domainFilm, err := dto.FilmDtoToDomain(film)
+	if err != nil {
+		h.log.Error("Failed to convert film", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updatedFilm, err := h.filmService.UpdateFilm(r.Context(), domainFilm)
+	if err != nil {
+		h.log.Error("Failed to update film", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmDomainToDto(updatedFilm))
+}
+
+// UpdateFilmActors replaces the cast of an existing film. Repeated ids are
+// ignored, unknown ones fail the request and are listed in the message.
+// @Summary Replace the actors of an existing film
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param input body []int true "id actors for film"
+// @Success 200 {object} dto.Film "Film updated successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Film not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/actors [put]
+// @Router /api/update_films_actors/{id} [post]
+func (h *FilmHandler) UpdateFilmActors(w http.ResponseWriter, r *http.Request) {
+	id, err := router.IntParam(r, "id")
 	if err != nil {
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
 		return
@@ -159,6 +284,181 @@ func (h *FilmHandler) UpdateFilmActors(w http.ResponseWriter, r *http.Request) {
 			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
 			return
 		}
+		if errors.Is(err, domain.ErrUnknownActors) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmDomainToDto(updatedFilm))
+}
+
+// AddFilmActor adds one actor to the cast of a film. The optional body sets the
+// role, adding an actor that is already in the cast only updates the role.
+// @Summary Add an actor to the cast of a film
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param actorId path int true "Actor ID"
+// @Param input body dto.Casting false "Role name, billing position and role type: regular, cameo, voice"
+// @Success 200 {object} dto.Film "Film with the updated cast"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Film or actor not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/actors/{actorId} [post]
+func (h *FilmHandler) AddFilmActor(w http.ResponseWriter, r *http.Request) {
+	h.changeFilmActor(w, r, h.filmService.AddFilmActor)
+}
+
+// RemoveFilmActor removes one actor from the cast of a film. Removing an actor
+// that is not in the cast succeeds without changes.
+// @Summary Remove an actor from the cast of a film
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param actorId path int true "Actor ID"
+// @Success 200 {object} dto.Film "Film with the updated cast"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Film not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/actors/{actorId} [delete]
+func (h *FilmHandler) RemoveFilmActor(w http.ResponseWriter, r *http.Request) {
+	h.changeFilmActor(w, r, func(ctx context.Context, casting domain.Casting) (*domain.Film, error) {
+		return h.filmService.RemoveFilmActor(ctx, casting.FilmID, casting.ActorID)
+	})
+}
+
+func (h *FilmHandler) changeFilmActor(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, casting domain.Casting) (*domain.Film, error)) {
+	casting, err := parseCasting(r, "id", "actorId")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	film, err := change(r.Context(), casting)
+	if err != nil {
+		h.log.Error("Failed to change film actor", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidCasting) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmDomainToDto(film))
+}
+
+// AddFilmCrew credits a person with a job on a film. Adding a credit that is
+// already there succeeds without changes.
+// @Summary Add a crew member to a film
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param personId path int true "Person ID"
+// @Param input body dto.Credit true "Job: director, writer, composer, producer"
+// @Success 200 {object} dto.Film "Film with the updated crew"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Film or person not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/crew/{personId} [post]
+func (h *FilmHandler) AddFilmCrew(w http.ResponseWriter, r *http.Request) {
+	h.changeFilmCrew(w, r, h.filmService.AddFilmCrew)
+}
+
+// RemoveFilmCrew removes the credit of a person on a film. Without a job every
+// credit of the person on the film is removed.
+// @Summary Remove a crew member from a film
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param personId path int true "Person ID"
+// @Param job query string false "Job to remove: director, writer, composer, producer"
+// @Success 200 {object} dto.Film "Film with the updated crew"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Film not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/crew/{personId} [delete]
+func (h *FilmHandler) RemoveFilmCrew(w http.ResponseWriter, r *http.Request) {
+	h.changeFilmCrew(w, r, h.filmService.RemoveFilmCrew)
+}
+
+func (h *FilmHandler) changeFilmCrew(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, credit domain.Credit) (*domain.Film, error)) {
+	credit, err := parseCredit(r, "id", "personId")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	film, err := change(r.Context(), credit)
+	if err != nil {
+		h.log.Error("Failed to change film crew", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidCredit) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmDomainToDto(film))
+}
+
+// UpdateFilmGenres replaces the genres of an existing film. Repeated ids are
+// ignored, unknown ones fail the request and are listed in the message.
+// @Summary Replace the genres of an existing film
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param input body []int true "id genres for film"
+// @Success 200 {object} dto.Film "Film updated successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Film not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/genres [put]
+func (h *FilmHandler) UpdateFilmGenres(w http.ResponseWriter, r *http.Request) {
+	id, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+		return
+	}
+
+	var genreIDs []int
+	body, _ := io.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &genreIDs); err != nil {
+		h.log.Error("Failed to decode film genres", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+
+	updatedFilm, err := h.filmService.UpdateFilmGenres(r.Context(), id, genreIDs)
+	if err != nil {
+		h.log.Error("Failed to update film genres", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		if errors.Is(err, domain.ErrUnknownGenres) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
 		return
 	}
@@ -166,6 +466,67 @@ func (h *FilmHandler) UpdateFilmActors(w http.ResponseWriter, r *http.Request) {
 	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmDomainToDto(updatedFilm))
 }
 
+// AddFilmGenre adds one genre to a film. Adding a genre the film already has
+// succeeds without changes.
+// @Summary Add a genre to a film
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param genreId path int true "Genre ID"
+// @Success 200 {object} dto.Film "Film with the updated genres"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Film or genre not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/genres/{genreId} [post]
+func (h *FilmHandler) AddFilmGenre(w http.ResponseWriter, r *http.Request) {
+	h.changeFilmGenre(w, r, h.filmService.AddFilmGenre)
+}
+
+// RemoveFilmGenre removes one genre from a film. Removing a genre the film
+// does not have succeeds without changes.
+// @Summary Remove a genre from a film
+// @Tags films
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param genreId path int true "Genre ID"
+// @Success 200 {object} dto.Film "Film with the updated genres"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Film not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/genres/{genreId} [delete]
+func (h *FilmHandler) RemoveFilmGenre(w http.ResponseWriter, r *http.Request) {
+	h.changeFilmGenre(w, r, h.filmService.RemoveFilmGenre)
+}
+
+func (h *FilmHandler) changeFilmGenre(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, filmID, genreID int) (*domain.Film, error)) {
+	filmID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+		return
+	}
+	genreID, err := router.IntParam(r, "genreId")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid genre ID")
+		return
+	}
+
+	film, err := change(r.Context(), filmID, genreID)
+	if err != nil {
+		h.log.Error("Failed to change film genre", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmDomainToDto(film))
+}
+
 // DeleteFilm deletes an existing film.
 // @Summary Delete an existing film
 // @Tags films
@@ -177,13 +538,7 @@ func (h *FilmHandler) UpdateFilmActors(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {string} string "Internal server error"
 // @Router /api/films/{id} [delete]
 func (h *FilmHandler) DeleteFilm(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
-		return
-	}
-
-	idStr := r.URL.Path[len("/api/films/"):]
-	id, err := strconv.Atoi(idStr)
+	id, err := router.IntParam(r, "id")
 	if err != nil {
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
 		return
@@ -203,93 +558,142 @@ func (h *FilmHandler) DeleteFilm(w http.ResponseWriter, r *http.Request) {
 	dto.NewSuccessClientResponseDto(r.Context(), w, "Film deleted successfully")
 }
 
-// SearchFilms
-// @Summary Search films by pattern
+// SearchFilms finds films by the words of their title, description, cast and
+// crew names. Every word matches as a prefix, results are ordered by relevance.
+// When nothing matches exactly, films with a similar title, actor or crew name
+// are returned along with "did you mean" suggestions.
+// @Summary Full-text search of films
 // @Tags films
 // @Accept json
 // @Produce json
-// @Param pattern path string true "Film pattern"
-// @Success 200 {array} []dto.Film "List of films"
+// @Param q query string true "Search query"
+// @Param similarity query number false "Similarity threshold of the fuzzy search, from 0 to 1, 0.3 by default"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Param offset query int false "Number of films to skip, ignored with cursor"
+// @Param cursor query string false "Cursor from a previous page"
+// @Success 200 {array} []dto.FilmMatch "List of found films"
+// @Failure 400 {string} string "Bad request"
 // @Failure 500 {string} string "Internal server error"
-// @Router /api/search_films/{pattern} [get]
+// @Router /api/search_films [get]
 func (h *FilmHandler) SearchFilms(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		// deprecated /api/search_films/{pattern} form
+		query = router.Param(r, "pattern")
+	}
+	if strings.TrimSpace(query) == "" {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "search query is required")
 		return
 	}
-	pattern := r.URL.Path[len("/api/search_films/"):]
-	films, err := h.filmService.SearchFilms(r.Context(), pattern)
+
+	similarity := constants.DefaultSimilarity
+	if value := r.URL.Query().Get("similarity"); value != "" {
+		var err error
+		if similarity, err = strconv.ParseFloat(value, 64); err != nil {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid similarity")
+			return
+		}
+	}
+
+	page, err := parsePageRequest(r)
 	if err != nil {
-		h.log.Error("Failed to get all films", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.filmService.SearchFilms(r.Context(), query, similarity, page)
+	if err != nil {
+		h.log.Error("Failed to search films", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidCursor) || errors.Is(err, domain.ErrInvalidQuery) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
 		return
 	}
-	data := make([]*dto.Film, len(films))
-	for i, film := range films {
-		data[i] = dto.FilmDomainToDto(film)
+	data := make([]*dto.FilmMatch, len(result.Matches))
+	for i, match := range result.Matches {
+		data[i] = dto.FilmMatchDomainToDto(match)
 	}
 
-	dto.NewSuccessClientResponseDto(r.Context(), w, data)
+	dto.NewSearchClientResponseDto(r.Context(), w, data, result)
 }
 
-// GetAllFilms retrieves all films with optional sorting by title, rating, or release date.
-// By default, films are sorted by rating in descending order.
+// GetAllFilms retrieves all films with optional sorting by title, rating, user rating, or release date.
+// Several sort fields may be given, e.g. sort_by=rating,title&order=desc,asc.
+// By default, films are sorted by rating in descending order. Filters are combined with AND.
+// The response counts the films matching the filters in every genre under facets.
 // @Summary Retrieve all films
 // @Tags films
 // @Accept json
 // @Produce json
-// @Param sort_by query string false "Sort by: title, rating, release_date"
-// @Param order query string false "Sort order: asc, desc"
+// @Param sort_by query string false "Comma separated sort fields: title, rating, user_rating, release_date"
+// @Param order query string false "Comma separated sort orders: asc, desc, one order applies to all fields"
+// @Param released_from query string false "Earliest release date, YYYY-MM-DD"
+// @Param released_to query string false "Latest release date, YYYY-MM-DD"
+// @Param min_rating query number false "Minimum rating"
+// @Param max_rating query number false "Maximum rating"
+// @Param min_user_rating query number false "Minimum mean of the user ratings, zero without votes"
+// @Param max_user_rating query number false "Maximum mean of the user ratings"
+// @Param actor_id query []int false "Keep films all of these actors took part in" collectionFormat(multi)
+// @Param title_prefix query string false "Title prefix, case-insensitive"
+// @Param no_actors query bool false "Keep films without actors"
+// @Param genre_id query []int false "Keep films of these genres" collectionFormat(multi)
+// @Param genre_match query string false "How genres combine: any (default), all"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Param offset query int false "Number of films to skip, ignored with cursor"
+// @Param cursor query string false "Cursor from a previous page"
 // @Success 200 {array} []dto.Film "List of films"
 // @Failure 400 {string} string "Bad request"
 // @Failure 500 {string} string "Internal server error"
 // @Router /api/films [get]
 func (h *FilmHandler) GetAllFilms(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	sort, err := parseFilmSort(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Parse query parameters for sorting
-	sortBy := r.URL.Query().Get("sort_by")
-	order := r.URL.Query().Get("order")
-
-	// Default sorting by rating in descending order
-	if sortBy == "" {
-		sortBy = "rating"
-	}
-	if order == "" {
-		order = "desc"
+	filter, err := parseFilmFilter(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	// Validate order parameter
-	if order != "asc" && order != "desc" {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "Invalid sort order")
+	page, err := parsePageRequest(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
 		return
 	}
-	validSortFields := map[string]bool{
-		"title":        true,
-		"rating":       true,
-		"release_date": true,
-	}
-	if !validSortFields[sortBy] {
-		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "Invalid sort by field")
+
+	// Get films with optional sorting and filtering
+	films, info, err := h.filmService.GetAllFilms(r.Context(), sort, filter, page)
+	if err != nil {
+		h.log.Error("Failed to get all films", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidCursor) || errors.Is(err, domain.ErrInvalidFilter) || errors.Is(err, domain.ErrInvalidSort) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
 		return
 	}
 
-	// Get films with optional sorting
-	films, err := h.filmService.GetAllFilms(r.Context(), sortBy, order)
+	genreCounts, err := h.filmService.GetFilmGenreFacets(r.Context(), filter)
 	if err != nil {
-		h.log.Error("Failed to get all films", zap.Error(err))
+		h.log.Error("Failed to count films by genre", zap.Error(err))
 		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
 		return
 	}
 
+	if !h.addUserStates(w, r, films) {
+		return
+	}
+
 	// Convert domain films to DTOs
 	data := make([]*dto.Film, len(films))
 	for i, film := range films {
 		data[i] = dto.FilmDomainToDto(film)
 	}
 
-	dto.NewSuccessClientResponseDto(r.Context(), w, data)
+	dto.NewFacetedPageClientResponseDto(r.Context(), w, data, info, dto.GenreFacetsDomainToDto(genreCounts))
 }
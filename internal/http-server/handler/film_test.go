@@ -2,8 +2,12 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/common/constants"
 	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/router"
 	"github.com/Max425/film-library.git/mocks/service"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -31,15 +35,15 @@ func TestFilmHandler_CreateFilm(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockFilmService, film *domain.Film) {
 				r.EXPECT().CreateFilm(gomock.Any(), film).Return(mockFilm, nil)
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}}`,
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"status":201,"message":"success","payload":{"id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}}`,
 		},
 		{
 			name:                 "Invalid JSON",
 			requestMethod:        http.MethodPost,
 			requestBody:          `{"title": "Inception", "description": "A thriller", "release_date": "2024-03-18"`,
 			mockBehavior:         func(r *mock_handler.MockFilmService, film *domain.Film) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
 		},
 		{
@@ -49,7 +53,7 @@ func TestFilmHandler_CreateFilm(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockFilmService, film *domain.Film) {
 				r.EXPECT().CreateFilm(gomock.Any(), film).Return(nil, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
 	}
@@ -79,6 +83,107 @@ func TestFilmHandler_CreateFilm(t *testing.T) {
 	}
 }
 
+func TestFilmHandler_GetFilmByID(t *testing.T) {
+	mockActor, _ := domain.NewActor(1, "Leonardo DiCaprio", "male", time.Date(1974, time.November, 11, 0, 0, 0, 0, time.UTC), nil)
+	mockFilm, _ := domain.NewFilm(1, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, []*domain.Actor{mockActor})
+	tests := []struct {
+		name                 string
+		requestURL           string
+		mockBehavior         func(r *mock_handler.MockFilmService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Ok",
+			requestURL: "/api/films/1",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().GetFilmByID(gomock.Any(), 1).Return(mockFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[{"id":1,"name":"Leonardo DiCaprio","gender":"male","birth_date":"1974-11-11T00:00:00Z","films":[]}]}}`,
+		},
+		{
+			name:                 "Invalid ID",
+			requestURL:           "/api/films/abc",
+			mockBehavior:         func(r *mock_handler.MockFilmService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid film ID","payload":""}`,
+		},
+		{
+			name:       "Not found",
+			requestURL: "/api/films/2",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().GetFilmByID(gomock.Any(), 2).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:       "Get film error",
+			requestURL: "/api/films/1",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().GetFilmByID(gomock.Any(), 1).Return(nil, errors.New("some error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockFilmService := mock_handler.NewMockFilmService(mockCtrl)
+			test.mockBehavior(mockFilmService)
+
+			logger := zap.NewNop()
+			filmHandler := NewFilmHandler(logger, mockFilmService)
+
+			req, err := http.NewRequest(http.MethodGet, test.requestURL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodGet, "/api/films/{id}", filmHandler.GetFilmByID)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestFilmHandler_GetFilmByID_UserState(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockFilm, _ := domain.NewFilm(1, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
+
+	mockFilmService := mock_handler.NewMockFilmService(mockCtrl)
+	mockFilmService.EXPECT().GetFilmByID(gomock.Any(), 1).Return(mockFilm, nil)
+	mockFilmService.EXPECT().AddFilmUserStates(gomock.Any(), 7, []*domain.Film{mockFilm}).
+		DoAndReturn(func(_ context.Context, _ int, films []*domain.Film) error {
+			films[0].SetUserState(&domain.FilmUserState{InWatchlist: true, WatchCount: 2, ListIDs: []int{4}})
+			return nil
+		})
+
+	filmHandler := NewFilmHandler(zap.NewNop(), mockFilmService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/films/1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, &domain.Session{UserID: 7, Role: constants.UserRole}))
+	rr := httptest.NewRecorder()
+
+	rt := router.New()
+	rt.HandleFunc(http.MethodGet, "/api/films/{id}", filmHandler.GetFilmByID)
+	rt.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[],"user_state":{"in_watchlist":true,"watch_count":2,"list_ids":[4]}}}`, rr.Body.String())
+}
+
 func TestFilmHandler_UpdateFilm(t *testing.T) {
 	mockFilm, _ := domain.NewFilm(0, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
 	tests := []struct {
@@ -104,7 +209,7 @@ func TestFilmHandler_UpdateFilm(t *testing.T) {
 			requestMethod:        http.MethodPut,
 			requestBody:          `{"title": "Inception", "description": "A thriller", "release_date": "2024-03-18"`,
 			mockBehavior:         func(r *mock_handler.MockFilmService, film *domain.Film) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
 		},
 		{
@@ -114,7 +219,7 @@ func TestFilmHandler_UpdateFilm(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockFilmService, film *domain.Film) {
 				r.EXPECT().UpdateFilm(gomock.Any(), film).Return(nil, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
 	}
@@ -144,6 +249,77 @@ func TestFilmHandler_UpdateFilm(t *testing.T) {
 	}
 }
 
+func TestFilmHandler_PatchFilm(t *testing.T) {
+	mockFilm, _ := domain.NewFilm(1, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
+	patchedFilm, _ := domain.NewFilm(1, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 8.5, nil)
+	tests := []struct {
+		name                 string
+		requestURL           string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockFilmService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestURL:  "/api/films/1",
+			requestBody: `{"rating": 8.5}`,
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().GetFilmByID(gomock.Any(), 1).Return(mockFilm, nil)
+				r.EXPECT().UpdateFilm(gomock.Any(), patchedFilm).Return(patchedFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":8.5,"actors":[]}}`,
+		},
+		{
+			name:        "Not found",
+			requestURL:  "/api/films/2",
+			requestBody: `{"rating": 8.5}`,
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().GetFilmByID(gomock.Any(), 2).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:        "Invalid rating",
+			requestURL:  "/api/films/1",
+			requestBody: `{"rating": 11}`,
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().GetFilmByID(gomock.Any(), 1).Return(mockFilm, nil)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"required parameter is omitted: rating should be between 0 and 10","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockFilmService := mock_handler.NewMockFilmService(mockCtrl)
+			test.mockBehavior(mockFilmService)
+
+			logger := zap.NewNop()
+			filmHandler := NewFilmHandler(logger, mockFilmService)
+
+			req, err := http.NewRequest(http.MethodPatch, test.requestURL, bytes.NewBufferString(test.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPatch, "/api/films/{id}", filmHandler.PatchFilm)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
 func TestFilmHandler_UpdateFilmActors(t *testing.T) {
 	mockFilm, _ := domain.NewFilm(0, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
 	tests := []struct {
@@ -157,6 +333,17 @@ func TestFilmHandler_UpdateFilmActors(t *testing.T) {
 	}{
 		{
 			name:          "Ok",
+			requestMethod: http.MethodPut,
+			requestURL:    "/api/films/1/actors",
+			requestBody:   `[1, 2, 3]`,
+			mockBehavior: func(r *mock_handler.MockFilmService, film *domain.Film, actorsId []int) {
+				r.EXPECT().UpdateFilmActors(gomock.Any(), 1, actorsId).Return(mockFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}}`,
+		},
+		{
+			name:          "Ok legacy route",
 			requestMethod: http.MethodPost,
 			requestURL:    "/api/update_films_actors/1",
 			requestBody:   `[1, 2, 3]`,
@@ -172,9 +359,20 @@ func TestFilmHandler_UpdateFilmActors(t *testing.T) {
 			requestURL:           "/api/update_films_actors/1",
 			requestBody:          `[1, 2, 3`,
 			mockBehavior:         func(r *mock_handler.MockFilmService, film *domain.Film, actorsId []int) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
 		},
+		{
+			name:          "Unknown actors",
+			requestMethod: http.MethodPut,
+			requestURL:    "/api/films/1/actors",
+			requestBody:   `[1, 2, 3]`,
+			mockBehavior: func(r *mock_handler.MockFilmService, film *domain.Film, actorsId []int) {
+				r.EXPECT().UpdateFilmActors(gomock.Any(), 1, actorsId).Return(nil, fmt.Errorf("%w: 2, 3", domain.ErrUnknownActors))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"unknown actors: 2, 3","payload":""}`,
+		},
 		{
 			name:          "Update film actors error",
 			requestMethod: http.MethodPost,
@@ -183,7 +381,7 @@ func TestFilmHandler_UpdateFilmActors(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockFilmService, film *domain.Film, actorsId []int) {
 				r.EXPECT().UpdateFilmActors(gomock.Any(), 1, actorsId).Return(nil, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
 	}
@@ -205,7 +403,321 @@ func TestFilmHandler_UpdateFilmActors(t *testing.T) {
 			}
 			rr := httptest.NewRecorder()
 
-			filmHandler.UpdateFilmActors(rr, req)
+			rt := router.New()
+			rt.HandleFunc(http.MethodPut, "/api/films/{id}/actors", filmHandler.UpdateFilmActors)
+			rt.HandleFunc(http.MethodPost, "/api/update_films_actors/{id}", filmHandler.UpdateFilmActors)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestFilmHandler_ChangeFilmActor(t *testing.T) {
+	mockFilm, _ := domain.NewFilm(1, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
+	billing := 2
+	castFilm, _ := domain.NewFilm(1, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
+	castFilm.AddCasting(&domain.Casting{FilmID: 1, ActorID: 2, RoleName: "Arthur", Billing: &billing, RoleType: domain.RoleTypeRegular})
+	tests := []struct {
+		name                 string
+		requestMethod        string
+		requestURL           string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockFilmService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "Add",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/films/1/actors/2",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().AddFilmActor(gomock.Any(), domain.Casting{FilmID: 1, ActorID: 2}).Return(mockFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}}`,
+		},
+		{
+			name:          "Add with role",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/films/1/actors/2",
+			requestBody:   `{"role_name":"Arthur","billing":2,"role_type":"regular"}`,
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				casting := domain.Casting{FilmID: 1, ActorID: 2, RoleName: "Arthur", Billing: &billing, RoleType: domain.RoleTypeRegular}
+				r.EXPECT().AddFilmActor(gomock.Any(), casting).Return(castFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[],"cast":[{"film_id":1,"actor_id":2,"role_name":"Arthur","billing":2,"role_type":"regular"}]}}`,
+		},
+		{
+			name:                 "Invalid role",
+			requestMethod:        http.MethodPost,
+			requestURL:           "/api/films/1/actors/2",
+			requestBody:          `{"billing":"first"}`,
+			mockBehavior:         func(r *mock_handler.MockFilmService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid role","payload":""}`,
+		},
+		{
+			name:          "Invalid role type",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/films/1/actors/2",
+			requestBody:   `{"role_type":"stunt"}`,
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().AddFilmActor(gomock.Any(), domain.Casting{FilmID: 1, ActorID: 2, RoleType: "stunt"}).
+					Return(nil, fmt.Errorf("%w: role type must be regular/cameo/voice", domain.ErrInvalidCasting))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid casting: role type must be regular/cameo/voice","payload":""}`,
+		},
+		{
+			name:          "Remove",
+			requestMethod: http.MethodDelete,
+			requestURL:    "/api/films/1/actors/2",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().RemoveFilmActor(gomock.Any(), 1, 2).Return(mockFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}}`,
+		},
+		{
+			name:                 "Invalid actor ID",
+			requestMethod:        http.MethodPost,
+			requestURL:           "/api/films/1/actors/abc",
+			mockBehavior:         func(r *mock_handler.MockFilmService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid actor ID","payload":""}`,
+		},
+		{
+			name:          "Actor not found",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/films/1/actors/2",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().AddFilmActor(gomock.Any(), domain.Casting{FilmID: 1, ActorID: 2}).Return(nil, fmt.Errorf("%w: actor 2", domain.ErrNotFound))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:          "Service error",
+			requestMethod: http.MethodDelete,
+			requestURL:    "/api/films/1/actors/2",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().RemoveFilmActor(gomock.Any(), 1, 2).Return(nil, errors.New("some error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockFilmService := mock_handler.NewMockFilmService(mockCtrl)
+			test.mockBehavior(mockFilmService)
+
+			filmHandler := NewFilmHandler(zap.NewNop(), mockFilmService)
+
+			req := httptest.NewRequest(test.requestMethod, test.requestURL, bytes.NewBufferString(test.requestBody))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/films/{id}/actors/{actorId}", filmHandler.AddFilmActor)
+			rt.HandleFunc(http.MethodDelete, "/api/films/{id}/actors/{actorId}", filmHandler.RemoveFilmActor)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestFilmHandler_ChangeFilmCrew(t *testing.T) {
+	crewFilm, _ := domain.NewFilm(1, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
+	crewFilm.AddCredit(&domain.Credit{FilmID: 1, PersonID: 2, Job: domain.JobDirector, FilmTitle: "Inception", PersonName: "Christopher Nolan"})
+	tests := []struct {
+		name                 string
+		requestMethod        string
+		requestURL           string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockFilmService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "Add",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/films/1/crew/2",
+			requestBody:   `{"job":"director"}`,
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().AddFilmCrew(gomock.Any(), domain.Credit{FilmID: 1, PersonID: 2, Job: domain.JobDirector}).Return(crewFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[],"crew":[{"film_id":1,"person_id":2,"job":"director","film_title":"Inception","person_name":"Christopher Nolan"}]}}`,
+		},
+		{
+			name:          "Missing job",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/films/1/crew/2",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().AddFilmCrew(gomock.Any(), domain.Credit{FilmID: 1, PersonID: 2}).
+					Return(nil, fmt.Errorf("%w: job is required", domain.ErrInvalidCredit))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid credit: job is required","payload":""}`,
+		},
+		{
+			name:                 "Invalid person ID",
+			requestMethod:        http.MethodPost,
+			requestURL:           "/api/films/1/crew/abc",
+			mockBehavior:         func(r *mock_handler.MockFilmService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid person ID","payload":""}`,
+		},
+		{
+			name:          "Person not found",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/films/1/crew/2",
+			requestBody:   `{"job":"writer"}`,
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().AddFilmCrew(gomock.Any(), domain.Credit{FilmID: 1, PersonID: 2, Job: domain.JobWriter}).
+					Return(nil, fmt.Errorf("%w: person 2", domain.ErrNotFound))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:          "Remove one job",
+			requestMethod: http.MethodDelete,
+			requestURL:    "/api/films/1/crew/2?job=director",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().RemoveFilmCrew(gomock.Any(), domain.Credit{FilmID: 1, PersonID: 2, Job: domain.JobDirector}).Return(crewFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[],"crew":[{"film_id":1,"person_id":2,"job":"director","film_title":"Inception","person_name":"Christopher Nolan"}]}}`,
+		},
+		{
+			name:          "Service error",
+			requestMethod: http.MethodDelete,
+			requestURL:    "/api/films/1/crew/2",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().RemoveFilmCrew(gomock.Any(), domain.Credit{FilmID: 1, PersonID: 2}).Return(nil, errors.New("some error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockFilmService := mock_handler.NewMockFilmService(mockCtrl)
+			test.mockBehavior(mockFilmService)
+
+			filmHandler := NewFilmHandler(zap.NewNop(), mockFilmService)
+
+			req := httptest.NewRequest(test.requestMethod, test.requestURL, bytes.NewBufferString(test.requestBody))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/films/{id}/crew/{personId}", filmHandler.AddFilmCrew)
+			rt.HandleFunc(http.MethodDelete, "/api/films/{id}/crew/{personId}", filmHandler.RemoveFilmCrew)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestFilmHandler_ChangeFilmGenres(t *testing.T) {
+	genreFilm, _ := domain.NewFilm(1, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
+	scifi, _ := domain.NewGenre(4, "Sci-Fi")
+	genreFilm.AddGenre(scifi)
+	tests := []struct {
+		name                 string
+		requestMethod        string
+		requestURL           string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockFilmService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "Replace",
+			requestMethod: http.MethodPut,
+			requestURL:    "/api/films/1/genres",
+			requestBody:   `[4]`,
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().UpdateFilmGenres(gomock.Any(), 1, []int{4}).Return(genreFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[],"genres":[{"id":4,"name":"Sci-Fi"}]}}`,
+		},
+		{
+			name:          "Unknown genres",
+			requestMethod: http.MethodPut,
+			requestURL:    "/api/films/1/genres",
+			requestBody:   `[4, 9]`,
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().UpdateFilmGenres(gomock.Any(), 1, []int{4, 9}).Return(nil, fmt.Errorf("%w: 9", domain.ErrUnknownGenres))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"unknown genres: 9","payload":""}`,
+		},
+		{
+			name:          "Add",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/films/1/genres/4",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().AddFilmGenre(gomock.Any(), 1, 4).Return(genreFilm, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[],"genres":[{"id":4,"name":"Sci-Fi"}]}}`,
+		},
+		{
+			name:          "Genre not found",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/films/1/genres/4",
+			mockBehavior: func(r *mock_handler.MockFilmService) {
+				r.EXPECT().AddFilmGenre(gomock.Any(), 1, 4).Return(nil, fmt.Errorf("%w: genre 4", domain.ErrNotFound))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:                 "Invalid genre ID",
+			requestMethod:        http.MethodDelete,
+			requestURL:           "/api/films/1/genres/abc",
+			mockBehavior:         func(r *mock_handler.MockFilmService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid genre ID","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockFilmService := mock_handler.NewMockFilmService(mockCtrl)
+			test.mockBehavior(mockFilmService)
+
+			filmHandler := NewFilmHandler(zap.NewNop(), mockFilmService)
+
+			req := httptest.NewRequest(test.requestMethod, test.requestURL, bytes.NewBufferString(test.requestBody))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPut, "/api/films/{id}/genres", filmHandler.UpdateFilmGenres)
+			rt.HandleFunc(http.MethodPost, "/api/films/{id}/genres/{genreId}", filmHandler.AddFilmGenre)
+			rt.HandleFunc(http.MethodDelete, "/api/films/{id}/genres/{genreId}", filmHandler.RemoveFilmGenre)
+			rt.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.expectedStatusCode, rr.Code)
 			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
@@ -237,7 +749,7 @@ func TestFilmHandler_DeleteFilm(t *testing.T) {
 			requestMethod:        http.MethodDelete,
 			requestURL:           "/api/films/abc",
 			mockBehavior:         func(r *mock_handler.MockFilmService, id int) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"status":400,"message":"invalid film ID","payload":""}`,
 		},
 		{
@@ -247,7 +759,7 @@ func TestFilmHandler_DeleteFilm(t *testing.T) {
 			mockBehavior: func(r *mock_handler.MockFilmService, id int) {
 				r.EXPECT().DeleteFilm(gomock.Any(), id).Return(errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
 	}
@@ -275,7 +787,9 @@ func TestFilmHandler_DeleteFilm(t *testing.T) {
 			}
 			rr := httptest.NewRecorder()
 
-			filmHandler.DeleteFilm(rr, req)
+			rt := router.New()
+			rt.HandleFunc(http.MethodDelete, "/api/films/{id}", filmHandler.DeleteFilm)
+			rt.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.expectedStatusCode, rr.Code)
 			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
@@ -288,28 +802,75 @@ func TestFilmHandler_SearchFilms(t *testing.T) {
 		name                 string
 		requestMethod        string
 		requestURL           string
-		mockBehavior         func(r *mock_handler.MockFilmService, films []*domain.Film, err error)
+		mockBehavior         func(r *mock_handler.MockFilmService, matches []*domain.FilmMatch, err error)
 		expectedStatusCode   int
 		expectedResponseBody string
 	}{
 		{
 			name:          "Ok",
 			requestMethod: http.MethodGet,
-			requestURL:    "/api/search_films/thriller",
-			mockBehavior: func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {
-				r.EXPECT().SearchFilms(gomock.Any(), "thriller").Return(films, err)
+			requestURL:    "/api/search_films?q=thrill",
+			mockBehavior: func(r *mock_handler.MockFilmService, matches []*domain.FilmMatch, err error) {
+				r.EXPECT().SearchFilms(gomock.Any(), "thrill", 0.3, domain.PageRequest{Limit: 20}).Return(&domain.FilmSearchResult{Matches: matches, Page: &domain.PageInfo{Total: 1, Limit: 20}}, err)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}]}`,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"rank":0.5,"title_headline":"Inception","description_headline":"A \u003cb\u003ethriller\u003c/b\u003e","id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}],"pagination":{"total":1,"limit":20,"offset":0}}`,
 		},
 		{
-			name:          "Internal Server Error",
+			name:          "Deprecated Path Pattern",
 			requestMethod: http.MethodGet,
-			requestURL:    "/api/search_films/thriller",
-			mockBehavior: func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {
-				r.EXPECT().SearchFilms(gomock.Any(), "thriller").Return(nil, errors.New("some error"))
+			requestURL:    "/api/search_films/thrill",
+			mockBehavior: func(r *mock_handler.MockFilmService, matches []*domain.FilmMatch, err error) {
+				r.EXPECT().SearchFilms(gomock.Any(), "thrill", 0.3, domain.PageRequest{Limit: 20}).Return(&domain.FilmSearchResult{Matches: matches, Page: &domain.PageInfo{Total: 1, Limit: 20}}, err)
 			},
 			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"rank":0.5,"title_headline":"Inception","description_headline":"A \u003cb\u003ethriller\u003c/b\u003e","id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}],"pagination":{"total":1,"limit":20,"offset":0}}`,
+		},
+		{
+			name:          "Did You Mean",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/search_films?q=incepton&similarity=0.5",
+			mockBehavior: func(r *mock_handler.MockFilmService, matches []*domain.FilmMatch, err error) {
+				r.EXPECT().SearchFilms(gomock.Any(), "incepton", 0.5, domain.PageRequest{Limit: 20}).
+					Return(&domain.FilmSearchResult{Matches: []*domain.FilmMatch{}, Page: &domain.PageInfo{Limit: 20}, Fuzzy: true, Suggestions: []string{"Inception"}}, err)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[],"pagination":{"total":0,"limit":20,"offset":0},"fuzzy":true,"suggestions":["Inception"]}`,
+		},
+		{
+			name:                 "Invalid Similarity",
+			requestMethod:        http.MethodGet,
+			requestURL:           "/api/search_films?q=thrill&similarity=high",
+			mockBehavior:         func(r *mock_handler.MockFilmService, matches []*domain.FilmMatch, err error) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid similarity","payload":""}`,
+		},
+		{
+			name:                 "Missing Query",
+			requestMethod:        http.MethodGet,
+			requestURL:           "/api/search_films?q=+",
+			mockBehavior:         func(r *mock_handler.MockFilmService, matches []*domain.FilmMatch, err error) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"search query is required","payload":""}`,
+		},
+		{
+			name:          "Invalid Query",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/search_films?q=%21%21",
+			mockBehavior: func(r *mock_handler.MockFilmService, matches []*domain.FilmMatch, err error) {
+				r.EXPECT().SearchFilms(gomock.Any(), "!!", 0.3, domain.PageRequest{Limit: 20}).Return(nil, domain.ErrInvalidQuery)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid search query","payload":""}`,
+		},
+		{
+			name:          "Internal Server Error",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/search_films?q=thrill",
+			mockBehavior: func(r *mock_handler.MockFilmService, matches []*domain.FilmMatch, err error) {
+				r.EXPECT().SearchFilms(gomock.Any(), "thrill", 0.3, domain.PageRequest{Limit: 20}).Return(nil, errors.New("some error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
 	}
@@ -320,12 +881,12 @@ func TestFilmHandler_SearchFilms(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			mockFilmService := mock_handler.NewMockFilmService(mockCtrl)
-			var films []*domain.Film
+			var matches []*domain.FilmMatch
 			if test.expectedStatusCode == http.StatusOK {
 				film, _ := domain.NewFilm(0, "Inception", "A thriller", time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), 9.2, nil)
-				films = []*domain.Film{film}
+				matches = []*domain.FilmMatch{{Film: film, Rank: 0.5, TitleHeadline: "Inception", DescriptionHeadline: "A <b>thriller</b>"}}
 			}
-			test.mockBehavior(mockFilmService, films, nil)
+			test.mockBehavior(mockFilmService, matches, nil)
 
 			logger := zap.NewNop()
 			filmHandler := NewFilmHandler(logger, mockFilmService)
@@ -336,7 +897,10 @@ func TestFilmHandler_SearchFilms(t *testing.T) {
 			}
 			rr := httptest.NewRecorder()
 
-			filmHandler.SearchFilms(rr, req)
+			rt := router.New()
+			rt.HandleFunc(http.MethodGet, "/api/search_films", filmHandler.SearchFilms)
+			rt.HandleFunc(http.MethodGet, "/api/search_films/{pattern}", filmHandler.SearchFilms)
+			rt.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.expectedStatusCode, rr.Code)
 			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
@@ -360,10 +924,12 @@ func TestFilmHandler_GetAllFilms(t *testing.T) {
 			requestURL:    "/api/films",
 			queryParams:   map[string]string{"sort_by": "title", "order": "asc"},
 			mockBehavior: func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {
-				r.EXPECT().GetAllFilms(gomock.Any(), "title", "asc").Return(films, err)
+				r.EXPECT().GetAllFilms(gomock.Any(), []domain.FilmSort{{Field: domain.FilmSortTitle}}, domain.FilmFilter{}, domain.PageRequest{Limit: 20}).Return(films, &domain.PageInfo{Total: 1, Limit: 20}, err)
+				thriller, _ := domain.NewGenre(3, "Thriller")
+				r.EXPECT().GetFilmGenreFacets(gomock.Any(), domain.FilmFilter{}).Return([]*domain.GenreCount{{Genre: thriller, Count: 1}}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}]}`,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}],"pagination":{"total":1,"limit":20,"offset":0},"facets":{"genres":[{"id":3,"name":"Thriller","count":1}]}}`,
 		},
 		{
 			name:                 "Invalid Sort Order",
@@ -371,17 +937,112 @@ func TestFilmHandler_GetAllFilms(t *testing.T) {
 			requestURL:           "/api/films",
 			queryParams:          map[string]string{"sort_by": "title", "order": "invalid"},
 			mockBehavior:         func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"status":400,"message":"Invalid sort order","payload":""}`,
 		},
 		{
-			name:                 "Invalid Sort By Field",
+			name:          "Invalid Sort By Field",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/films",
+			queryParams:   map[string]string{"sort_by": "invalid", "order": "asc"},
+			mockBehavior: func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {
+				r.EXPECT().GetAllFilms(gomock.Any(), []domain.FilmSort{{Field: "invalid"}}, domain.FilmFilter{}, domain.PageRequest{Limit: 20}).
+					Return(nil, nil, fmt.Errorf("%w: unknown field %q", domain.ErrInvalidSort, "invalid"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid sort: unknown field \"invalid\"","payload":""}`,
+		},
+		{
+			name:          "Multi Column Sort",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/films",
+			queryParams:   map[string]string{"sort_by": "rating,title", "order": "desc,asc"},
+			mockBehavior: func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {
+				sort := []domain.FilmSort{{Field: domain.FilmSortRating, Desc: true}, {Field: domain.FilmSortTitle}}
+				r.EXPECT().GetAllFilms(gomock.Any(), sort, domain.FilmFilter{}, domain.PageRequest{Limit: 20}).Return(films, &domain.PageInfo{Total: 1, Limit: 20}, err)
+				r.EXPECT().GetFilmGenreFacets(gomock.Any(), domain.FilmFilter{}).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}],"pagination":{"total":1,"limit":20,"offset":0},"facets":{"genres":[]}}`,
+		},
+		{
+			name:                 "Sort Orders Mismatch",
 			requestMethod:        http.MethodGet,
 			requestURL:           "/api/films",
-			queryParams:          map[string]string{"sort_by": "invalid", "order": "asc"},
+			queryParams:          map[string]string{"sort_by": "rating,title,release_date", "order": "desc,asc"},
 			mockBehavior:         func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"Invalid sort order","payload":""}`,
+		},
+		{
+			name:          "Filter",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/films",
+			queryParams:   map[string]string{"released_from": "2024-01-01", "min_rating": "9", "actor_id": "1,2", "title_prefix": "In"},
+			mockBehavior: func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {
+				from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+				minRating := 9.0
+				filter := domain.FilmFilter{ReleasedFrom: &from, MinRating: &minRating, ActorIDs: []int{1, 2}, TitlePrefix: "In"}
+				r.EXPECT().GetAllFilms(gomock.Any(), []domain.FilmSort{{Field: domain.FilmSortRating, Desc: true}}, filter, domain.PageRequest{Limit: 20}).Return(films, &domain.PageInfo{Total: 1, Limit: 20}, err)
+				r.EXPECT().GetFilmGenreFacets(gomock.Any(), filter).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}],"pagination":{"total":1,"limit":20,"offset":0},"facets":{"genres":[]}}`,
+		},
+		{
+			name:          "Genre Filter",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/films",
+			queryParams:   map[string]string{"genre_id": "1,2", "genre_match": "all"},
+			mockBehavior: func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {
+				filter := domain.FilmFilter{GenreIDs: []int{1, 2}, GenreMatch: domain.GenreMatchAll}
+				r.EXPECT().GetAllFilms(gomock.Any(), []domain.FilmSort{{Field: domain.FilmSortRating, Desc: true}}, filter, domain.PageRequest{Limit: 20}).Return(films, &domain.PageInfo{Total: 1, Limit: 20}, err)
+				drama, _ := domain.NewGenre(1, "Drama")
+				thriller, _ := domain.NewGenre(2, "Thriller")
+				r.EXPECT().GetFilmGenreFacets(gomock.Any(), filter).Return([]*domain.GenreCount{{Genre: drama, Count: 1}, {Genre: thriller, Count: 1}}, nil)
+			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":400,"message":"Invalid sort by field","payload":""}`,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":0,"title":"Inception","description":"A thriller","release_date":"2024-03-18T00:00:00Z","rating":9.2,"actors":[]}],"pagination":{"total":1,"limit":20,"offset":0},"facets":{"genres":[{"id":1,"name":"Drama","count":1},{"id":2,"name":"Thriller","count":1}]}}`,
+		},
+		{
+			name:                 "Invalid Filter Value",
+			requestMethod:        http.MethodGet,
+			requestURL:           "/api/films",
+			queryParams:          map[string]string{"released_to": "yesterday"},
+			mockBehavior:         func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid released_to","payload":""}`,
+		},
+		{
+			name:          "Inconsistent Filter",
+			requestMethod: http.MethodGet,
+			requestURL:    "/api/films",
+			queryParams:   map[string]string{"actor_id": "1", "no_actors": "true"},
+			mockBehavior: func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {
+				filter := domain.FilmFilter{ActorIDs: []int{1}, WithoutActors: true}
+				r.EXPECT().GetAllFilms(gomock.Any(), []domain.FilmSort{{Field: domain.FilmSortRating, Desc: true}}, filter, domain.PageRequest{Limit: 20}).
+					Return(nil, nil, fmt.Errorf("%w: actor ids can not be combined with no actors", domain.ErrInvalidFilter))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid filter: actor ids can not be combined with no actors","payload":""}`,
+		},
+		{
+			name:                 "Invalid Limit",
+			requestMethod:        http.MethodGet,
+			requestURL:           "/api/films",
+			queryParams:          map[string]string{"limit": "1000"},
+			mockBehavior:         func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid limit","payload":""}`,
+		},
+		{
+			name:                 "Invalid Cursor",
+			requestMethod:        http.MethodGet,
+			requestURL:           "/api/films",
+			queryParams:          map[string]string{"cursor": "???"},
+			mockBehavior:         func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid cursor","payload":""}`,
 		},
 		{
 			name:          "Internal Server Error",
@@ -389,9 +1050,9 @@ func TestFilmHandler_GetAllFilms(t *testing.T) {
 			requestURL:    "/api/films",
 			queryParams:   map[string]string{"sort_by": "title", "order": "asc"},
 			mockBehavior: func(r *mock_handler.MockFilmService, films []*domain.Film, err error) {
-				r.EXPECT().GetAllFilms(gomock.Any(), "title", "asc").Return(nil, errors.New("some error"))
+				r.EXPECT().GetAllFilms(gomock.Any(), []domain.FilmSort{{Field: domain.FilmSortTitle}}, domain.FilmFilter{}, domain.PageRequest{Limit: 20}).Return(nil, nil, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
+			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
 		},
 	}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Max425/film-library.git/internal/domain"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseFilmFilter reads the film list filter from the query parameters.
+// actor_id and genre_id may be repeated or hold a comma separated list.
+func parseFilmFilter(r *http.Request) (domain.FilmFilter, error) {
+	var filter domain.FilmFilter
+	query := r.URL.Query()
+
+	for param, target := range map[string]**time.Time{
+		"released_from": &filter.ReleasedFrom,
+		"released_to":   &filter.ReleasedTo,
+	} {
+		if value := query.Get(param); value != "" {
+			date, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return filter, errors.New("invalid " + param)
+			}
+			*target = &date
+		}
+	}
+
+	for param, target := range map[string]**float64{
+		"min_rating":      &filter.MinRating,
+		"max_rating":      &filter.MaxRating,
+		"min_user_rating": &filter.MinUserRating,
+		"max_user_rating": &filter.MaxUserRating,
+	} {
+		if value := query.Get(param); value != "" {
+			rating, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return filter, errors.New("invalid " + param)
+			}
+			*target = &rating
+		}
+	}
+
+	for _, value := range query["actor_id"] {
+		for _, part := range strings.Split(value, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return filter, errors.New("invalid actor_id")
+			}
+			filter.ActorIDs = append(filter.ActorIDs, id)
+		}
+	}
+
+	for _, value := range query["genre_id"] {
+		for _, part := range strings.Split(value, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return filter, errors.New("invalid genre_id")
+			}
+			filter.GenreIDs = append(filter.GenreIDs, id)
+		}
+	}
+	filter.GenreMatch = domain.GenreMatch(query.Get("genre_match"))
+
+	filter.TitlePrefix = query.Get("title_prefix")
+
+	if value := query.Get("no_actors"); value != "" {
+		noActors, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, errors.New("invalid no_actors")
+		}
+		filter.WithoutActors = noActors
+	}
+
+	return filter, nil
+}
+
+// parseActorFilter reads the actor list filter from the query parameters.
+func parseActorFilter(r *http.Request) (domain.ActorFilter, error) {
+	query := r.URL.Query()
+	filter := domain.ActorFilter{
+		Name:   query.Get("name"),
+		Gender: query.Get("gender"),
+	}
+
+	for param, target := range map[string]**time.Time{
+		"born_from": &filter.BornFrom,
+		"born_to":   &filter.BornTo,
+	} {
+		if value := query.Get(param); value != "" {
+			date, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return filter, errors.New("invalid " + param)
+			}
+			*target = &date
+		}
+	}
+
+	return filter, nil
+}
+
+// parseUserFilter reads the user list filter from the query parameters.
+func parseUserFilter(r *http.Request) (domain.UserFilter, error) {
+	query := r.URL.Query()
+	filter := domain.UserFilter{Search: query.Get("q")}
+
+	if value := query.Get("role"); value != "" {
+		role, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, errors.New("invalid role")
+		}
+		filter.Role = &role
+	}
+
+	return filter, nil
+}
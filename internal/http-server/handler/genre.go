@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/Max425/film-library.git/internal/common"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+type GenreService interface {
+	CreateGenre(ctx context.Context, genre *domain.Genre) (*domain.Genre, error)
+	GetGenreByID(ctx context.Context, id int) (*domain.Genre, error)
+	UpdateGenre(ctx context.Context, genre *domain.Genre) (*domain.Genre, error)
+	DeleteGenre(ctx context.Context, id int) error
+	GetAllGenres(ctx context.Context) ([]*domain.Genre, error)
+}
+
+type GenreHandler struct {
+	log          *zap.Logger
+	genreService GenreService
+}
+
+func NewGenreHandler(log *zap.Logger, genreService GenreService) *GenreHandler {
+	return &GenreHandler{
+		log:          log,
+		genreService: genreService,
+	}
+}
+
+// CreateGenre creates a new genre. Genre names are unique.
+// @Summary Create a new genre
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param input body dto.Genre true "Genre object to be created"
+// @Success 201 {object} dto.Genre "Genre created successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 409 {string} string "Genre already exists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/genres [post]
+func (h *GenreHandler) CreateGenre(w http.ResponseWriter, r *http.Request) {
+	var genre dto.Genre
+	body, _ := io.ReadAll(r.Body)
+	if err := genre.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode genre", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	domainGenre, err := dto.GenreDtoToDomain(&genre)
+	if err != nil {
+		h.log.Error("Failed to convert genre", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	genreCreated, err := h.genreService.CreateGenre(r.Context(), domainGenre)
+	if err != nil {
+		h.log.Error("Failed to create genre", zap.Error(err))
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusConflict, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewCreatedClientResponseDto(r.Context(), w, dto.GenreDomainToDto(genreCreated))
+}
+
+// GetGenreByID retrieves a genre.
+// @Summary Retrieve a genre by ID
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Success 200 {object} dto.Genre "Genre"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/genres/{id} [get]
+func (h *GenreHandler) GetGenreByID(w http.ResponseWriter, r *http.Request) {
+	genreID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid genre ID")
+		return
+	}
+
+	genre, err := h.genreService.GetGenreByID(r.Context(), genreID)
+	if err != nil {
+		h.log.Error("Failed to get genre", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.GenreDomainToDto(genre))
+}
+
+// UpdateGenre renames an existing genre.
+// @Summary Update an existing genre
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Param input body dto.Genre true "Genre object to be updated"
+// @Success 200 {object} dto.Genre "Genre updated successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Genre already exists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/genres/{id} [put]
+func (h *GenreHandler) UpdateGenre(w http.ResponseWriter, r *http.Request) {
+	genreID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid genre ID")
+		return
+	}
+
+	var genre dto.Genre
+	body, _ := io.ReadAll(r.Body)
+	if err = genre.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode genre", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	genre.ID = genreID
+
+	domainGenre, err := dto.GenreDtoToDomain(&genre)
+	if err != nil {
+		h.log.Error("Failed to convert genre", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	genreUpdated, err := h.genreService.UpdateGenre(r.Context(), domainGenre)
+	if err != nil {
+		h.log.Error("Failed to update genre", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusConflict, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.GenreDomainToDto(genreUpdated))
+}
+
+// DeleteGenre deletes an existing genre, films lose it.
+// @Summary Delete an existing genre
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Success 200 {string} string "Genre deleted successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/genres/{id} [delete]
+func (h *GenreHandler) DeleteGenre(w http.ResponseWriter, r *http.Request) {
+	genreID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid genre ID")
+		return
+	}
+
+	if err = h.genreService.DeleteGenre(r.Context(), genreID); err != nil {
+		h.log.Error("Failed to delete genre", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Genre deleted successfully")
+}
+
+// GetAllGenres retrieves every genre ordered by name.
+// @Summary Retrieve all genres
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Success 200 {array} []dto.Genre "List of genres"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/genres [get]
+func (h *GenreHandler) GetAllGenres(w http.ResponseWriter, r *http.Request) {
+	genres, err := h.genreService.GetAllGenres(r.Context())
+	if err != nil {
+		h.log.Error("Failed to get all genres", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	data := make([]*dto.Genre, len(genres))
+	for i, genre := range genres {
+		data[i] = dto.GenreDomainToDto(genre)
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, data)
+}
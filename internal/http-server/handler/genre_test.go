@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"github.com/Max425/film-library.git/mocks/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGenreHandler_CreateGenre(t *testing.T) {
+	mockGenre, _ := domain.NewGenre(0, "Drama")
+	createdGenre, _ := domain.NewGenre(1, "Drama")
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockGenreService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"name":"Drama"}`,
+			mockBehavior: func(r *mock_handler.MockGenreService) {
+				r.EXPECT().CreateGenre(gomock.Any(), mockGenre).Return(createdGenre, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"status":201,"message":"success","payload":{"id":1,"name":"Drama"}}`,
+		},
+		{
+			name:        "Duplicate name",
+			requestBody: `{"name":"Drama"}`,
+			mockBehavior: func(r *mock_handler.MockGenreService) {
+				r.EXPECT().CreateGenre(gomock.Any(), mockGenre).Return(nil, fmt.Errorf("%w: genre %q", domain.ErrAlreadyExists, "Drama"))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":409,"message":"already exists: genre \"Drama\"","payload":""}`,
+		},
+		{
+			name:                 "Missing name",
+			requestBody:          `{}`,
+			mockBehavior:         func(r *mock_handler.MockGenreService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"required parameter is omitted: name is required","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockGenreService := mock_handler.NewMockGenreService(mockCtrl)
+			test.mockBehavior(mockGenreService)
+
+			genreHandler := NewGenreHandler(zap.NewNop(), mockGenreService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/genres", bytes.NewBufferString(test.requestBody))
+			rr := httptest.NewRecorder()
+
+			genreHandler.CreateGenre(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestGenreHandler_UpdateGenre(t *testing.T) {
+	mockGenre, _ := domain.NewGenre(1, "Noir")
+	tests := []struct {
+		name                 string
+		requestURL           string
+		mockBehavior         func(r *mock_handler.MockGenreService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Ok",
+			requestURL: "/api/genres/1",
+			mockBehavior: func(r *mock_handler.MockGenreService) {
+				r.EXPECT().UpdateGenre(gomock.Any(), mockGenre).Return(mockGenre, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"name":"Noir"}}`,
+		},
+		{
+			name:                 "Invalid genre ID",
+			requestURL:           "/api/genres/abc",
+			mockBehavior:         func(r *mock_handler.MockGenreService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid genre ID","payload":""}`,
+		},
+		{
+			name:       "Not found",
+			requestURL: "/api/genres/1",
+			mockBehavior: func(r *mock_handler.MockGenreService) {
+				r.EXPECT().UpdateGenre(gomock.Any(), mockGenre).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockGenreService := mock_handler.NewMockGenreService(mockCtrl)
+			test.mockBehavior(mockGenreService)
+
+			genreHandler := NewGenreHandler(zap.NewNop(), mockGenreService)
+
+			req := httptest.NewRequest(http.MethodPut, test.requestURL, bytes.NewBufferString(`{"name":"Noir"}`))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPut, "/api/genres/{id}", genreHandler.UpdateGenre)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestGenreHandler_GetAllGenres(t *testing.T) {
+	comedy, _ := domain.NewGenre(2, "Comedy")
+	drama, _ := domain.NewGenre(1, "Drama")
+	tests := []struct {
+		name                 string
+		mockBehavior         func(r *mock_handler.MockGenreService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *mock_handler.MockGenreService) {
+				r.EXPECT().GetAllGenres(gomock.Any()).Return([]*domain.Genre{comedy, drama}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":2,"name":"Comedy"},{"id":1,"name":"Drama"}]}`,
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(r *mock_handler.MockGenreService) {
+				r.EXPECT().GetAllGenres(gomock.Any()).Return(nil, errors.New("error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockGenreService := mock_handler.NewMockGenreService(mockCtrl)
+			test.mockBehavior(mockGenreService)
+
+			genreHandler := NewGenreHandler(zap.NewNop(), mockGenreService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/genres", nil)
+			rr := httptest.NewRecorder()
+
+			genreHandler.GetAllGenres(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
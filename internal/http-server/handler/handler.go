@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"github.com/Max425/film-library.git/internal/comfig"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
 	"go.uber.org/zap"
 	"net/http"
 )
@@ -9,6 +12,11 @@ type Service interface {
 	AuthService
 	FilmService
 	ActorService
+	PersonService
+	GenreService
+	ReviewService
+	ListService
+	AdminService
 }
 
 type Handler struct {
@@ -17,22 +25,41 @@ type Handler struct {
 	AuthHandler
 	FilmHandler
 	ActorHandler
+	PersonHandler
+	GenreHandler
+	ReviewHandler
+	ListHandler
+	AdminHandler
 }
 
-func NewHandler(service Service, log *zap.Logger) *Handler {
+func NewHandler(service Service, log *zap.Logger, cfg config.HttpConfig) *Handler {
 	return &Handler{
 		log,
-		*NewMiddleware(log, service),
+		*NewMiddleware(log, service, cfg.LegacyStatusCodes, cfg.TrustedProxies),
 		*NewAuthHandler(log, service),
 		*NewFilmHandler(log, service),
 		*NewActorHandler(log, service),
+		*NewPersonHandler(log, service),
+		*NewGenreHandler(log, service),
+		*NewReviewHandler(log, service),
+		*NewListHandler(log, service),
+		*NewAdminHandler(log, service),
 	}
 }
 
-func (h *Handler) UseRecoveryLoggingAuth(next http.HandlerFunc) http.HandlerFunc {
+// UseRecoveryLoggingAuth lets through signed in users whose role grants the permission.
+func (h *Handler) UseRecoveryLoggingAuth(permission domain.Permission, next http.HandlerFunc) http.HandlerFunc {
 	return h.panicRecoveryMiddleware(
 		h.loggingMiddleware(
-			h.authMiddleware(next)),
+			h.authMiddleware(permission, next)),
+	)
+}
+
+// UseRecoveryLoggingSession lets any signed in user through, whatever the method.
+func (h *Handler) UseRecoveryLoggingSession(next http.HandlerFunc) http.HandlerFunc {
+	return h.panicRecoveryMiddleware(
+		h.loggingMiddleware(
+			h.sessionMiddleware(next)),
 	)
 }
 
@@ -40,3 +67,18 @@ func (h *Handler) UseRecoveryLogging(next http.HandlerFunc) http.HandlerFunc {
 	return h.panicRecoveryMiddleware(
 		h.loggingMiddleware(next))
 }
+
+// UseDeprecated marks the route as a deprecated alias of the successor route.
+func (h *Handler) UseDeprecated(successor string, next http.HandlerFunc) http.HandlerFunc {
+	return h.deprecatedMiddleware(successor, next)
+}
+
+// NotFound answers requests that match no route.
+func (h *Handler) NotFound(w http.ResponseWriter, r *http.Request) {
+	dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+}
+
+// MethodNotAllowed answers requests whose route has no handler for the method.
+func (h *Handler) MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	dto.NewErrorClientResponseDto(r.Context(), w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+}
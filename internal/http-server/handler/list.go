@@ -0,0 +1,528 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/Max425/film-library.git/internal/common"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+type ListService interface {
+	AddToWatchlist(ctx context.Context, userID, filmID int) ([]*domain.Film, error)
+	RemoveFromWatchlist(ctx context.Context, userID, filmID int) ([]*domain.Film, error)
+	GetWatchlist(ctx context.Context, userID int) ([]*domain.Film, error)
+	AddWatched(ctx context.Context, entry domain.WatchEntry) (*domain.WatchEntry, error)
+	DeleteWatched(ctx context.Context, userID, id int) error
+	GetWatched(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.WatchEntry, *domain.PageInfo, error)
+	CreateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error)
+	GetFilmList(ctx context.Context, userID, id int) (*domain.FilmList, error)
+	GetSharedFilmList(ctx context.Context, token string) (*domain.FilmList, error)
+	UpdateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error)
+	DeleteFilmList(ctx context.Context, userID, id int) error
+	GetFilmLists(ctx context.Context, userID int) ([]*domain.FilmList, error)
+	AddFilmListItem(ctx context.Context, userID, listID, filmID int) (*domain.FilmList, error)
+	RemoveFilmListItem(ctx context.Context, userID, listID, filmID int) (*domain.FilmList, error)
+	ShareFilmList(ctx context.Context, userID, id int) (*domain.FilmList, error)
+	UnshareFilmList(ctx context.Context, userID, id int) error
+}
+
+type ListHandler struct {
+	log         *zap.Logger
+	listService ListService
+}
+
+func NewListHandler(log *zap.Logger, listService ListService) *ListHandler {
+	return &ListHandler{
+		log:         log,
+		listService: listService,
+	}
+}
+
+// GetWatchlist retrieves the films the signed in user wants to watch, the latest added first.
+// @Summary Retrieve own watchlist
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Success 200 {array} []dto.Film "Films in the watchlist"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/watchlist [get]
+func (h *ListHandler) GetWatchlist(w http.ResponseWriter, r *http.Request) {
+	films, err := h.listService.GetWatchlist(r.Context(), sessionFromContext(r.Context()).UserID)
+	if err != nil {
+		h.log.Error("Failed to get watchlist", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, filmsToDto(films))
+}
+
+// AddToWatchlist adds a film to the watchlist of the signed in user, adding it twice changes nothing.
+// @Summary Add a film to own watchlist
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param filmId path int true "Film ID"
+// @Success 200 {array} []dto.Film "Films in the watchlist"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/watchlist/{filmId} [put]
+func (h *ListHandler) AddToWatchlist(w http.ResponseWriter, r *http.Request) {
+	filmID, err := router.IntParam(r, "filmId")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+		return
+	}
+
+	films, err := h.listService.AddToWatchlist(r.Context(), sessionFromContext(r.Context()).UserID, filmID)
+	if err != nil {
+		h.log.Error("Failed to add film to watchlist", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, filmsToDto(films))
+}
+
+// RemoveFromWatchlist removes a film from the watchlist of the signed in user.
+// @Summary Remove a film from own watchlist
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param filmId path int true "Film ID"
+// @Success 200 {array} []dto.Film "Films in the watchlist"
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/watchlist/{filmId} [delete]
+func (h *ListHandler) RemoveFromWatchlist(w http.ResponseWriter, r *http.Request) {
+	filmID, err := router.IntParam(r, "filmId")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+		return
+	}
+
+	films, err := h.listService.RemoveFromWatchlist(r.Context(), sessionFromContext(r.Context()).UserID, filmID)
+	if err != nil {
+		h.log.Error("Failed to remove film from watchlist", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, filmsToDto(films))
+}
+
+// AddWatched logs a film as watched by the signed in user, today when no date is given.
+// A film may be logged many times.
+// @Summary Log a watched film
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param input body dto.WatchEntry true "Film ID and an optional date like 2024-03-18"
+// @Success 201 {object} dto.WatchEntry "Film logged successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/watched [post]
+func (h *ListHandler) AddWatched(w http.ResponseWriter, r *http.Request) {
+	var entry dto.WatchEntry
+	body, _ := io.ReadAll(r.Body)
+	if err := entry.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode watch entry", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	domainEntry, err := dto.WatchEntryDtoToDomain(&entry, sessionFromContext(r.Context()).UserID)
+	if err != nil {
+		h.log.Error("Failed to convert watch entry", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entryCreated, err := h.listService.AddWatched(r.Context(), domainEntry)
+	if err != nil {
+		h.log.Error("Failed to log watched film", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewCreatedClientResponseDto(r.Context(), w, dto.WatchEntryDomainToDto(entryCreated))
+}
+
+// DeleteWatched deletes an entry from the watched log of the signed in user.
+// @Summary Delete a watched log entry
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path int true "Entry ID"
+// @Success 200 {string} string "Entry deleted successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/watched/{id} [delete]
+func (h *ListHandler) DeleteWatched(w http.ResponseWriter, r *http.Request) {
+	entryID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid entry ID")
+		return
+	}
+
+	if err = h.listService.DeleteWatched(r.Context(), sessionFromContext(r.Context()).UserID, entryID); err != nil {
+		h.log.Error("Failed to delete watched film", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Entry deleted successfully")
+}
+
+// GetWatched retrieves the watched log of the signed in user, the latest watched first.
+// @Summary Retrieve own watched log
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Param offset query int false "Number of entries to skip, ignored with cursor"
+// @Param cursor query string false "Cursor from a previous page"
+// @Success 200 {array} []dto.WatchEntry "Watched log"
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/watched [get]
+func (h *ListHandler) GetWatched(w http.ResponseWriter, r *http.Request) {
+	page, err := parsePageRequest(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, info, err := h.listService.GetWatched(r.Context(), sessionFromContext(r.Context()).UserID, page)
+	if err != nil {
+		h.log.Error("Failed to get watched films", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	data := make([]*dto.WatchEntry, len(entries))
+	for i, entry := range entries {
+		data[i] = dto.WatchEntryDomainToDto(entry)
+	}
+
+	dto.NewPageClientResponseDto(r.Context(), w, data, info)
+}
+
+// CreateFilmList creates a list of the signed in user, list names are unique per user.
+// @Summary Create a film list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param input body dto.FilmListInput true "List name"
+// @Success 201 {object} dto.FilmList "List created successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 409 {string} string "List already exists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/lists [post]
+func (h *ListHandler) CreateFilmList(w http.ResponseWriter, r *http.Request) {
+	var input dto.FilmListInput
+	body, _ := io.ReadAll(r.Body)
+	if err := input.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode list", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	domainList, err := dto.FilmListInputToDomain(&input, 0, sessionFromContext(r.Context()).UserID)
+	if err != nil {
+		h.log.Error("Failed to convert list", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	listCreated, err := h.listService.CreateFilmList(r.Context(), domainList)
+	if err != nil {
+		h.log.Error("Failed to create list", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewCreatedClientResponseDto(r.Context(), w, dto.FilmListDomainToDto(listCreated, true))
+}
+
+// GetFilmList retrieves a list of the signed in user with its films.
+// @Summary Retrieve own film list by ID
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List ID"
+// @Success 200 {object} dto.FilmList "List"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/lists/{id} [get]
+func (h *ListHandler) GetFilmList(w http.ResponseWriter, r *http.Request) {
+	listID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid list ID")
+		return
+	}
+
+	list, err := h.listService.GetFilmList(r.Context(), sessionFromContext(r.Context()).UserID, listID)
+	if err != nil {
+		h.log.Error("Failed to get list", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmListDomainToDto(list, true))
+}
+
+// GetSharedFilmList retrieves a shared list by its token, no sign in is needed.
+// @Summary Retrieve a shared film list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} dto.FilmList "List"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/shared/lists/{token} [get]
+func (h *ListHandler) GetSharedFilmList(w http.ResponseWriter, r *http.Request) {
+	list, err := h.listService.GetSharedFilmList(r.Context(), router.Param(r, "token"))
+	if err != nil {
+		h.log.Error("Failed to get shared list", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmListDomainToDto(list, false))
+}
+
+// UpdateFilmList renames a list of the signed in user.
+// @Summary Rename own film list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List ID"
+// @Param input body dto.FilmListInput true "List name"
+// @Success 200 {object} dto.FilmList "List updated successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "List already exists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/lists/{id} [put]
+func (h *ListHandler) UpdateFilmList(w http.ResponseWriter, r *http.Request) {
+	listID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid list ID")
+		return
+	}
+
+	var input dto.FilmListInput
+	body, _ := io.ReadAll(r.Body)
+	if err = input.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode list", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	domainList, err := dto.FilmListInputToDomain(&input, listID, sessionFromContext(r.Context()).UserID)
+	if err != nil {
+		h.log.Error("Failed to convert list", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	listUpdated, err := h.listService.UpdateFilmList(r.Context(), domainList)
+	if err != nil {
+		h.log.Error("Failed to update list", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmListDomainToDto(listUpdated, true))
+}
+
+// DeleteFilmList deletes a list of the signed in user.
+// @Summary Delete own film list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List ID"
+// @Success 200 {string} string "List deleted successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/lists/{id} [delete]
+func (h *ListHandler) DeleteFilmList(w http.ResponseWriter, r *http.Request) {
+	listID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid list ID")
+		return
+	}
+
+	if err = h.listService.DeleteFilmList(r.Context(), sessionFromContext(r.Context()).UserID, listID); err != nil {
+		h.log.Error("Failed to delete list", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "List deleted successfully")
+}
+
+// GetFilmLists retrieves the lists of the signed in user without their films.
+// @Summary Retrieve own film lists
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Success 200 {array} []dto.FilmList "Lists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/lists [get]
+func (h *ListHandler) GetFilmLists(w http.ResponseWriter, r *http.Request) {
+	lists, err := h.listService.GetFilmLists(r.Context(), sessionFromContext(r.Context()).UserID)
+	if err != nil {
+		h.log.Error("Failed to get lists", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	data := make([]*dto.FilmList, len(lists))
+	for i, list := range lists {
+		data[i] = dto.FilmListDomainToDto(list, true)
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, data)
+}
+
+// AddFilmListItem adds a film to a list of the signed in user.
+// @Summary Add a film to own list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List ID"
+// @Param filmId path int true "Film ID"
+// @Success 200 {object} dto.FilmList "List"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/lists/{id}/films/{filmId} [put]
+func (h *ListHandler) AddFilmListItem(w http.ResponseWriter, r *http.Request) {
+	h.changeFilmListItem(w, r, h.listService.AddFilmListItem)
+}
+
+// RemoveFilmListItem removes a film from a list of the signed in user.
+// @Summary Remove a film from own list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List ID"
+// @Param filmId path int true "Film ID"
+// @Success 200 {object} dto.FilmList "List"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/lists/{id}/films/{filmId} [delete]
+func (h *ListHandler) RemoveFilmListItem(w http.ResponseWriter, r *http.Request) {
+	h.changeFilmListItem(w, r, h.listService.RemoveFilmListItem)
+}
+
+func (h *ListHandler) changeFilmListItem(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, userID, listID, filmID int) (*domain.FilmList, error)) {
+	listID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid list ID")
+		return
+	}
+	filmID, err := router.IntParam(r, "filmId")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+		return
+	}
+
+	list, err := change(r.Context(), sessionFromContext(r.Context()).UserID, listID, filmID)
+	if err != nil {
+		h.log.Error("Failed to change list films", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmListDomainToDto(list, true))
+}
+
+// ShareFilmList makes a list of the signed in user readable by a link. Sharing again
+// gives a new link and the old one stops working.
+// @Summary Share own film list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List ID"
+// @Success 200 {object} dto.FilmList "List with the share URL"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/lists/{id}/share [post]
+func (h *ListHandler) ShareFilmList(w http.ResponseWriter, r *http.Request) {
+	listID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid list ID")
+		return
+	}
+
+	list, err := h.listService.ShareFilmList(r.Context(), sessionFromContext(r.Context()).UserID, listID)
+	if err != nil {
+		h.log.Error("Failed to share list", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.FilmListDomainToDto(list, true))
+}
+
+// UnshareFilmList stops sharing a list of the signed in user.
+// @Summary Stop sharing own film list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path int true "List ID"
+// @Success 200 {string} string "List is no longer shared"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/lists/{id}/share [delete]
+func (h *ListHandler) UnshareFilmList(w http.ResponseWriter, r *http.Request) {
+	listID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid list ID")
+		return
+	}
+
+	if err = h.listService.UnshareFilmList(r.Context(), sessionFromContext(r.Context()).UserID, listID); err != nil {
+		h.log.Error("Failed to unshare list", zap.Error(err))
+		h.listError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "List is no longer shared")
+}
+
+func (h *ListHandler) listError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+	case errors.Is(err, domain.ErrAlreadyExists):
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidWatch), errors.Is(err, domain.ErrInvalidCursor):
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+	default:
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+	}
+}
+
+func filmsToDto(films []*domain.Film) []*dto.Film {
+	data := make([]*dto.Film, len(films))
+	for i, film := range films {
+		data[i] = dto.FilmDomainToDto(film)
+	}
+	return data
+}
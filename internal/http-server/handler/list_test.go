@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"github.com/Max425/film-library.git/mocks/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListHandler_AddToWatchlist(t *testing.T) {
+	session := &domain.Session{UserID: 7, Role: constants.UserRole}
+	mockFilm, _ := domain.NewFilm(5, "Heat", "", time.Date(1995, time.December, 15, 0, 0, 0, 0, time.UTC), 8.3, nil)
+	tests := []struct {
+		name                 string
+		requestURL           string
+		mockBehavior         func(r *mock_handler.MockListService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Ok",
+			requestURL: "/api/watchlist/5",
+			mockBehavior: func(r *mock_handler.MockListService) {
+				r.EXPECT().AddToWatchlist(gomock.Any(), 7, 5).Return([]*domain.Film{mockFilm}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":5,"title":"Heat","description":"","release_date":"1995-12-15T00:00:00Z","rating":8.3,"actors":[]}]}`,
+		},
+		{
+			name:                 "Invalid ID",
+			requestURL:           "/api/watchlist/abc",
+			mockBehavior:         func(r *mock_handler.MockListService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid film ID","payload":""}`,
+		},
+		{
+			name:       "Film not found",
+			requestURL: "/api/watchlist/6",
+			mockBehavior: func(r *mock_handler.MockListService) {
+				r.EXPECT().AddToWatchlist(gomock.Any(), 7, 6).Return(nil, fmt.Errorf("%w: film 6", domain.ErrNotFound))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockListService := mock_handler.NewMockListService(mockCtrl)
+			test.mockBehavior(mockListService)
+
+			listHandler := NewListHandler(zap.NewNop(), mockListService)
+
+			req := httptest.NewRequest(http.MethodPut, test.requestURL, nil)
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, session))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPut, "/api/watchlist/{filmId}", listHandler.AddToWatchlist)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestListHandler_AddWatched(t *testing.T) {
+	session := &domain.Session{UserID: 7, Role: constants.UserRole}
+	watchedAt := time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockListService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"film_id":5,"watched_at":"2024-03-18"}`,
+			mockBehavior: func(r *mock_handler.MockListService) {
+				r.EXPECT().AddWatched(gomock.Any(), domain.WatchEntry{UserID: 7, FilmID: 5, WatchedAt: watchedAt}).
+					Return(&domain.WatchEntry{ID: 11, UserID: 7, FilmID: 5, FilmTitle: "Heat", WatchedAt: watchedAt}, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"status":201,"message":"success","payload":{"id":11,"film_id":5,"film_title":"Heat","watched_at":"2024-03-18"}}`,
+		},
+		{
+			name:        "Without date",
+			requestBody: `{"film_id":5}`,
+			mockBehavior: func(r *mock_handler.MockListService) {
+				r.EXPECT().AddWatched(gomock.Any(), domain.WatchEntry{UserID: 7, FilmID: 5}).
+					Return(&domain.WatchEntry{ID: 12, UserID: 7, FilmID: 5, FilmTitle: "Heat", WatchedAt: watchedAt}, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"status":201,"message":"success","payload":{"id":12,"film_id":5,"film_title":"Heat","watched_at":"2024-03-18"}}`,
+		},
+		{
+			name:                 "Invalid date",
+			requestBody:          `{"film_id":5,"watched_at":"18.03.2024"}`,
+			mockBehavior:         func(r *mock_handler.MockListService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid watched_at","payload":""}`,
+		},
+		{
+			name:        "Future date",
+			requestBody: `{"film_id":5,"watched_at":"2024-03-18"}`,
+			mockBehavior: func(r *mock_handler.MockListService) {
+				r.EXPECT().AddWatched(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: film cannot be watched in the future", domain.ErrInvalidWatch))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid watch date: film cannot be watched in the future","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockListService := mock_handler.NewMockListService(mockCtrl)
+			test.mockBehavior(mockListService)
+
+			listHandler := NewListHandler(zap.NewNop(), mockListService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/watched", bytes.NewBufferString(test.requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, session))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/watched", listHandler.AddWatched)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestListHandler_CreateFilmList(t *testing.T) {
+	session := &domain.Session{UserID: 7, Role: constants.UserRole}
+	mockList, _ := domain.NewFilmList(0, 7, "Noir")
+	createdList, _ := domain.NewFilmList(4, 7, "Noir")
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockListService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"name":"Noir"}`,
+			mockBehavior: func(r *mock_handler.MockListService) {
+				r.EXPECT().CreateFilmList(gomock.Any(), mockList).Return(createdList, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"status":201,"message":"success","payload":{"id":4,"name":"Noir"}}`,
+		},
+		{
+			name:        "Name taken",
+			requestBody: `{"name":"Noir"}`,
+			mockBehavior: func(r *mock_handler.MockListService) {
+				r.EXPECT().CreateFilmList(gomock.Any(), mockList).Return(nil, fmt.Errorf(`%w: list "Noir"`, domain.ErrAlreadyExists))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":409,"message":"already exists: list \"Noir\"","payload":""}`,
+		},
+		{
+			name:                 "Empty name",
+			requestBody:          `{"name":""}`,
+			mockBehavior:         func(r *mock_handler.MockListService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"required parameter is omitted: name is required","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockListService := mock_handler.NewMockListService(mockCtrl)
+			test.mockBehavior(mockListService)
+
+			listHandler := NewListHandler(zap.NewNop(), mockListService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/lists", bytes.NewBufferString(test.requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, session))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/lists", listHandler.CreateFilmList)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestListHandler_ShareFilmList(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sharedList, _ := domain.NewFilmList(4, 7, "Noir")
+	sharedList.SetShareToken("0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7")
+
+	mockListService := mock_handler.NewMockListService(mockCtrl)
+	mockListService.EXPECT().ShareFilmList(gomock.Any(), 7, 4).Return(sharedList, nil)
+
+	listHandler := NewListHandler(zap.NewNop(), mockListService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/lists/4/share", nil)
+	req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, &domain.Session{UserID: 7, Role: constants.UserRole}))
+	rr := httptest.NewRecorder()
+
+	rt := router.New()
+	rt.HandleFunc(http.MethodPost, "/api/lists/{id}/share", listHandler.ShareFilmList)
+	rt.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"status":200,"message":"success","payload":{"id":4,"name":"Noir","share_url":"http://localhost:8000/api/shared/lists/0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7"}}`, rr.Body.String())
+}
+
+func TestListHandler_GetSharedFilmList(t *testing.T) {
+	sharedList, _ := domain.NewFilmList(4, 7, "Noir")
+	sharedList.SetShareToken("0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7")
+	tests := []struct {
+		name                 string
+		token                string
+		mockBehavior         func(r *mock_handler.MockListService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "Ok",
+			token: "0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7",
+			mockBehavior: func(r *mock_handler.MockListService) {
+				r.EXPECT().GetSharedFilmList(gomock.Any(), "0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7").Return(sharedList, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":4,"name":"Noir"}}`,
+		},
+		{
+			name:  "Not shared",
+			token: "unknown",
+			mockBehavior: func(r *mock_handler.MockListService) {
+				r.EXPECT().GetSharedFilmList(gomock.Any(), "unknown").Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockListService := mock_handler.NewMockListService(mockCtrl)
+			test.mockBehavior(mockListService)
+
+			listHandler := NewListHandler(zap.NewNop(), mockListService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/shared/lists/"+test.token, nil)
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodGet, "/api/shared/lists/{token}", listHandler.GetSharedFilmList)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
@@ -3,55 +3,128 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
 	"go.uber.org/zap"
+	"net"
 	"net/http"
+	"net/netip"
 	"runtime/debug"
+	"strings"
 	"time"
 )
 
+// statusCodesHeader lets a client choose per request between real HTTP
+// status codes, "http", and the legacy envelope always sent with 200 OK,
+// "legacy". Requests without it get the default of the server.
+const statusCodesHeader = "X-Status-Codes"
+
 type Middleware struct {
-	log         *zap.Logger
-	authService AuthService
+	log               *zap.Logger
+	authService       AuthService
+	legacyStatusCodes bool
+	trustedProxies    []netip.Prefix
 }
 
-func NewMiddleware(log *zap.Logger, authService AuthService) *Middleware {
+func NewMiddleware(log *zap.Logger, authService AuthService, legacyStatusCodes bool, trustedProxies []netip.Prefix) *Middleware {
 	return &Middleware{
-		log:         log,
-		authService: authService,
+		log:               log,
+		authService:       authService,
+		legacyStatusCodes: legacyStatusCodes,
+		trustedProxies:    trustedProxies,
 	}
 }
 
-func (h *Middleware) authMiddleware(next http.Handler) http.Handler {
+// sessionMiddleware lets signed in users through and puts their session into
+// the request context. A bearer access token is taken over the session cookie.
+func (h *Middleware) sessionMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		session, err := r.Cookie("session_id")
+		if token, ok := bearerToken(r); ok {
+			session, err := h.authService.ParseAccessToken(r.Context(), token)
+			if errors.Is(err, domain.ErrDisabled) {
+				dto.NewErrorClientResponseDto(r.Context(), w, http.StatusForbidden, err.Error())
+				return
+			}
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				dto.NewErrorClientResponseDto(r.Context(), w, http.StatusUnauthorized, "Need auth")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), constants.KeySession, session)))
+			return
+		}
+
+		cookie, err := r.Cookie("session_id")
 		if errors.Is(err, http.ErrNoCookie) {
 			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusUnauthorized, "Need auth")
 			return
 		}
 
-		role, err := h.authService.GetSessionValue(r.Context(), session.Value)
+		session, err := h.authService.GetSessionValue(r.Context(), cookie.Value)
+		if errors.Is(err, domain.ErrDisabled) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusForbidden, err.Error())
+			return
+		}
 		if err != nil {
 			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusUnauthorized, "Need auth")
 			return
 		}
-		if (r.Method == "POST" || r.Method == "PUT") && role != constants.AdminRole {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), constants.KeySession, session)))
+	})
+}
+
+// authMiddleware lets through signed in users whose role grants the permission.
+func (h *Middleware) authMiddleware(permission domain.Permission, next http.Handler) http.Handler {
+	return h.sessionMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session := sessionFromContext(r.Context())
+		if !domain.RoleHas(session.Role, permission) {
 			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusForbidden, "forbidden")
 			return
 		}
 		next.ServeHTTP(w, r)
-	})
+	}))
+}
+
+// bearerToken returns the token of the Authorization: Bearer header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// sessionFromContext returns the session put into the context by sessionMiddleware.
+func sessionFromContext(ctx context.Context) *domain.Session {
+	session, _ := ctx.Value(constants.KeySession).(*domain.Session)
+	return session
+}
+
+func (h *Middleware) deprecatedMiddleware(successor string, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+		h.log.Warn("Deprecated route used",
+			zap.String("Method", r.Method),
+			zap.String("RequestURI", r.RequestURI),
+			zap.String("Successor", successor),
+		)
+		next.ServeHTTP(w, r)
+	}
 }
 
 func (h *Middleware) loggingMiddleware(next http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
-		requestInfo := &dto.RequestInfo{}
-		ctx := context.WithValue(r.Context(), constants.KeyRequestInfo, requestInfo)
+		w.Header().Add("Vary", statusCodesHeader)
+		ctx := context.WithValue(r.Context(), constants.KeyRequestInfo, h.newRequestInfo(r))
+		r = r.WithContext(ctx)
+		r.RemoteAddr = h.remoteAddr(r)
 
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r)
 
 		timing := time.Since(start)
 
@@ -80,12 +153,74 @@ func (h *Middleware) panicRecoveryMiddleware(next http.Handler) http.HandlerFunc
 				h.log.Error("Panic",
 					zap.String("Method", r.Method),
 					zap.String("RequestURI", r.RequestURI),
-					zap.String("Error", err.(string)),
+					zap.String("Error", fmt.Sprint(err)),
 					zap.String("Message", string(debug.Stack())),
 				)
-				dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+				ctx := context.WithValue(r.Context(), constants.KeyRequestInfo, h.newRequestInfo(r))
+				dto.NewErrorClientResponseDto(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
 			}
 		}()
 		next.ServeHTTP(w, r)
 	}
 }
+
+// newRequestInfo tells whether the response to the request goes out with
+// the legacy 200 OK status.
+func (h *Middleware) newRequestInfo(r *http.Request) *dto.RequestInfo {
+	legacy := h.legacyStatusCodes
+	switch strings.ToLower(r.Header.Get(statusCodesHeader)) {
+	case "legacy":
+		legacy = true
+	case "http":
+		legacy = false
+	}
+	return &dto.RequestInfo{LegacyStatus: legacy}
+}
+
+// remoteAddr returns the address of the client. A trusted proxy passes it in
+// X-Real-IP or appends it to X-Forwarded-For, the headers of anyone else are
+// ignored as the client may put any address there.
+func (h *Middleware) remoteAddr(r *http.Request) string {
+	peer, ok := parseAddr(r.RemoteAddr)
+	if !ok || !h.trustedProxy(peer) {
+		return r.RemoteAddr
+	}
+	if addr, ok := parseAddr(r.Header.Get("X-Real-IP")); ok {
+		return addr.String()
+	}
+	// every proxy appends the address it got the request from, the first
+	// untrusted one from the right is the client
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr, ok := parseAddr(forwarded[i])
+		if !ok {
+			break
+		}
+		if !h.trustedProxy(addr) {
+			return addr.String()
+		}
+	}
+	return r.RemoteAddr
+}
+
+func (h *Middleware) trustedProxy(addr netip.Addr) bool {
+	for _, prefix := range h.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAddr reads an address with or without a port.
+func parseAddr(value string) (netip.Addr, bool) {
+	value = strings.TrimSpace(value)
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
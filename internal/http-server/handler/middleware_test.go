@@ -0,0 +1,338 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/mocks/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestMiddleware_LegacyStatusCodes(t *testing.T) {
+	tests := []struct {
+		name                 string
+		legacyStatusCodes    bool
+		statusCodes          string
+		panics               bool
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Real status",
+			legacyStatusCodes:    false,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:                 "Legacy status",
+			legacyStatusCodes:    true,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:                 "Client asks for legacy status",
+			legacyStatusCodes:    false,
+			statusCodes:          "legacy",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:                 "Client asks for real status",
+			legacyStatusCodes:    true,
+			statusCodes:          "http",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+		{
+			name:                 "Panic with real status",
+			panics:               true,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":500,"message":"Internal Server Error","payload":""}`,
+		},
+		{
+			name:                 "Panic with legacy status",
+			statusCodes:          "legacy",
+			panics:               true,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":500,"message":"Internal Server Error","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewMiddleware(zap.NewNop(), nil, test.legacyStatusCodes, nil)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.panics {
+					panic(fmt.Errorf("boom"))
+				}
+				dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, "not found")
+			})
+
+			req, err := http.NewRequest(http.MethodGet, "/api/films/1", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.statusCodes != "" {
+				req.Header.Set("X-Status-Codes", test.statusCodes)
+			}
+			rr := httptest.NewRecorder()
+
+			m.panicRecoveryMiddleware(m.loggingMiddleware(next)).ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+			assert.Equal(t, "X-Status-Codes", rr.Header().Get("Vary"))
+		})
+	}
+}
+
+func TestMiddleware_RemoteAddr(t *testing.T) {
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		realIP       string
+		forwardedFor []string
+		expectedAddr string
+	}{
+		{
+			name:         "Direct client",
+			remoteAddr:   "192.0.2.1:54321",
+			expectedAddr: "192.0.2.1:54321",
+		},
+		{
+			name:         "Direct client spoofs headers",
+			remoteAddr:   "192.0.2.1:54321",
+			realIP:       "198.51.100.7",
+			forwardedFor: []string{"198.51.100.8"},
+			expectedAddr: "192.0.2.1:54321",
+		},
+		{
+			name:         "Proxy passes X-Real-IP",
+			remoteAddr:   "10.0.0.2:40000",
+			realIP:       "198.51.100.7",
+			expectedAddr: "198.51.100.7",
+		},
+		{
+			name:         "Proxy chain passes X-Forwarded-For",
+			remoteAddr:   "10.0.0.2:40000",
+			forwardedFor: []string{"203.0.113.9, 198.51.100.7", "10.0.0.3"},
+			expectedAddr: "198.51.100.7",
+		},
+		{
+			name:         "Proxy without headers",
+			remoteAddr:   "10.0.0.2:40000",
+			expectedAddr: "10.0.0.2:40000",
+		},
+		{
+			name:         "Proxy passes garbage",
+			remoteAddr:   "10.0.0.2:40000",
+			realIP:       "unknown",
+			forwardedFor: []string{"unknown"},
+			expectedAddr: "10.0.0.2:40000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewMiddleware(zap.NewNop(), nil, false, trustedProxies)
+			var remoteAddr string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				remoteAddr = r.RemoteAddr
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+			req.RemoteAddr = test.remoteAddr
+			if test.realIP != "" {
+				req.Header.Set("X-Real-IP", test.realIP)
+			}
+			for _, value := range test.forwardedFor {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+
+			m.loggingMiddleware(next).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, test.expectedAddr, remoteAddr)
+		})
+	}
+}
+
+func TestMiddleware_Session(t *testing.T) {
+	tests := []struct {
+		name                 string
+		requestMethod        string
+		cookie               bool
+		bearer               string
+		permission           domain.Permission
+		mockBehavior         func(r *mock_handler.MockAuthService)
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedAuthenticate string
+	}{
+		{
+			name:          "User writes through session middleware",
+			requestMethod: http.MethodPost,
+			cookie:        true,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().GetSessionValue(gomock.Any(), "sid").Return(&domain.Session{UserID: 7, Role: constants.UserRole}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":7}`,
+		},
+		{
+			name:          "User writes through auth middleware",
+			requestMethod: http.MethodPost,
+			cookie:        true,
+			permission:    domain.PermFilmWrite,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().GetSessionValue(gomock.Any(), "sid").Return(&domain.Session{UserID: 7, Role: constants.UserRole}, nil)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"forbidden","payload":""}`,
+		},
+		{
+			name:          "User deletes through auth middleware",
+			requestMethod: http.MethodDelete,
+			cookie:        true,
+			permission:    domain.PermFilmDelete,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().GetSessionValue(gomock.Any(), "sid").Return(&domain.Session{UserID: 7, Role: constants.UserRole}, nil)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"forbidden","payload":""}`,
+		},
+		{
+			name:          "User reads through auth middleware",
+			requestMethod: http.MethodGet,
+			cookie:        true,
+			permission:    domain.PermFilmRead,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().GetSessionValue(gomock.Any(), "sid").Return(&domain.Session{UserID: 7, Role: constants.UserRole}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":7}`,
+		},
+		{
+			name:          "Editor deletes through auth middleware",
+			requestMethod: http.MethodDelete,
+			cookie:        true,
+			permission:    domain.PermFilmDelete,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().GetSessionValue(gomock.Any(), "sid").Return(&domain.Session{UserID: 8, Role: constants.EditorRole}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":8}`,
+		},
+		{
+			name:          "Editor manages users through auth middleware",
+			requestMethod: http.MethodPost,
+			cookie:        true,
+			permission:    domain.PermUserManage,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().GetSessionValue(gomock.Any(), "sid").Return(&domain.Session{UserID: 8, Role: constants.EditorRole}, nil)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"forbidden","payload":""}`,
+		},
+		{
+			name:          "Disabled user",
+			requestMethod: http.MethodGet,
+			cookie:        true,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().GetSessionValue(gomock.Any(), "sid").Return(nil, domain.ErrDisabled)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"account is disabled","payload":""}`,
+		},
+		{
+			name:          "Bearer token over cookie",
+			requestMethod: http.MethodPost,
+			cookie:        true,
+			bearer:        "Bearer token",
+			permission:    domain.PermFilmWrite,
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().ParseAccessToken(gomock.Any(), "token").Return(&domain.Session{UserID: 8, Role: constants.EditorRole}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":8}`,
+		},
+		{
+			name:          "Invalid bearer token",
+			requestMethod: http.MethodGet,
+			bearer:        "bearer token",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().ParseAccessToken(gomock.Any(), "token").Return(nil, fmt.Errorf("%w: token is expired", domain.ErrInvalidToken))
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":401,"message":"Need auth","payload":""}`,
+			expectedAuthenticate: `Bearer error="invalid_token"`,
+		},
+		{
+			name:          "Bearer token of disabled user",
+			requestMethod: http.MethodGet,
+			bearer:        "Bearer token",
+			mockBehavior: func(r *mock_handler.MockAuthService) {
+				r.EXPECT().ParseAccessToken(gomock.Any(), "token").Return(nil, domain.ErrDisabled)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"account is disabled","payload":""}`,
+		},
+		{
+			name:                 "Other authorization scheme",
+			requestMethod:        http.MethodGet,
+			bearer:               "Basic Ym9iOnF3ZXJ0eQ==",
+			mockBehavior:         func(r *mock_handler.MockAuthService) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":401,"message":"Need auth","payload":""}`,
+		},
+		{
+			name:                 "No cookie",
+			requestMethod:        http.MethodGet,
+			mockBehavior:         func(r *mock_handler.MockAuthService) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":401,"message":"Need auth","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockAuthService := mock_handler.NewMockAuthService(mockCtrl)
+			test.mockBehavior(mockAuthService)
+
+			m := NewMiddleware(zap.NewNop(), mockAuthService, false, nil)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				dto.NewSuccessClientResponseDto(r.Context(), w, sessionFromContext(r.Context()).UserID)
+			})
+			handler := m.sessionMiddleware(next)
+			if test.permission != "" {
+				handler = m.authMiddleware(test.permission, next)
+			}
+
+			req := httptest.NewRequest(test.requestMethod, "/api/films/1/reviews", nil)
+			if test.cookie {
+				req.AddCookie(&http.Cookie{Name: "session_id", Value: "sid"})
+			}
+			if test.bearer != "" {
+				req.Header.Set("Authorization", test.bearer)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+			assert.Equal(t, test.expectedAuthenticate, rr.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
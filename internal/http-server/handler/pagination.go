@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"net/http"
+	"strconv"
+)
+
+// parsePageRequest reads the limit, offset and cursor query parameters.
+func parsePageRequest(r *http.Request) (domain.PageRequest, error) {
+	page := domain.PageRequest{Limit: constants.DefaultPageLimit}
+	query := r.URL.Query()
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 || value > constants.MaxPageLimit {
+			return page, errors.New("invalid limit")
+		}
+		page.Limit = value
+	}
+
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return page, errors.New("invalid offset")
+		}
+		page.Offset = value
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		value, err := dto.DecodeCursor(cursor)
+		if err != nil {
+			return page, err
+		}
+		page.Cursor = value
+	}
+
+	return page, nil
+}
@@ -0,0 +1,338 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/Max425/film-library.git/internal/common"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+type PersonService interface {
+	CreatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error)
+	GetPersonByID(ctx context.Context, id int) (*domain.Person, error)
+	UpdatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error)
+	DeletePerson(ctx context.Context, id int) error
+	GetAllPersons(ctx context.Context, filter domain.PersonFilter, page domain.PageRequest) ([]*domain.Person, *domain.PageInfo, error)
+	AddPersonFilm(ctx context.Context, credit domain.Credit) (*domain.Person, error)
+	RemovePersonFilm(ctx context.Context, credit domain.Credit) (*domain.Person, error)
+}
+
+type PersonHandler struct {
+	log           *zap.Logger
+	personService PersonService
+}
+
+func NewPersonHandler(log *zap.Logger, personService PersonService) *PersonHandler {
+	return &PersonHandler{
+		log:           log,
+		personService: personService,
+	}
+}
+
+// CreatePerson creates a new crew member.
+// @Summary Create a new person
+// @Tags persons
+// @Accept json
+// @Produce json
+// @Param input body dto.Person true "Person object to be created"
+// @Success 201 {object} dto.Person "Person created successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/persons [post]
+func (h *PersonHandler) CreatePerson(w http.ResponseWriter, r *http.Request) {
+	var person dto.Person
+	body, _ := io.ReadAll(r.Body)
+	if err := person.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode person", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	domainPerson, err := dto.PersonDtoToDomain(&person)
+	if err != nil {
+		h.log.Error("Failed to convert person", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	personCreated, err := h.personService.CreatePerson(r.Context(), domainPerson)
+	if err != nil {
+		h.log.Error("Failed to create person", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewCreatedClientResponseDto(r.Context(), w, dto.PersonDomainToDto(personCreated))
+}
+
+// GetPersonByID retrieves a person with their credits.
+// @Summary Retrieve a person by ID
+// @Tags persons
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {object} dto.Person "Person with credits"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/persons/{id} [get]
+func (h *PersonHandler) GetPersonByID(w http.ResponseWriter, r *http.Request) {
+	personID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	person, err := h.personService.GetPersonByID(r.Context(), personID)
+	if err != nil {
+		h.log.Error("Failed to get person", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.PersonDomainToDto(person))
+}
+
+// UpdatePerson updates an existing person.
+// @Summary Update an existing person
+// @Tags persons
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param input body dto.Person true "Person object to be updated"
+// @Success 200 {object} dto.Person "Person updated successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/persons/{id} [put]
+func (h *PersonHandler) UpdatePerson(w http.ResponseWriter, r *http.Request) {
+	personID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	var person dto.Person
+	body, _ := io.ReadAll(r.Body)
+	if err = person.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode person", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	person.ID = personID
+
+	h.updatePerson(w, r, &person)
+}
+
+// PatchPerson partially updates an existing person.
+// Only the fields present in the request body are changed.
+// @Summary Partially update an existing person
+// @Tags persons
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param input body dto.Person true "Person fields to be updated"
+// @Success 200 {object} dto.Person "Person updated successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/persons/{id} [patch]
+func (h *PersonHandler) PatchPerson(w http.ResponseWriter, r *http.Request) {
+	personID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	currentPerson, err := h.personService.GetPersonByID(r.Context(), personID)
+	if err != nil {
+		h.log.Error("Failed to get person", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	person := dto.PersonDomainToDto(currentPerson)
+	body, _ := io.ReadAll(r.Body)
+	if err = person.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode person", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	person.ID = personID
+
+	h.updatePerson(w, r, person)
+}
+
+func (h *PersonHandler) updatePerson(w http.ResponseWriter, r *http.Request, person *dto.Person) {
+	domainPerson, err := dto.PersonDtoToDomain(person)
+	if err != nil {
+		h.log.Error("Failed to convert person", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	personUpdated, err := h.personService.UpdatePerson(r.Context(), domainPerson)
+	if err != nil {
+		h.log.Error("Failed to update person", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.PersonDomainToDto(personUpdated))
+}
+
+// DeletePerson deletes an existing person along with their credits.
+// @Summary Delete an existing person
+// @Tags persons
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Success 200 {string} string "Person deleted successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/persons/{id} [delete]
+func (h *PersonHandler) DeletePerson(w http.ResponseWriter, r *http.Request) {
+	personID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid person ID")
+		return
+	}
+
+	if err = h.personService.DeletePerson(r.Context(), personID); err != nil {
+		h.log.Error("Failed to delete person", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Person deleted successfully")
+}
+
+// GetAllPersons retrieves crew members ordered by id, optionally filtered by
+// name and job.
+// @Summary Retrieve all persons
+// @Tags persons
+// @Accept json
+// @Produce json
+// @Param name query string false "Name fragment, case-insensitive"
+// @Param job query string false "Job: director, writer, composer, producer"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Param offset query int false "Number of persons to skip, ignored with cursor"
+// @Param cursor query string false "Cursor from a previous page"
+// @Success 200 {array} []dto.Person "List of persons"
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/persons [get]
+func (h *PersonHandler) GetAllPersons(w http.ResponseWriter, r *http.Request) {
+	filter := domain.PersonFilter{
+		Name: r.URL.Query().Get("name"),
+		Job:  domain.Job(r.URL.Query().Get("job")),
+	}
+
+	page, err := parsePageRequest(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	persons, info, err := h.personService.GetAllPersons(r.Context(), filter, page)
+	if err != nil {
+		h.log.Error("Failed to get all persons", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidCursor) || errors.Is(err, domain.ErrInvalidFilter) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	data := make([]*dto.Person, len(persons))
+	for i, person := range persons {
+		data[i] = dto.PersonDomainToDto(person)
+	}
+
+	dto.NewPageClientResponseDto(r.Context(), w, data, info)
+}
+
+// AddPersonFilm credits a person with a job on a film. The job comes from the
+// body or the job query parameter, a person can hold several jobs on a film.
+// @Summary Add a film to the credits of a person
+// @Tags persons
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param filmId path int true "Film ID"
+// @Param job query string false "Job: director, writer, composer, producer"
+// @Param input body dto.Credit false "Job: director, writer, composer, producer"
+// @Success 200 {object} dto.Person "Person with the updated credits"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Person or film not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/persons/{id}/films/{filmId} [post]
+func (h *PersonHandler) AddPersonFilm(w http.ResponseWriter, r *http.Request) {
+	h.changePersonFilm(w, r, h.personService.AddPersonFilm)
+}
+
+// RemovePersonFilm removes the credit of a person on a film. Without a job
+// every credit of the person on the film is removed.
+// @Summary Remove a film from the credits of a person
+// @Tags persons
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param filmId path int true "Film ID"
+// @Param job query string false "Job to remove: director, writer, composer, producer"
+// @Success 200 {object} dto.Person "Person with the updated credits"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Person not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/persons/{id}/films/{filmId} [delete]
+func (h *PersonHandler) RemovePersonFilm(w http.ResponseWriter, r *http.Request) {
+	h.changePersonFilm(w, r, h.personService.RemovePersonFilm)
+}
+
+func (h *PersonHandler) changePersonFilm(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, credit domain.Credit) (*domain.Person, error)) {
+	credit, err := parseCredit(r, "filmId", "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	person, err := change(r.Context(), credit)
+	if err != nil {
+		h.log.Error("Failed to change person film", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidCredit) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.PersonDomainToDto(person))
+}
@@ -0,0 +1,358 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"github.com/Max425/film-library.git/mocks/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPersonHandler_CreatePerson(t *testing.T) {
+	mockPerson, _ := domain.NewPerson(0, "Hans Zimmer", nil)
+	createdPerson, _ := domain.NewPerson(1, "Hans Zimmer", nil)
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockPersonService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"name":"Hans Zimmer"}`,
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().CreatePerson(gomock.Any(), mockPerson).Return(createdPerson, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"status":201,"message":"success","payload":{"id":1,"name":"Hans Zimmer"}}`,
+		},
+		{
+			name:                 "Missing name",
+			requestBody:          `{}`,
+			mockBehavior:         func(r *mock_handler.MockPersonService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"required parameter is omitted: name is required","payload":""}`,
+		},
+		{
+			name:                 "Invalid body",
+			requestBody:          `{"name":`,
+			mockBehavior:         func(r *mock_handler.MockPersonService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"bad request","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockPersonService := mock_handler.NewMockPersonService(mockCtrl)
+			test.mockBehavior(mockPersonService)
+
+			personHandler := NewPersonHandler(zap.NewNop(), mockPersonService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/persons", bytes.NewBufferString(test.requestBody))
+			rr := httptest.NewRecorder()
+
+			personHandler.CreatePerson(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestPersonHandler_GetPersonByID(t *testing.T) {
+	mockPerson, _ := domain.NewPerson(1, "Christopher Nolan", []*domain.Credit{
+		{FilmID: 2, PersonID: 1, Job: domain.JobDirector, FilmTitle: "Inception", PersonName: "Christopher Nolan"},
+	})
+	tests := []struct {
+		name                 string
+		requestURL           string
+		mockBehavior         func(r *mock_handler.MockPersonService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Ok",
+			requestURL: "/api/persons/1",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().GetPersonByID(gomock.Any(), 1).Return(mockPerson, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"name":"Christopher Nolan","credits":[{"film_id":2,"person_id":1,"job":"director","film_title":"Inception","person_name":"Christopher Nolan"}]}}`,
+		},
+		{
+			name:                 "Invalid person ID",
+			requestURL:           "/api/persons/abc",
+			mockBehavior:         func(r *mock_handler.MockPersonService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid person ID","payload":""}`,
+		},
+		{
+			name:       "Not found",
+			requestURL: "/api/persons/1",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().GetPersonByID(gomock.Any(), 1).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockPersonService := mock_handler.NewMockPersonService(mockCtrl)
+			test.mockBehavior(mockPersonService)
+
+			personHandler := NewPersonHandler(zap.NewNop(), mockPersonService)
+
+			req := httptest.NewRequest(http.MethodGet, test.requestURL, nil)
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodGet, "/api/persons/{id}", personHandler.GetPersonByID)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestPersonHandler_PatchPerson(t *testing.T) {
+	currentPerson, _ := domain.NewPerson(1, "Hans Zimmer", nil)
+	updatedPerson, _ := domain.NewPerson(1, "Hans Florian Zimmer", nil)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockPersonService := mock_handler.NewMockPersonService(mockCtrl)
+	gomock.InOrder(
+		mockPersonService.EXPECT().GetPersonByID(gomock.Any(), 1).Return(currentPerson, nil),
+		mockPersonService.EXPECT().UpdatePerson(gomock.Any(), updatedPerson).Return(updatedPerson, nil),
+	)
+
+	personHandler := NewPersonHandler(zap.NewNop(), mockPersonService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/persons/1", bytes.NewBufferString(`{"name":"Hans Florian Zimmer"}`))
+	rr := httptest.NewRecorder()
+
+	rt := router.New()
+	rt.HandleFunc(http.MethodPatch, "/api/persons/{id}", personHandler.PatchPerson)
+	rt.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"status":200,"message":"success","payload":{"id":1,"name":"Hans Florian Zimmer"}}`, rr.Body.String())
+}
+
+func TestPersonHandler_DeletePerson(t *testing.T) {
+	tests := []struct {
+		name                 string
+		mockBehavior         func(r *mock_handler.MockPersonService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().DeletePerson(gomock.Any(), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"Person deleted successfully"}`,
+		},
+		{
+			name: "Not found",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().DeletePerson(gomock.Any(), 1).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockPersonService := mock_handler.NewMockPersonService(mockCtrl)
+			test.mockBehavior(mockPersonService)
+
+			personHandler := NewPersonHandler(zap.NewNop(), mockPersonService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/persons/1", nil)
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodDelete, "/api/persons/{id}", personHandler.DeletePerson)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestPersonHandler_GetAllPersons(t *testing.T) {
+	mockPerson, _ := domain.NewPerson(1, "Hans Zimmer", nil)
+	tests := []struct {
+		name                 string
+		requestURL           string
+		mockBehavior         func(r *mock_handler.MockPersonService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Ok",
+			requestURL: "/api/persons?name=zim&job=composer",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				filter := domain.PersonFilter{Name: "zim", Job: domain.JobComposer}
+				r.EXPECT().GetAllPersons(gomock.Any(), filter, domain.PageRequest{Limit: 20}).
+					Return([]*domain.Person{mockPerson}, &domain.PageInfo{Total: 1, Limit: 20}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":1,"name":"Hans Zimmer"}],"pagination":{"total":1,"limit":20,"offset":0}}`,
+		},
+		{
+			name:       "Invalid job",
+			requestURL: "/api/persons?job=gaffer",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().GetAllPersons(gomock.Any(), domain.PersonFilter{Job: "gaffer"}, domain.PageRequest{Limit: 20}).
+					Return(nil, nil, fmt.Errorf("%w: job must be director/writer/composer/producer", domain.ErrInvalidFilter))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid filter: job must be director/writer/composer/producer","payload":""}`,
+		},
+		{
+			name:       "Service error",
+			requestURL: "/api/persons",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().GetAllPersons(gomock.Any(), domain.PersonFilter{}, domain.PageRequest{Limit: 20}).Return(nil, nil, errors.New("error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":500,"message":"internal error","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockPersonService := mock_handler.NewMockPersonService(mockCtrl)
+			test.mockBehavior(mockPersonService)
+
+			personHandler := NewPersonHandler(zap.NewNop(), mockPersonService)
+
+			req := httptest.NewRequest(http.MethodGet, test.requestURL, nil)
+			rr := httptest.NewRecorder()
+
+			personHandler.GetAllPersons(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestPersonHandler_ChangePersonFilm(t *testing.T) {
+	mockPerson, _ := domain.NewPerson(1, "Hans Zimmer", []*domain.Credit{{FilmID: 2, PersonID: 1, Job: domain.JobComposer}})
+
+	tests := []struct {
+		name                 string
+		requestMethod        string
+		requestURL           string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockPersonService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "Add",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/persons/1/films/2",
+			requestBody:   `{"job":"composer"}`,
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().AddPersonFilm(gomock.Any(), domain.Credit{FilmID: 2, PersonID: 1, Job: domain.JobComposer}).Return(mockPerson, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"name":"Hans Zimmer","credits":[{"film_id":2,"person_id":1,"job":"composer"}]}}`,
+		},
+		{
+			name:          "Remove one job",
+			requestMethod: http.MethodDelete,
+			requestURL:    "/api/persons/1/films/2?job=composer",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().RemovePersonFilm(gomock.Any(), domain.Credit{FilmID: 2, PersonID: 1, Job: domain.JobComposer}).Return(mockPerson, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":{"id":1,"name":"Hans Zimmer","credits":[{"film_id":2,"person_id":1,"job":"composer"}]}}`,
+		},
+		{
+			name:          "Unknown job",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/persons/1/films/2?job=actor",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().AddPersonFilm(gomock.Any(), domain.Credit{FilmID: 2, PersonID: 1, Job: "actor"}).
+					Return(nil, fmt.Errorf("%w: job must be director/writer/composer/producer", domain.ErrInvalidCredit))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid credit: job must be director/writer/composer/producer","payload":""}`,
+		},
+		{
+			name:                 "Invalid film ID",
+			requestMethod:        http.MethodPost,
+			requestURL:           "/api/persons/1/films/abc",
+			mockBehavior:         func(r *mock_handler.MockPersonService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid film ID","payload":""}`,
+		},
+		{
+			name:          "Film not found",
+			requestMethod: http.MethodPost,
+			requestURL:    "/api/persons/1/films/2?job=writer",
+			mockBehavior: func(r *mock_handler.MockPersonService) {
+				r.EXPECT().AddPersonFilm(gomock.Any(), domain.Credit{FilmID: 2, PersonID: 1, Job: domain.JobWriter}).
+					Return(nil, fmt.Errorf("%w: film 2", domain.ErrNotFound))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockPersonService := mock_handler.NewMockPersonService(mockCtrl)
+			test.mockBehavior(mockPersonService)
+
+			personHandler := NewPersonHandler(zap.NewNop(), mockPersonService)
+
+			req := httptest.NewRequest(test.requestMethod, test.requestURL, bytes.NewBufferString(test.requestBody))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/persons/{id}/films/{filmId}", personHandler.AddPersonFilm)
+			rt.HandleFunc(http.MethodDelete, "/api/persons/{id}/films/{filmId}", personHandler.RemovePersonFilm)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/Max425/film-library.git/internal/common"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/handler/dto"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, review *domain.Review) (*domain.Review, error)
+	GetReviewByID(ctx context.Context, id int) (*domain.Review, error)
+	UpdateReview(ctx context.Context, session *domain.Session, review *domain.Review) (*domain.Review, error)
+	DeleteReview(ctx context.Context, session *domain.Session, id int) error
+	GetFilmReviews(ctx context.Context, filmID int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error)
+	GetUserReviews(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error)
+}
+
+type ReviewHandler struct {
+	log           *zap.Logger
+	reviewService ReviewService
+}
+
+func NewReviewHandler(log *zap.Logger, reviewService ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		log:           log,
+		reviewService: reviewService,
+	}
+}
+
+// CreateReview rates the film on behalf of the signed in user, a user reviews a film once.
+// @Summary Review a film
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param input body dto.ReviewInput true "Rating from 1 to 10 and an optional text"
+// @Success 201 {object} dto.Review "Review created successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 409 {string} string "Film already reviewed"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/reviews [post]
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	filmID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+		return
+	}
+
+	var input dto.ReviewInput
+	body, _ := io.ReadAll(r.Body)
+	if err = input.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode review", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	domainReview, err := dto.ReviewInputToDomain(&input, 0, filmID, sessionFromContext(r.Context()).UserID)
+	if err != nil {
+		h.log.Error("Failed to convert review", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reviewCreated, err := h.reviewService.CreateReview(r.Context(), domainReview)
+	if err != nil {
+		h.log.Error("Failed to create review", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusConflict, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewCreatedClientResponseDto(r.Context(), w, dto.ReviewDomainToDto(reviewCreated))
+}
+
+// GetReviewByID retrieves a review.
+// @Summary Retrieve a review by ID
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 200 {object} dto.Review "Review"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/reviews/{id} [get]
+func (h *ReviewHandler) GetReviewByID(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid review ID")
+		return
+	}
+
+	review, err := h.reviewService.GetReviewByID(r.Context(), reviewID)
+	if err != nil {
+		h.log.Error("Failed to get review", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.ReviewDomainToDto(review))
+}
+
+// UpdateReview changes the rating and the text of a review, only its author may do it.
+// @Summary Edit own review
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param input body dto.ReviewInput true "Rating from 1 to 10 and an optional text"
+// @Success 200 {object} dto.Review "Review updated successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {string} string "Not the author"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/reviews/{id} [put]
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid review ID")
+		return
+	}
+
+	var input dto.ReviewInput
+	body, _ := io.ReadAll(r.Body)
+	if err = input.UnmarshalJSON(body); err != nil {
+		h.log.Error("Failed to decode review", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, common.ErrBadRequest.String())
+		return
+	}
+	session := sessionFromContext(r.Context())
+	domainReview, err := dto.ReviewInputToDomain(&input, reviewID, 0, session.UserID)
+	if err != nil {
+		h.log.Error("Failed to convert review", zap.Error(err))
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reviewUpdated, err := h.reviewService.UpdateReview(r.Context(), session, domainReview)
+	if err != nil {
+		h.log.Error("Failed to update review", zap.Error(err))
+		h.reviewWriteError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, dto.ReviewDomainToDto(reviewUpdated))
+}
+
+// DeleteReview deletes a review on behalf of its author or of a moderator.
+// @Summary Delete a review
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 200 {string} string "Review deleted successfully"
+// @Failure 400 {string} string "Bad request"
+// @Failure 403 {string} string "Neither the author nor a moderator"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/reviews/{id} [delete]
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid review ID")
+		return
+	}
+
+	if err = h.reviewService.DeleteReview(r.Context(), sessionFromContext(r.Context()), reviewID); err != nil {
+		h.log.Error("Failed to delete review", zap.Error(err))
+		h.reviewWriteError(w, r, err)
+		return
+	}
+
+	dto.NewSuccessClientResponseDto(r.Context(), w, "Review deleted successfully")
+}
+
+func (h *ReviewHandler) reviewWriteError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+	case errors.Is(err, domain.ErrForbidden):
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusForbidden, err.Error())
+	default:
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+	}
+}
+
+// GetFilmReviews retrieves the reviews of a film, the newest first.
+// @Summary Retrieve the reviews of a film
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Film ID"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Param offset query int false "Number of reviews to skip, ignored with cursor"
+// @Param cursor query string false "Cursor from a previous page"
+// @Success 200 {array} []dto.Review "List of reviews"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/films/{id}/reviews [get]
+func (h *ReviewHandler) GetFilmReviews(w http.ResponseWriter, r *http.Request) {
+	filmID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid film ID")
+		return
+	}
+	h.getReviews(w, r, filmID, h.reviewService.GetFilmReviews)
+}
+
+// GetUserReviews retrieves the reviews written by a user, the newest first.
+// @Summary Retrieve the reviews of a user
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Param offset query int false "Number of reviews to skip, ignored with cursor"
+// @Param cursor query string false "Cursor from a previous page"
+// @Success 200 {array} []dto.Review "List of reviews"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /api/users/{id}/reviews [get]
+func (h *ReviewHandler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
+	userID, err := router.IntParam(r, "id")
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	h.getReviews(w, r, userID, h.reviewService.GetUserReviews)
+}
+
+func (h *ReviewHandler) getReviews(w http.ResponseWriter, r *http.Request, id int,
+	list func(ctx context.Context, id int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error)) {
+	page, err := parsePageRequest(r)
+	if err != nil {
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reviews, info, err := list(r.Context(), id, page)
+	if err != nil {
+		h.log.Error("Failed to get reviews", zap.Error(err))
+		if errors.Is(err, domain.ErrNotFound) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusNotFound, common.ErrNotFound.String())
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			dto.NewErrorClientResponseDto(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dto.NewErrorClientResponseDto(r.Context(), w, http.StatusInternalServerError, common.ErrInternal.String())
+		return
+	}
+
+	data := make([]*dto.Review, len(reviews))
+	for i, review := range reviews {
+		data[i] = dto.ReviewDomainToDto(review)
+	}
+
+	dto.NewPageClientResponseDto(r.Context(), w, data, info)
+}
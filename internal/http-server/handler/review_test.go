@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"github.com/Max425/film-library.git/mocks/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReviewHandler_CreateReview(t *testing.T) {
+	session := &domain.Session{UserID: 7, Role: constants.UserRole}
+	mockReview, _ := domain.NewReview(0, 1, 7, 8, "Great")
+	createdReview, _ := domain.NewReview(3, 1, 7, 8, "Great")
+	createdReview.SetTimestamps(time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name                 string
+		requestBody          string
+		mockBehavior         func(r *mock_handler.MockReviewService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			requestBody: `{"rating":8,"text":"Great"}`,
+			mockBehavior: func(r *mock_handler.MockReviewService) {
+				r.EXPECT().CreateReview(gomock.Any(), mockReview).Return(createdReview, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"status":201,"message":"success","payload":{"id":3,"film_id":1,"user_id":7,"rating":8,"text":"Great","created_at":"2024-03-18T00:00:00Z","updated_at":"2024-03-18T00:00:00Z"}}`,
+		},
+		{
+			name:        "Already reviewed",
+			requestBody: `{"rating":8,"text":"Great"}`,
+			mockBehavior: func(r *mock_handler.MockReviewService) {
+				r.EXPECT().CreateReview(gomock.Any(), mockReview).Return(nil, fmt.Errorf("%w: review of film 1", domain.ErrAlreadyExists))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":409,"message":"already exists: review of film 1","payload":""}`,
+		},
+		{
+			name:                 "Rating out of range",
+			requestBody:          `{"rating":11}`,
+			mockBehavior:         func(r *mock_handler.MockReviewService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"required parameter is omitted: rating should be between 1 and 10","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockReviewService := mock_handler.NewMockReviewService(mockCtrl)
+			test.mockBehavior(mockReviewService)
+
+			reviewHandler := NewReviewHandler(zap.NewNop(), mockReviewService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/films/1/reviews", bytes.NewBufferString(test.requestBody))
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, session))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodPost, "/api/films/{id}/reviews", reviewHandler.CreateReview)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestReviewHandler_DeleteReview(t *testing.T) {
+	session := &domain.Session{UserID: 2, Role: constants.UserRole}
+	tests := []struct {
+		name                 string
+		requestURL           string
+		mockBehavior         func(r *mock_handler.MockReviewService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:       "Ok",
+			requestURL: "/api/reviews/3",
+			mockBehavior: func(r *mock_handler.MockReviewService) {
+				r.EXPECT().DeleteReview(gomock.Any(), session, 3).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":"Review deleted successfully"}`,
+		},
+		{
+			name:       "Not the author",
+			requestURL: "/api/reviews/3",
+			mockBehavior: func(r *mock_handler.MockReviewService) {
+				r.EXPECT().DeleteReview(gomock.Any(), session, 3).Return(domain.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":403,"message":"forbidden","payload":""}`,
+		},
+		{
+			name:                 "Invalid review ID",
+			requestURL:           "/api/reviews/abc",
+			mockBehavior:         func(r *mock_handler.MockReviewService) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":400,"message":"invalid review ID","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockReviewService := mock_handler.NewMockReviewService(mockCtrl)
+			test.mockBehavior(mockReviewService)
+
+			reviewHandler := NewReviewHandler(zap.NewNop(), mockReviewService)
+
+			req := httptest.NewRequest(http.MethodDelete, test.requestURL, nil)
+			req = req.WithContext(context.WithValue(req.Context(), constants.KeySession, session))
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodDelete, "/api/reviews/{id}", reviewHandler.DeleteReview)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
+
+func TestReviewHandler_GetFilmReviews(t *testing.T) {
+	review, _ := domain.NewReview(3, 1, 7, 8, "")
+	review.SetTimestamps(time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC), time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name                 string
+		mockBehavior         func(r *mock_handler.MockReviewService)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *mock_handler.MockReviewService) {
+				r.EXPECT().GetFilmReviews(gomock.Any(), 1, domain.PageRequest{Limit: constants.DefaultPageLimit}).
+					Return([]*domain.Review{review}, &domain.PageInfo{Total: 1, Limit: constants.DefaultPageLimit}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":200,"message":"success","payload":[{"id":3,"film_id":1,"user_id":7,"rating":8,"text":"","created_at":"2024-03-18T00:00:00Z","updated_at":"2024-03-18T00:00:00Z"}],"pagination":{"total":1,"limit":20,"offset":0}}`,
+		},
+		{
+			name: "Film not found",
+			mockBehavior: func(r *mock_handler.MockReviewService) {
+				r.EXPECT().GetFilmReviews(gomock.Any(), 1, gomock.Any()).Return(nil, nil, fmt.Errorf("%w: film 1", domain.ErrNotFound))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":404,"message":"not found","payload":""}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockReviewService := mock_handler.NewMockReviewService(mockCtrl)
+			test.mockBehavior(mockReviewService)
+
+			reviewHandler := NewReviewHandler(zap.NewNop(), mockReviewService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/films/1/reviews", nil)
+			rr := httptest.NewRecorder()
+
+			rt := router.New()
+			rt.HandleFunc(http.MethodGet, "/api/films/{id}/reviews", reviewHandler.GetFilmReviews)
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+		})
+	}
+}
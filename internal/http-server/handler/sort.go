@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Max425/film-library.git/internal/domain"
+	"net/http"
+	"strings"
+)
+
+type sortParam struct {
+	field string
+	desc  bool
+}
+
+// parseSortParams reads the sort_by and order query parameters. Both take comma
+// separated lists matched by position, a single order applies to every field.
+// Fields default to defaultField and orders to desc.
+func parseSortParams(r *http.Request, defaultField string) ([]sortParam, error) {
+	var fields []string
+	if sortBy := r.URL.Query().Get("sort_by"); sortBy != "" {
+		fields = strings.Split(sortBy, ",")
+	} else if defaultField != "" {
+		fields = []string{defaultField}
+	}
+	orders := []string{"desc"}
+	if order := r.URL.Query().Get("order"); order != "" {
+		orders = strings.Split(order, ",")
+	}
+	if len(orders) != 1 && len(orders) != len(fields) {
+		return nil, errors.New("Invalid sort order")
+	}
+
+	params := make([]sortParam, len(fields))
+	for i, field := range fields {
+		order := orders[0]
+		if len(orders) > 1 {
+			order = orders[i]
+		}
+		if order != "asc" && order != "desc" {
+			return nil, errors.New("Invalid sort order")
+		}
+		params[i] = sortParam{field: strings.TrimSpace(field), desc: order == "desc"}
+	}
+	return params, nil
+}
+
+// parseFilmSort reads the film list ordering, the service checks the fields.
+// By default, films are sorted by rating in descending order.
+func parseFilmSort(r *http.Request) ([]domain.FilmSort, error) {
+	params, err := parseSortParams(r, string(domain.FilmSortRating))
+	if err != nil {
+		return nil, err
+	}
+
+	sort := make([]domain.FilmSort, len(params))
+	for i, param := range params {
+		sort[i] = domain.FilmSort{Field: domain.FilmSortField(param.field), Desc: param.desc}
+	}
+	return sort, nil
+}
+
+// parseActorSort reads the actor list ordering, the service checks the fields.
+// By default, actors are sorted by id.
+func parseActorSort(r *http.Request) ([]domain.ActorSort, error) {
+	params, err := parseSortParams(r, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sort := make([]domain.ActorSort, len(params))
+	for i, param := range params {
+		sort[i] = domain.ActorSort{Field: domain.ActorSortField(param.field), Desc: param.desc}
+	}
+	return sort, nil
+}
@@ -0,0 +1,167 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type ctxKey string
+
+const keyParams ctxKey = "path_params"
+
+// Router dispatches requests by path pattern and HTTP method.
+// Patterns are slash separated, a segment written as {name} matches any
+// non-empty value and is available to the handler through Param.
+type Router struct {
+	routes []*route
+	// NotFound is called when no pattern matches the request path.
+	NotFound http.Handler
+	// MethodNotAllowed is called when a pattern matches but has no handler
+	// for the request method. The Allow header is already set.
+	MethodNotAllowed http.Handler
+}
+
+type route struct {
+	segments []string
+	handlers map[string]http.Handler
+}
+
+func New() *Router {
+	return &Router{
+		NotFound: http.NotFoundHandler(),
+		MethodNotAllowed: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}),
+	}
+}
+
+// Handle registers the handler for the given method and pattern.
+func (rt *Router) Handle(method, pattern string, handler http.Handler) {
+	segments := split(pattern)
+	for _, rr := range rt.routes {
+		if equal(rr.segments, segments) {
+			if _, ok := rr.handlers[method]; ok {
+				panic(fmt.Sprintf("router: multiple registrations for %s %s", method, pattern))
+			}
+			rr.handlers[method] = handler
+			return
+		}
+	}
+	rt.routes = append(rt.routes, &route{
+		segments: segments,
+		handlers: map[string]http.Handler{method: handler},
+	})
+}
+
+// HandleFunc registers the handler function for the given method and pattern.
+func (rt *Router) HandleFunc(method, pattern string, handler http.HandlerFunc) {
+	rt.Handle(method, pattern, handler)
+}
+
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var matched *route
+	var params map[string]string
+	bestScore := -1
+	path := split(r.URL.Path)
+	for _, rr := range rt.routes {
+		p, score, ok := rr.match(path)
+		if ok && score > bestScore {
+			matched, params, bestScore = rr, p, score
+		}
+	}
+
+	if matched == nil {
+		rt.NotFound.ServeHTTP(w, r)
+		return
+	}
+
+	handler, ok := matched.handlers[r.Method]
+	if !ok {
+		w.Header().Set("Allow", strings.Join(matched.methods(), ", "))
+		rt.MethodNotAllowed.ServeHTTP(w, r)
+		return
+	}
+
+	if len(params) > 0 {
+		r = r.WithContext(context.WithValue(r.Context(), keyParams, params))
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// Param returns the value of the named path parameter or an empty string.
+func Param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(keyParams).(map[string]string)
+	return params[name]
+}
+
+// IntParam returns the named path parameter converted to int.
+func IntParam(r *http.Request, name string) (int, error) {
+	value := Param(r, name)
+	if value == "" {
+		return 0, fmt.Errorf("path parameter %q is missing", name)
+	}
+	return strconv.Atoi(value)
+}
+
+// match reports whether the path fits the route. The score is the number of
+// static segments, so that /films/search wins over /films/{id}.
+func (rr *route) match(path []string) (map[string]string, int, bool) {
+	if len(path) != len(rr.segments) {
+		return nil, 0, false
+	}
+	var params map[string]string
+	score := 0
+	for i, segment := range rr.segments {
+		if name, ok := paramName(segment); ok {
+			if path[i] == "" {
+				return nil, 0, false
+			}
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[name] = path[i]
+			continue
+		}
+		if segment != path[i] {
+			return nil, 0, false
+		}
+		score++
+	}
+	return params, score, true
+}
+
+func (rr *route) methods() []string {
+	methods := make([]string, 0, len(rr.handlers))
+	for method := range rr.handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+func paramName(segment string) (string, bool) {
+	if len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+func split(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
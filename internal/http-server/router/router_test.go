@@ -0,0 +1,137 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	rt := New()
+	rt.HandleFunc(http.MethodGet, "/api/films", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("list"))
+	})
+	rt.HandleFunc(http.MethodPost, "/api/films", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("create"))
+	})
+	rt.HandleFunc(http.MethodGet, "/api/films/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("film " + Param(r, "id")))
+	})
+	rt.HandleFunc(http.MethodDelete, "/api/films/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("delete " + Param(r, "id")))
+	})
+	rt.HandleFunc(http.MethodGet, "/api/films/search", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("search"))
+	})
+	rt.HandleFunc(http.MethodPut, "/api/films/{id}/actors/{actorId}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(Param(r, "id") + "/" + Param(r, "actorId")))
+	})
+
+	tests := []struct {
+		name                 string
+		requestMethod        string
+		requestURL           string
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedAllow        string
+	}{
+		{
+			name:                 "Static route",
+			requestMethod:        http.MethodGet,
+			requestURL:           "/api/films",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "list",
+		},
+		{
+			name:                 "Trailing slash",
+			requestMethod:        http.MethodPost,
+			requestURL:           "/api/films/",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "create",
+		},
+		{
+			name:                 "Path parameter",
+			requestMethod:        http.MethodDelete,
+			requestURL:           "/api/films/42",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "delete 42",
+		},
+		{
+			name:                 "Static segment wins over parameter",
+			requestMethod:        http.MethodGet,
+			requestURL:           "/api/films/search",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "search",
+		},
+		{
+			name:                 "Several parameters",
+			requestMethod:        http.MethodPut,
+			requestURL:           "/api/films/1/actors/2",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1/2",
+		},
+		{
+			name:                 "Method not allowed",
+			requestMethod:        http.MethodPatch,
+			requestURL:           "/api/films/1",
+			expectedStatusCode:   http.StatusMethodNotAllowed,
+			expectedResponseBody: "Method Not Allowed\n",
+			expectedAllow:        "DELETE, GET",
+		},
+		{
+			name:                 "Not found",
+			requestMethod:        http.MethodGet,
+			requestURL:           "/api/films/1/crew",
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "404 page not found\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.requestMethod, test.requestURL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+
+			rt.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.expectedStatusCode, rr.Code)
+			assert.Equal(t, test.expectedResponseBody, rr.Body.String())
+			assert.Equal(t, test.expectedAllow, rr.Header().Get("Allow"))
+		})
+	}
+}
+
+func TestRouter_HandleDuplicate(t *testing.T) {
+	rt := New()
+	rt.HandleFunc(http.MethodGet, "/api/films", func(w http.ResponseWriter, r *http.Request) {})
+
+	assert.Panics(t, func() {
+		rt.HandleFunc(http.MethodGet, "/api/films/", func(w http.ResponseWriter, r *http.Request) {})
+	})
+}
+
+func TestIntParam(t *testing.T) {
+	var id int
+	var err error
+	rt := New()
+	rt.HandleFunc(http.MethodGet, "/api/films/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err = IntParam(r, "id")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/films/7", nil)
+	rt.ServeHTTP(httptest.NewRecorder(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, id)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/films/abc", nil)
+	rt.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Error(t, err)
+
+	_, err = IntParam(httptest.NewRequest(http.MethodGet, "/api/films", nil), "id")
+	assert.Error(t, err)
+}
@@ -1,11 +1,16 @@
 package http_server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	_ "github.com/Max425/film-library.git/docs"
 	"github.com/Max425/film-library.git/internal/comfig"
 	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/http-server/handler"
+	"github.com/Max425/film-library.git/internal/http-server/router"
+	"github.com/Max425/film-library.git/internal/mailer"
 	"github.com/Max425/film-library.git/internal/repository"
 	"github.com/Max425/film-library.git/internal/service"
 	"github.com/swaggo/http-swagger"
@@ -17,53 +22,172 @@ type Service interface {
 	handler.FilmService
 }
 
-func NewHttpServer(log *zap.Logger, postgres config.PostgresConfig, redis config.RedisConfig, listenAddr string) (*http.Server, error) {
+// NewHttpServer returns the server and a function to call once it is shut
+// down. The function lets the mails still sent in the background go out and
+// then closes the stores.
+func NewHttpServer(log *zap.Logger, cfg *config.Config) (*http.Server, func(ctx context.Context) error, error) {
 	// connect to db
-	dbConnect, err := repository.NewPostgresDB(postgres)
+	dbConnect, err := repository.NewPostgresDB(cfg.Postgres)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// connect to redis
-	redisClient, err := repository.NewRedisClient(redis)
+	redisClient, err := repository.NewRedisClient(cfg.Redis)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// create all repositories
 	repositories := repository.NewRepository(dbConnect, log, redisClient)
 
 	// create all services
-	services := service.NewService(repositories, log)
+	hasher, err := service.NewPasswordHasher(cfg.Auth.PasswordHasher)
+	if err != nil {
+		return nil, nil, err
+	}
+	policy := service.NewPasswordPolicy(cfg.Auth.PasswordMinLength, cfg.Auth.PasswordMinClasses)
+	mail, err := mailer.New(cfg.Mail, log)
+	if err != nil {
+		return nil, nil, err
+	}
+	throttle := service.NewLoginThrottle(cfg.Auth.LoginMaxAttempts, cfg.Auth.LoginMaxIPAttempts, cfg.Auth.LoginWindow, cfg.Auth.LoginLockout)
+	accessKeys := make([]service.AccessKey, 0, len(cfg.Auth.JWT.Keys))
+	for _, key := range cfg.Auth.JWT.Keys {
+		accessKey, err := service.ParseAccessKey(key.ID, key.Algorithm, key.Secret, key.PrivateKey, key.PublicKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		accessKeys = append(accessKeys, accessKey)
+	}
+	tokens, err := service.NewAccessTokens(cfg.Auth.JWT.Issuer, cfg.Auth.JWT.AccessTTL, cfg.Auth.JWT.RefreshTTL, cfg.Auth.JWT.SigningKey, accessKeys...)
+	if err != nil {
+		return nil, nil, err
+	}
+	services := service.NewService(repositories, log, hasher, policy, mail, throttle, cfg.Auth.RequireVerifiedMail, tokens)
 
-	h := handler.NewHandler(services, log)
+	h := handler.NewHandler(services, log, cfg.Http)
 
-	mux := http.NewServeMux()
+	api := router.New()
+	api.NotFound = h.UseRecoveryLogging(h.NotFound)
+	api.MethodNotAllowed = h.UseRecoveryLogging(h.MethodNotAllowed)
 
-	mux.Handle("/swagger/", httpSwagger.Handler(
-		httpSwagger.URL(fmt.Sprintf("%s/swagger/doc.json", constants.Host)),
-	))
 	// Auth
-	mux.HandleFunc("/api/auth/login", h.UseRecoveryLogging(h.SignIn))
-	mux.HandleFunc("/api/auth/logout", h.UseRecoveryLogging(h.Logout))
-	mux.HandleFunc("/api/auth/sign-up", h.UseRecoveryLogging(h.SignUp))
+	api.HandleFunc(http.MethodPost, "/api/auth/login", h.UseRecoveryLogging(h.SignIn))
+	api.HandleFunc(http.MethodPost, "/api/auth/token", h.UseRecoveryLogging(h.IssueToken))
+	api.HandleFunc(http.MethodPost, "/api/auth/refresh", h.UseRecoveryLogging(h.RefreshToken))
+	api.HandleFunc(http.MethodDelete, "/api/auth/logout", h.UseRecoveryLogging(h.Logout))
+	api.HandleFunc(http.MethodPost, "/api/auth/sign-up", h.UseRecoveryLogging(h.SignUp))
+	api.HandleFunc(http.MethodGet, "/api/auth/me", h.UseRecoveryLoggingSession(h.Me))
+	api.HandleFunc(http.MethodPut, "/api/auth/password", h.UseRecoveryLoggingSession(h.ChangePassword))
+	api.HandleFunc(http.MethodPost, "/api/auth/password/reset", h.UseRecoveryLogging(h.RequestPasswordReset))
+	api.HandleFunc(http.MethodPost, "/api/auth/password/reset/confirm", h.UseRecoveryLogging(h.ResetPassword))
+	api.HandleFunc(http.MethodPost, "/api/auth/verify", h.UseRecoveryLogging(h.RequestVerification))
+	api.HandleFunc(http.MethodGet, "/api/auth/verify", h.UseRecoveryLogging(h.VerifyMail))
+	api.HandleFunc(http.MethodGet, "/api/auth/sessions", h.UseRecoveryLoggingSession(h.GetSessions))
+	api.HandleFunc(http.MethodDelete, "/api/auth/sessions", h.UseRecoveryLoggingSession(h.RevokeSessions))
+	api.HandleFunc(http.MethodDelete, "/api/auth/sessions/{id}", h.UseRecoveryLoggingSession(h.RevokeSession))
 
 	// Actors endpoints
-	mux.HandleFunc("/api/create_actors", h.UseRecoveryLoggingAuth(h.CreateActor))
-	mux.HandleFunc("/api/update_actors", h.UseRecoveryLoggingAuth(h.UpdateActor))
-	mux.HandleFunc("/api/actors/", h.UseRecoveryLoggingAuth(h.DeleteActor))
-	mux.HandleFunc("/api/actors", h.UseRecoveryLoggingAuth(h.GetAllActors))
+	api.HandleFunc(http.MethodGet, "/api/actors", h.UseRecoveryLoggingAuth(domain.PermActorRead, h.GetAllActors))
+	api.HandleFunc(http.MethodPost, "/api/actors", h.UseRecoveryLoggingAuth(domain.PermActorWrite, h.CreateActor))
+	api.HandleFunc(http.MethodGet, "/api/actors/{id}", h.UseRecoveryLoggingAuth(domain.PermActorRead, h.GetActorByID))
+	api.HandleFunc(http.MethodPut, "/api/actors/{id}", h.UseRecoveryLoggingAuth(domain.PermActorWrite, h.UpdateActor))
+	api.HandleFunc(http.MethodPatch, "/api/actors/{id}", h.UseRecoveryLoggingAuth(domain.PermActorWrite, h.PatchActor))
+	api.HandleFunc(http.MethodDelete, "/api/actors/{id}", h.UseRecoveryLoggingAuth(domain.PermActorDelete, h.DeleteActor))
+	api.HandleFunc(http.MethodPost, "/api/actors/{id}/films/{filmId}", h.UseRecoveryLoggingAuth(domain.PermActorWrite, h.AddActorFilm))
+	api.HandleFunc(http.MethodDelete, "/api/actors/{id}/films/{filmId}", h.UseRecoveryLoggingAuth(domain.PermActorWrite, h.RemoveActorFilm))
+
+	// Persons endpoints
+	api.HandleFunc(http.MethodGet, "/api/persons", h.UseRecoveryLoggingAuth(domain.PermPersonRead, h.GetAllPersons))
+	api.HandleFunc(http.MethodPost, "/api/persons", h.UseRecoveryLoggingAuth(domain.PermPersonWrite, h.CreatePerson))
+	api.HandleFunc(http.MethodGet, "/api/persons/{id}", h.UseRecoveryLoggingAuth(domain.PermPersonRead, h.GetPersonByID))
+	api.HandleFunc(http.MethodPut, "/api/persons/{id}", h.UseRecoveryLoggingAuth(domain.PermPersonWrite, h.UpdatePerson))
+	api.HandleFunc(http.MethodPatch, "/api/persons/{id}", h.UseRecoveryLoggingAuth(domain.PermPersonWrite, h.PatchPerson))
+	api.HandleFunc(http.MethodDelete, "/api/persons/{id}", h.UseRecoveryLoggingAuth(domain.PermPersonDelete, h.DeletePerson))
+	api.HandleFunc(http.MethodPost, "/api/persons/{id}/films/{filmId}", h.UseRecoveryLoggingAuth(domain.PermPersonWrite, h.AddPersonFilm))
+	api.HandleFunc(http.MethodDelete, "/api/persons/{id}/films/{filmId}", h.UseRecoveryLoggingAuth(domain.PermPersonWrite, h.RemovePersonFilm))
+
+	// Genres endpoints
+	api.HandleFunc(http.MethodGet, "/api/genres", h.UseRecoveryLoggingAuth(domain.PermGenreRead, h.GetAllGenres))
+	api.HandleFunc(http.MethodPost, "/api/genres", h.UseRecoveryLoggingAuth(domain.PermGenreWrite, h.CreateGenre))
+	api.HandleFunc(http.MethodGet, "/api/genres/{id}", h.UseRecoveryLoggingAuth(domain.PermGenreRead, h.GetGenreByID))
+	api.HandleFunc(http.MethodPut, "/api/genres/{id}", h.UseRecoveryLoggingAuth(domain.PermGenreWrite, h.UpdateGenre))
+	api.HandleFunc(http.MethodDelete, "/api/genres/{id}", h.UseRecoveryLoggingAuth(domain.PermGenreDelete, h.DeleteGenre))
+
+	// Reviews endpoints
+	api.HandleFunc(http.MethodGet, "/api/reviews/{id}", h.UseRecoveryLoggingAuth(domain.PermReviewRead, h.GetReviewByID))
+	api.HandleFunc(http.MethodPut, "/api/reviews/{id}", h.UseRecoveryLoggingAuth(domain.PermReviewWrite, h.UpdateReview))
+	api.HandleFunc(http.MethodDelete, "/api/reviews/{id}", h.UseRecoveryLoggingAuth(domain.PermReviewWrite, h.DeleteReview))
+	api.HandleFunc(http.MethodGet, "/api/users/{id}/reviews", h.UseRecoveryLoggingAuth(domain.PermReviewRead, h.GetUserReviews))
+
+	// Users endpoints
+	api.HandleFunc(http.MethodGet, "/api/users", h.UseRecoveryLoggingAuth(domain.PermUserManage, h.GetUsers))
+	api.HandleFunc(http.MethodGet, "/api/users/{id}", h.UseRecoveryLoggingAuth(domain.PermUserManage, h.GetUser))
+	api.HandleFunc(http.MethodDelete, "/api/users/{id}", h.UseRecoveryLoggingAuth(domain.PermUserManage, h.DeleteUser))
+	api.HandleFunc(http.MethodPut, "/api/users/{id}/role", h.UseRecoveryLoggingAuth(domain.PermUserManage, h.ChangeUserRole))
+	api.HandleFunc(http.MethodPost, "/api/users/{id}/disable", h.UseRecoveryLoggingAuth(domain.PermUserManage, h.DisableUser))
+	api.HandleFunc(http.MethodPost, "/api/users/{id}/enable", h.UseRecoveryLoggingAuth(domain.PermUserManage, h.EnableUser))
+	api.HandleFunc(http.MethodDelete, "/api/users/{id}/sessions", h.UseRecoveryLoggingAuth(domain.PermUserManage, h.LogoutUser))
+	api.HandleFunc(http.MethodPost, "/api/users/{id}/unlock", h.UseRecoveryLoggingAuth(domain.PermUserManage, h.UnlockUser))
+
+	// Lists endpoints
+	api.HandleFunc(http.MethodGet, "/api/watchlist", h.UseRecoveryLoggingAuth(domain.PermListRead, h.GetWatchlist))
+	api.HandleFunc(http.MethodPut, "/api/watchlist/{filmId}", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.AddToWatchlist))
+	api.HandleFunc(http.MethodDelete, "/api/watchlist/{filmId}", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.RemoveFromWatchlist))
+	api.HandleFunc(http.MethodGet, "/api/watched", h.UseRecoveryLoggingAuth(domain.PermListRead, h.GetWatched))
+	api.HandleFunc(http.MethodPost, "/api/watched", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.AddWatched))
+	api.HandleFunc(http.MethodDelete, "/api/watched/{id}", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.DeleteWatched))
+	api.HandleFunc(http.MethodGet, "/api/lists", h.UseRecoveryLoggingAuth(domain.PermListRead, h.GetFilmLists))
+	api.HandleFunc(http.MethodPost, "/api/lists", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.CreateFilmList))
+	api.HandleFunc(http.MethodGet, "/api/lists/{id}", h.UseRecoveryLoggingAuth(domain.PermListRead, h.GetFilmList))
+	api.HandleFunc(http.MethodPut, "/api/lists/{id}", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.UpdateFilmList))
+	api.HandleFunc(http.MethodDelete, "/api/lists/{id}", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.DeleteFilmList))
+	api.HandleFunc(http.MethodPut, "/api/lists/{id}/films/{filmId}", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.AddFilmListItem))
+	api.HandleFunc(http.MethodDelete, "/api/lists/{id}/films/{filmId}", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.RemoveFilmListItem))
+	api.HandleFunc(http.MethodPost, "/api/lists/{id}/share", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.ShareFilmList))
+	api.HandleFunc(http.MethodDelete, "/api/lists/{id}/share", h.UseRecoveryLoggingAuth(domain.PermListWrite, h.UnshareFilmList))
+	api.HandleFunc(http.MethodGet, "/api/shared/lists/{token}", h.UseRecoveryLogging(h.GetSharedFilmList))
 
 	// Films endpoints
-	mux.HandleFunc("/api/create_films", h.UseRecoveryLoggingAuth(h.CreateFilm))
-	mux.HandleFunc("/api/update_films", h.UseRecoveryLoggingAuth(h.UpdateFilm))
-	mux.HandleFunc("/api/update_films_actors/", h.UseRecoveryLoggingAuth(h.UpdateFilmActors))
-	mux.HandleFunc("/api/films/", h.UseRecoveryLoggingAuth(h.DeleteFilm))
-	mux.HandleFunc("/api/search_films/", h.UseRecoveryLoggingAuth(h.SearchFilms))
-	mux.HandleFunc("/api/films", h.UseRecoveryLoggingAuth(h.GetAllFilms))
-
-	return &http.Server{
-		Addr:    listenAddr,
+	api.HandleFunc(http.MethodGet, "/api/films", h.UseRecoveryLoggingAuth(domain.PermFilmRead, h.GetAllFilms))
+	api.HandleFunc(http.MethodPost, "/api/films", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.CreateFilm))
+	api.HandleFunc(http.MethodGet, "/api/films/{id}", h.UseRecoveryLoggingAuth(domain.PermFilmRead, h.GetFilmByID))
+	api.HandleFunc(http.MethodPut, "/api/films/{id}", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.UpdateFilm))
+	api.HandleFunc(http.MethodPatch, "/api/films/{id}", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.PatchFilm))
+	api.HandleFunc(http.MethodDelete, "/api/films/{id}", h.UseRecoveryLoggingAuth(domain.PermFilmDelete, h.DeleteFilm))
+	api.HandleFunc(http.MethodPut, "/api/films/{id}/actors", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.UpdateFilmActors))
+	api.HandleFunc(http.MethodPost, "/api/films/{id}/actors/{actorId}", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.AddFilmActor))
+	api.HandleFunc(http.MethodDelete, "/api/films/{id}/actors/{actorId}", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.RemoveFilmActor))
+	api.HandleFunc(http.MethodPost, "/api/films/{id}/crew/{personId}", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.AddFilmCrew))
+	api.HandleFunc(http.MethodDelete, "/api/films/{id}/crew/{personId}", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.RemoveFilmCrew))
+	api.HandleFunc(http.MethodPut, "/api/films/{id}/genres", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.UpdateFilmGenres))
+	api.HandleFunc(http.MethodPost, "/api/films/{id}/genres/{genreId}", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.AddFilmGenre))
+	api.HandleFunc(http.MethodDelete, "/api/films/{id}/genres/{genreId}", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.RemoveFilmGenre))
+	api.HandleFunc(http.MethodGet, "/api/films/{id}/reviews", h.UseRecoveryLoggingAuth(domain.PermReviewRead, h.GetFilmReviews))
+	api.HandleFunc(http.MethodPost, "/api/films/{id}/reviews", h.UseRecoveryLoggingAuth(domain.PermReviewWrite, h.CreateReview))
+	api.HandleFunc(http.MethodGet, "/api/search_films", h.UseRecoveryLoggingAuth(domain.PermFilmRead, h.SearchFilms))
+
+	// Deprecated aliases kept for old clients
+	api.HandleFunc(http.MethodPost, "/api/create_actors", h.UseDeprecated("/api/actors", h.UseRecoveryLoggingAuth(domain.PermActorWrite, h.CreateActor)))
+	api.HandleFunc(http.MethodPut, "/api/update_actors", h.UseDeprecated("/api/actors/{id}", h.UseRecoveryLoggingAuth(domain.PermActorWrite, h.UpdateActor)))
+	api.HandleFunc(http.MethodPost, "/api/create_films", h.UseDeprecated("/api/films", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.CreateFilm)))
+	api.HandleFunc(http.MethodPut, "/api/update_films", h.UseDeprecated("/api/films/{id}", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.UpdateFilm)))
+	api.HandleFunc(http.MethodPost, "/api/update_films_actors/{id}", h.UseDeprecated("/api/films/{id}/actors", h.UseRecoveryLoggingAuth(domain.PermFilmWrite, h.UpdateFilmActors)))
+	api.HandleFunc(http.MethodGet, "/api/search_films/{pattern}", h.UseDeprecated("/api/search_films?q={pattern}", h.UseRecoveryLoggingAuth(domain.PermFilmRead, h.SearchFilms)))
+
+	mux := http.NewServeMux()
+	mux.Handle("/swagger/", httpSwagger.Handler(
+		httpSwagger.URL(fmt.Sprintf("%s/swagger/doc.json", constants.Host)),
+	))
+	mux.Handle("/api/", api)
+
+	srv := &http.Server{
+		Addr:    cfg.Http.Addr,
 		Handler: mux,
-	}, nil
+	}
+	closeServer := func(ctx context.Context) error {
+		return errors.Join(services.Wait(ctx), redisClient.Close(), dbConnect.Close())
+	}
+	return srv, closeServer, nil
 }
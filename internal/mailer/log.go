@@ -0,0 +1,22 @@
+package mailer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer writes the mails to the log instead of sending them, it stands
+// in for a mail server in local development.
+type LogMailer struct {
+	log *zap.Logger
+}
+
+func NewLogMailer(log *zap.Logger) *LogMailer {
+	return &LogMailer{log: log}
+}
+
+func (m *LogMailer) Send(_ context.Context, to, subject, body string) error {
+	m.log.Info("Mail", zap.String("to", to), zap.String("subject", subject), zap.String("body", body))
+	return nil
+}
@@ -0,0 +1,27 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Max425/film-library.git/internal/comfig"
+	"go.uber.org/zap"
+)
+
+// Mailer sends plain text mails.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// New returns the mailer of the driver, one that only logs the mails when
+// the driver is empty.
+func New(cfg config.MailConfig, log *zap.Logger) (Mailer, error) {
+	switch cfg.Driver {
+	case "", "log":
+		return NewLogMailer(log), nil
+	case "smtp":
+		return NewSMTPMailer(cfg)
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
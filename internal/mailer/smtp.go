@@ -0,0 +1,126 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/Max425/film-library.git/internal/comfig"
+)
+
+// smtpTimeout bounds a whole delivery, from the dial to the end of the
+// conversation, so a server that hangs does not keep the mail forever.
+const smtpTimeout = 30 * time.Second
+
+// SMTPMailer sends the mails through an SMTP server, authenticating when
+// a username is configured.
+type SMTPMailer struct {
+	addr    string
+	host    string
+	from    string
+	sender  string
+	auth    smtp.Auth
+	timeout time.Duration
+}
+
+// NewSMTPMailer fails when the from setting is not a mail address. The address
+// with the name goes to the From header, the bare one is the envelope sender.
+func NewSMTPMailer(cfg config.MailConfig) (*SMTPMailer, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mail from %q: %w", cfg.From, err)
+	}
+	m := &SMTPMailer{addr: net.JoinHostPort(cfg.Host, cfg.Port), host: cfg.Host, from: from.String(),
+		sender: from.Address, timeout: smtpTimeout}
+	if cfg.Username != "" {
+		m.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return m, nil
+}
+
+// Send delivers the mail as smtp.SendMail does, upgrading to TLS when the
+// server offers it, but gives up once the context is done or the timeout
+// passes, whichever comes first.
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	msg, err := buildMessage(m.from, to, subject, body, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	c, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err = c.Auth(m.auth); err != nil {
+			return err
+		}
+	}
+	if err = c.Mail(m.sender); err != nil {
+		return err
+	}
+	if err = c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// buildMessage makes an RFC 5322 message of a UTF-8 plain text body.
+func buildMessage(from, to, subject, body string, date time.Time) ([]byte, error) {
+	for _, header := range []string{from, to} {
+		if strings.ContainsAny(header, "\r\n") {
+			return nil, fmt.Errorf("invalid mail address %q", header)
+		}
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return msg.Bytes(), nil
+}
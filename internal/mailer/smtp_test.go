@@ -0,0 +1,142 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Max425/film-library.git/internal/comfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSMTPMailer(t *testing.T) {
+	m, err := NewSMTPMailer(config.MailConfig{From: "Film Library <noreply@localhost>", Host: "localhost", Port: "25"})
+	assert.NoError(t, err)
+	assert.Equal(t, `"Film Library" <noreply@localhost>`, m.from)
+	assert.Equal(t, "noreply@localhost", m.sender)
+
+	m, err = NewSMTPMailer(config.MailConfig{From: "noreply@localhost", Host: "localhost", Port: "25"})
+	assert.NoError(t, err)
+	assert.Equal(t, "<noreply@localhost>", m.from)
+	assert.Equal(t, "noreply@localhost", m.sender)
+
+	_, err = NewSMTPMailer(config.MailConfig{From: "Film Library", Host: "localhost", Port: "25"})
+	assert.Error(t, err)
+}
+
+func TestBuildMessage(t *testing.T) {
+	date := time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)
+
+	msg, err := buildMessage("noreply@localhost", "bob@example.com", "Confirm your mail", "Hello!\nBye.\n", date)
+	assert.NoError(t, err)
+	assert.Equal(t, "From: noreply@localhost\r\n"+
+		"To: bob@example.com\r\n"+
+		"Subject: Confirm your mail\r\n"+
+		"Date: Mon, 18 Mar 2024 12:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n"+
+		"\r\n"+
+		"Hello!\r\nBye.\r\n", string(msg))
+}
+
+func TestBuildMessage_Headers(t *testing.T) {
+	date := time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)
+
+	_, err := buildMessage("noreply@localhost", "bob@example.com\r\nBcc: eve@example.com", "Hi", "", date)
+	assert.Error(t, err)
+
+	msg, err := buildMessage("noreply@localhost", "bob@example.com", "Hi\r\nBcc: eve@example.com", "", date)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(msg), "\r\nBcc:")
+}
+
+// serveSMTP answers one SMTP conversation on the listener and sends the
+// commands the client gave, the message lines excluded.
+func serveSMTP(l net.Listener) <-chan []string {
+	commands := make(chan []string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			close(commands)
+			return
+		}
+		defer conn.Close()
+		text := textproto.NewConn(conn)
+		var got []string
+		defer func() { commands <- got }()
+
+		text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			got = append(got, line)
+			switch verb := strings.ToUpper(strings.Fields(line)[0]); verb {
+			case "EHLO":
+				text.PrintfLine("250 localhost")
+			case "DATA":
+				text.PrintfLine("354 go ahead")
+				if _, err = text.ReadDotLines(); err != nil {
+					return
+				}
+				text.PrintfLine("250 queued")
+			case "QUIT":
+				text.PrintfLine("221 bye")
+				return
+			default:
+				text.PrintfLine("250 ok")
+			}
+		}
+	}()
+	return commands
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	commands := serveSMTP(l)
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	m, err := NewSMTPMailer(config.MailConfig{From: "Film Library <noreply@localhost>", Host: host, Port: port})
+	assert.NoError(t, err)
+	assert.NoError(t, m.Send(context.Background(), "bob@example.com", "Hi", "Hello!"))
+	assert.Equal(t, []string{
+		"EHLO localhost",
+		"MAIL FROM:<noreply@localhost>",
+		"RCPT TO:<bob@example.com>",
+		"DATA",
+		"QUIT",
+	}, <-commands)
+}
+
+func TestSMTPMailer_Send_Timeout(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		// accepts and never answers
+		conn, err := l.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(time.Second)
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	m, err := NewSMTPMailer(config.MailConfig{From: "noreply@localhost", Host: host, Port: port})
+	assert.NoError(t, err)
+	m.timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	assert.Error(t, m.Send(context.Background(), "bob@example.com", "Hi", "Hello!"))
+	assert.Less(t, time.Since(start), time.Second)
+}
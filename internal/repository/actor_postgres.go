@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/repository/store"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -45,6 +48,27 @@ func (r *ActorRepository) FindActorByID(ctx context.Context, id int) (*domain.Ac
 		r.logger.Error("Failed to find actor by ID", zap.Error(err))
 		return nil, err
 	}
+
+	rolesQuery := `
+		SELECT f.id, f.title, f.description, f.release_date, f.rating, f.created_at, f.updated_at,
+			   fa.film_id, fa.actor_id, COALESCE(fa.role_name, '') AS role_name, fa.billing, fa.role_type
+		FROM film AS f
+		JOIN film_actor AS fa ON f.id = fa.film_id
+		WHERE fa.actor_id = $1
+		ORDER BY f.id
+	`
+	var roles []struct {
+		store.Film
+		store.Casting
+	}
+	if err = r.db.SelectContext(ctx, &roles, rolesQuery, id); err != nil {
+		r.logger.Error("Failed to find actor films", zap.Error(err))
+		return nil, err
+	}
+	for i := range roles {
+		storeActor.Films = append(storeActor.Films, &roles[i].Film)
+		storeActor.Roles = append(storeActor.Roles, &roles[i].Casting)
+	}
 	return store.ActorStoreToDomain(storeActor)
 }
 
@@ -69,18 +93,60 @@ func (r *ActorRepository) DeleteActor(ctx context.Context, id int) error {
 	return nil
 }
 
-func (r *ActorRepository) GetAllActors(ctx context.Context) ([]*domain.Actor, error) {
-	query := `
-		SELECT a.id, a.name, a.gender, a.birth_date, f.id AS film_id, f.title, f.description, f.release_date, f.rating
-		FROM actor AS a
-		LEFT JOIN film_actor AS fa ON a.id = fa.actor_id
+func (r *ActorRepository) GetAllActors(ctx context.Context, sort []domain.ActorSort, filter domain.ActorFilter, page domain.PageRequest) ([]*domain.Actor, *domain.PageInfo, error) {
+	keys, columns, err := actorSortKeys(sort)
+	if err != nil {
+		return nil, nil, err
+	}
+	cursorOf := func(actor *domain.Actor) *domain.Cursor {
+		var values []string
+		for _, column := range columns {
+			values = append(values, column.value(actor))
+		}
+		return &domain.Cursor{Values: values, ID: actor.GetId()}
+	}
+
+	where, args := actorFilterCondition(filter, "a", []any{})
+
+	var total int
+	if err = r.db.GetContext(ctx, &total, `SELECT count(*) FROM actor AS a WHERE `+where, args...); err != nil {
+		r.logger.Error("Failed to count actors", zap.Error(err))
+		return nil, nil, err
+	}
+
+	if page.Cursor != nil {
+		var keyset string
+		if keyset, args, err = keysetCondition(keys, "a", page.Cursor, args); err != nil {
+			return nil, nil, err
+		}
+		where += " AND " + keyset
+	}
+	backward := page.Cursor != nil && page.Cursor.Backward
+	limit, offset := limitOffset(page)
+	args = append(args, limit, offset)
+
+	query := fmt.Sprintf(`
+		WITH page AS (
+			SELECT a.id, a.name, a.gender, a.birth_date
+			FROM (
+				SELECT actor.*, (SELECT count(*) FROM film_actor WHERE film_actor.actor_id = actor.id) AS film_count
+				FROM actor
+			) AS a
+			WHERE %s
+			ORDER BY %s
+			LIMIT $%d OFFSET $%d
+		)
+		SELECT p.id, p.name, p.gender, p.birth_date, f.id AS film_id, f.title, f.description, f.release_date, f.rating,
+			   COALESCE(fa.role_name, ''), fa.billing, COALESCE(fa.role_type, '')
+		FROM page AS p
+		LEFT JOIN film_actor AS fa ON p.id = fa.actor_id
 		LEFT JOIN film AS f ON fa.film_id = f.id
-		ORDER BY a.id, f.id
-	`
-	rows, err := r.db.QueryContext(ctx, query)
+		ORDER BY %s, f.id
+	`, where, orderBy(keys, "a", backward), len(args)-1, len(args), orderBy(keys, "p", backward))
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		r.logger.Error("Failed to get all actors with films", zap.Error(err))
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -94,7 +160,10 @@ func (r *ActorRepository) GetAllActors(ctx context.Context) ([]*domain.Actor, er
 		var filmTitle, filmDescription sql.NullString
 		var filmReleaseDate sql.NullTime
 		var filmRating sql.NullFloat64
-		if err = rows.Scan(&actorID, &actorName, &actorGender, &actorBirthDate, &filmID, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating); err != nil {
+		var roleName, roleType string
+		var billing *int
+		if err = rows.Scan(&actorID, &actorName, &actorGender, &actorBirthDate, &filmID, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating,
+			&roleName, &billing, &roleType); err != nil {
 			r.logger.Error("Failed to scan row", zap.Error(err))
 			continue
 		}
@@ -107,12 +176,90 @@ func (r *ActorRepository) GetAllActors(ctx context.Context) ([]*domain.Actor, er
 		if filmID.Valid {
 			film, _ := domain.NewFilm(int(filmID.Int64), filmTitle.String, filmDescription.String, filmReleaseDate.Time, filmRating.Float64, nil)
 			currentActor.AddFilm(film)
+			currentActor.AddRole(&domain.Casting{
+				FilmID:   int(filmID.Int64),
+				ActorID:  actorID,
+				RoleName: roleName,
+				Billing:  billing,
+				RoleType: domain.RoleType(roleType),
+			})
 		}
 	}
 	if err = rows.Err(); err != nil {
 		r.logger.Error("Error while iterating rows", zap.Error(err))
-		return nil, err
+		return nil, nil, err
+	}
+
+	actors, info := paginate(actors, page, total, keys, cursorOf)
+	return actors, info, nil
+}
+
+// actorFilterCondition renders the filter as a condition on the actor table
+// under alias. Filter values are appended to args as placeholders.
+func actorFilterCondition(filter domain.ActorFilter, alias string, args []any) (string, []any) {
+	conditions := []string{"TRUE"}
+	add := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, alias, len(args)))
+	}
+
+	if filter.Name != "" {
+		add(`%s.name ILIKE $%d ESCAPE '\'`, "%"+likeEscaper.Replace(filter.Name)+"%")
+	}
+	if filter.Gender != "" {
+		add("%s.gender = $%d", filter.Gender)
+	}
+	if filter.BornFrom != nil {
+		add("%s.birth_date >= $%d", *filter.BornFrom)
 	}
+	if filter.BornTo != nil {
+		add("%s.birth_date <= $%d", *filter.BornTo)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+type actorSortColumn struct {
+	name  string
+	cast  string
+	value func(actor *domain.Actor) string
+}
 
-	return actors, nil
+// actorSortColumns maps the sort fields to actor columns. Only the columns
+// listed here ever get into ORDER BY.
+var actorSortColumns = map[domain.ActorSortField]actorSortColumn{
+	domain.ActorSortName: {
+		name:  "name",
+		cast:  "text",
+		value: func(actor *domain.Actor) string { return actor.GetName() },
+	},
+	domain.ActorSortBirthDate: {
+		name:  "birth_date",
+		cast:  "date",
+		value: func(actor *domain.Actor) string { return actor.GetBirthDate().Format("2006-01-02") },
+	},
+	domain.ActorSortFilmCount: {
+		name:  "film_count",
+		cast:  "bigint",
+		value: func(actor *domain.Actor) string { return strconv.Itoa(len(actor.GetFilms())) },
+	},
+}
+
+// actorSortKeys turns the ordering into sort keys ending with id, which makes
+// the order stable. The id follows the direction of the last field. Only the
+// known fields map to columns, anything else is refused.
+func actorSortKeys(sort []domain.ActorSort) ([]sortKey, []actorSortColumn, error) {
+	keys := make([]sortKey, 0, len(sort)+1)
+	columns := make([]actorSortColumn, 0, len(sort))
+	for _, s := range sort {
+		column, ok := actorSortColumns[s.Field]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: unknown field %q", domain.ErrInvalidSort, s.Field)
+		}
+		keys = append(keys, sortKey{name: column.name, cast: column.cast, desc: s.Desc})
+		columns = append(columns, column)
+	}
+	idDesc := len(sort) > 0 && sort[len(sort)-1].Desc
+	keys = append(keys, sortKey{name: "id", cast: "int", desc: idDesc})
+	return keys, columns, nil
 }
@@ -62,10 +62,18 @@ func TestActorRepository_FindActorByID(t *testing.T) {
 		WithArgs(actorID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "birth_date"}).
 			AddRow(storeActor.ID, storeActor.Name, storeActor.Gender, storeActor.BirthDate))
+	mock.ExpectQuery("SELECT (.+) FROM film").
+		WithArgs(actorID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "film_id", "actor_id", "role_name", "billing", "role_type"}).
+			AddRow(1, "Test Film", "Test Description", time.Unix(0, 0), 4.5, 1, actorID, "Narrator", nil, "voice"))
 
 	result, err := r.FindActorByID(context.Background(), actorID)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
+	assert.Len(t, result.GetFilms(), 1)
+	assert.Equal(t, "Test Film", result.GetFilms()[0].GetTitle())
+	assert.Equal(t, []*domain.Casting{{FilmID: 1, ActorID: actorID, RoleName: "Narrator", RoleType: domain.RoleTypeVoice}}, result.GetRoles())
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestActorRepository_UpdateActor(t *testing.T) {
@@ -125,26 +133,27 @@ func TestActorRepository_GetAllActors(t *testing.T) {
 	logger := zap.NewNop()
 	r := NewActorRepository(db, logger)
 
-	query := `
-		SELECT a.id, a.name, a.gender, a.birth_date, f.id AS film_id, f.title, f.description, f.release_date, f.rating
-		FROM actor AS a
-		LEFT JOIN film_actor AS fa ON a.id = fa.actor_id
-		LEFT JOIN film AS f ON fa.film_id = f.id
-		ORDER BY a.id, f.id
-	`
+	mock.ExpectQuery("SELECT count(.+) FROM actor").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
 
-	rows := sqlmock.NewRows([]string{"id", "name", "gender", "birth_date", "film_id", "title", "description", "release_date", "rating"}).
-		AddRow(1, "Actor 1", "male", time.Unix(0, 0), 1, "Film 1", "Description 1", time.Unix(0, 0), 7.5).
-		AddRow(1, "Actor 1", "male", time.Unix(0, 0), 2, "Film 2", "Description 2", time.Unix(0, 0), 8.0).
-		AddRow(2, "Actor 2", "male", time.Unix(0, 0), 3, "Film 3", "Description 3", time.Unix(0, 0), 6.5)
+	rows := sqlmock.NewRows([]string{"id", "name", "gender", "birth_date", "film_id", "title", "description", "release_date", "rating", "role_name", "billing", "role_type"}).
+		AddRow(1, "Actor 1", "male", time.Unix(0, 0), 1, "Film 1", "Description 1", time.Unix(0, 0), 7.5, "", nil, "regular").
+		AddRow(1, "Actor 1", "male", time.Unix(0, 0), 2, "Film 2", "Description 2", time.Unix(0, 0), 8.0, "", nil, "regular").
+		AddRow(2, "Actor 2", "male", time.Unix(0, 0), 3, "Film 3", "Description 3", time.Unix(0, 0), 6.5, "", nil, "regular").
+		AddRow(3, "Actor 3", "female", time.Unix(0, 0), nil, nil, nil, nil, nil, "", nil, "")
 
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery("WITH page AS (.+) ORDER BY a.id ASC LIMIT (.+) SELECT (.+) FROM page AS p").
+		WithArgs(3, 0).
+		WillReturnRows(rows)
 
-	results, err := r.GetAllActors(context.Background())
+	results, info, err := r.GetAllActors(context.Background(), nil, domain.ActorFilter{}, domain.PageRequest{Limit: 2})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, results)
 	assert.Len(t, results, 2)
+	assert.Equal(t, 3, info.Total)
+	assert.Nil(t, info.PrevCursor)
+	assert.Equal(t, &domain.Cursor{Sort: "id:asc", ID: 2}, info.NextCursor)
 
 	assert.Equal(t, 1, results[0].GetId())
 	assert.Equal(t, "Actor 1", results[0].GetName())
@@ -157,3 +166,71 @@ func TestActorRepository_GetAllActors(t *testing.T) {
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestActorRepository_GetAllActors_SearchAndSort(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewActorRepository(db, logger)
+
+	from := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+	filter := domain.ActorFilter{Name: "act_", Gender: "male", BornFrom: &from}
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM actor AS a WHERE TRUE AND a.name ILIKE \$1 (.+) AND a.gender = \$2 AND a.birth_date >= \$3`).
+		WithArgs(`%act\_%`, "male", from).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	rows := sqlmock.NewRows([]string{"id", "name", "gender", "birth_date", "film_id", "title", "description", "release_date", "rating", "role_name", "billing", "role_type"}).
+		AddRow(2, "Actor 2", "male", time.Unix(0, 0), 1, "Film 1", "Description 1", time.Unix(0, 0), 7.5, "", nil, "regular").
+		AddRow(2, "Actor 2", "male", time.Unix(0, 0), 2, "Film 2", "Description 2", time.Unix(0, 0), 8.0, "", nil, "regular").
+		AddRow(1, "Actor 1", "male", time.Unix(0, 0), 3, "Film 3", "Description 3", time.Unix(0, 0), 6.5, "", nil, "regular")
+
+	mock.ExpectQuery("WITH page AS (.+) AS film_count (.+) ORDER BY a.film_count DESC, a.name ASC, a.id ASC (.+) ORDER BY p.film_count DESC, p.name ASC, p.id ASC, f.id").
+		WithArgs(`%act\_%`, "male", from, 3, 0).
+		WillReturnRows(rows)
+
+	sort := []domain.ActorSort{{Field: domain.ActorSortFilmCount, Desc: true}, {Field: domain.ActorSortName}}
+	results, info, err := r.GetAllActors(context.Background(), sort, filter, domain.PageRequest{Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, 2, results[0].GetId())
+	assert.Equal(t, 2, info.Total)
+	assert.Nil(t, info.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActorRepository_GetAllActors_Cursor(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewActorRepository(db, logger)
+
+	mock.ExpectQuery("SELECT count(.+) FROM actor").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	rows := sqlmock.NewRows([]string{"id", "name", "gender", "birth_date", "film_id", "title", "description", "release_date", "rating", "role_name", "billing", "role_type"}).
+		AddRow(3, "Actor 3", "female", time.Unix(0, 0), 1, "Film 1", "Description 1", time.Unix(0, 0), 7.5, "", nil, "regular").
+		AddRow(1, "Actor 1", "male", time.Unix(0, 0), nil, nil, nil, nil, nil, "", nil, "")
+
+	mock.ExpectQuery("WITH page AS (.+) WHERE TRUE AND \\(\\(a.film_count < \\$1::bigint\\) OR \\(a.film_count = \\$1::bigint AND a.id < \\$2::int\\)\\)").
+		WithArgs("2", "2", 2, 0).
+		WillReturnRows(rows)
+
+	sort := []domain.ActorSort{{Field: domain.ActorSortFilmCount, Desc: true}}
+	cursor := &domain.Cursor{Sort: "film_count:desc,id:desc", Values: []string{"2"}, ID: 2}
+	results, info, err := r.GetAllActors(context.Background(), sort, domain.ActorFilter{}, domain.PageRequest{Limit: 1, Cursor: cursor})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, &domain.Cursor{Sort: "film_count:desc,id:desc", Values: []string{"1"}, ID: 3}, info.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -8,8 +8,12 @@ import (
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/repository/store"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 )
 
 type FilmRepository struct {
@@ -37,7 +41,7 @@ func (r *FilmRepository) CreateFilm(ctx context.Context, film *domain.Film) (*do
 
 func (r *FilmRepository) FindFilmByID(ctx context.Context, id int) (*domain.Film, error) {
 	storeFilm := &store.Film{}
-	query := `SELECT * FROM film WHERE id = $1`
+	query := `SELECT id, title, description, release_date, rating, user_rating, vote_count, created_at, updated_at FROM film WHERE id = $1`
 	err := r.db.GetContext(ctx, storeFilm, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -46,6 +50,52 @@ func (r *FilmRepository) FindFilmByID(ctx context.Context, id int) (*domain.Film
 		r.logger.Error("Failed to find film by ID", zap.Error(err))
 		return nil, err
 	}
+
+	castQuery := `
+		SELECT a.id, a.name, a.gender, a.birth_date, a.created_at, a.updated_at,
+			   fa.film_id, fa.actor_id, COALESCE(fa.role_name, '') AS role_name, fa.billing, fa.role_type
+		FROM actor AS a
+		JOIN film_actor AS fa ON a.id = fa.actor_id
+		WHERE fa.film_id = $1
+		ORDER BY fa.billing NULLS LAST, a.id
+	`
+	var cast []struct {
+		store.Actor
+		store.Casting
+	}
+	if err = r.db.SelectContext(ctx, &cast, castQuery, id); err != nil {
+		r.logger.Error("Failed to find film actors", zap.Error(err))
+		return nil, err
+	}
+	for i := range cast {
+		storeFilm.Actors = append(storeFilm.Actors, &cast[i].Actor)
+		storeFilm.Cast = append(storeFilm.Cast, &cast[i].Casting)
+	}
+
+	crewQuery := `
+		SELECT fc.film_id, fc.person_id, fc.job, f.title AS film_title, p.name AS person_name
+		FROM film_crew AS fc
+		JOIN film AS f ON fc.film_id = f.id
+		JOIN person AS p ON fc.person_id = p.id
+		WHERE fc.film_id = $1
+		ORDER BY array_position(ARRAY['director', 'writer', 'producer', 'composer'], fc.job::text), p.name, p.id
+	`
+	if err = r.db.SelectContext(ctx, &storeFilm.Crew, crewQuery, id); err != nil {
+		r.logger.Error("Failed to find film crew", zap.Error(err))
+		return nil, err
+	}
+
+	genresQuery := `
+		SELECT g.id, g.name, g.created_at, g.updated_at
+		FROM genre AS g
+		JOIN film_genre AS fg ON g.id = fg.genre_id
+		WHERE fg.film_id = $1
+		ORDER BY g.name, g.id
+	`
+	if err = r.db.SelectContext(ctx, &storeFilm.Genres, genresQuery, id); err != nil {
+		r.logger.Error("Failed to find film genres", zap.Error(err))
+		return nil, err
+	}
 	return store.FilmStoreToDomain(storeFilm)
 }
 
@@ -60,25 +110,224 @@ func (r *FilmRepository) UpdateFilm(ctx context.Context, film *domain.Film) (*do
 	return film, nil
 }
 
+// UpdateFilmActors replaces the cast of the film in one transaction. Unknown
+// actor ids fail the whole replace with ErrUnknownActors listing them.
 func (r *FilmRepository) UpdateFilmActors(ctx context.Context, id int, actorsID []int) (*domain.Film, error) {
-	deleteQuery := `DELETE FROM film_actor WHERE film_id = $1`
-	_, err := r.db.ExecContext(ctx, deleteQuery, id)
+	err := withTx(ctx, r.db, r.logger, nil, func(tx *sqlx.Tx) error {
+		// lock the film, so concurrent replaces of its cast do not interleave
+		var filmID int
+		if err := tx.GetContext(ctx, &filmID, `SELECT id FROM film WHERE id = $1 FOR UPDATE`, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrNotFound
+			}
+			r.logger.Error("Failed to lock film", zap.Error(err))
+			return err
+		}
+
+		var existing []int
+		if err := tx.SelectContext(ctx, &existing, `SELECT id FROM actor WHERE id = ANY($1)`, pq.Array(actorsID)); err != nil {
+			r.logger.Error("Failed to find film actors", zap.Error(err))
+			return err
+		}
+		if missing := missingIDs(actorsID, existing); len(missing) > 0 {
+			return fmt.Errorf("%w: %s", domain.ErrUnknownActors, joinInts(missing))
+		}
+
+		// actors staying in the cast keep their rows, and so their roles
+		deleteQuery := `DELETE FROM film_actor WHERE film_id = $1 AND NOT (actor_id = ANY($2))`
+		if _, err := tx.ExecContext(ctx, deleteQuery, id, pq.Array(actorsID)); err != nil {
+			r.logger.Error("Failed to delete film actors", zap.Error(err))
+			return err
+		}
+		if len(actorsID) == 0 {
+			return nil
+		}
+
+		insertQuery := `INSERT INTO film_actor (film_id, actor_id) SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING`
+		if _, err := tx.ExecContext(ctx, insertQuery, id, pq.Array(actorsID)); err != nil {
+			r.logger.Error("Failed to insert film actors", zap.Error(err))
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		r.logger.Error("Failed to delete film actors", zap.Error(err))
 		return nil, err
 	}
 
-	for _, actorID := range actorsID {
-		insertQuery := `INSERT INTO film_actor (film_id, actor_id) VALUES ($1, $2)`
-		_, err = r.db.ExecContext(ctx, insertQuery, id, actorID)
-		if err != nil {
-			r.logger.Error("Failed to insert film actor", zap.Error(err))
+	return r.FindFilmByID(ctx, id)
+}
+
+// AddFilmActor adds the actor to the cast of the film. Adding an actor that
+// is already there only changes the role, and only when role details are set.
+func (r *FilmRepository) AddFilmActor(ctx context.Context, casting domain.Casting) error {
+	query := `
+		WITH film_row AS (
+			SELECT id FROM film WHERE id = $1
+		), actor_row AS (
+			SELECT id FROM actor WHERE id = $2
+		), inserted AS (
+			INSERT INTO film_actor (film_id, actor_id, role_name, billing, role_type)
+			SELECT film_row.id, actor_row.id, NULLIF($3, ''), $4, COALESCE(NULLIF($5, ''), 'regular')
+			FROM film_row, actor_row
+			ON CONFLICT (film_id, actor_id) DO UPDATE
+			SET role_name = EXCLUDED.role_name, billing = EXCLUDED.billing, role_type = EXCLUDED.role_type
+			WHERE $6
+		)
+		SELECT EXISTS (SELECT 1 FROM film_row), EXISTS (SELECT 1 FROM actor_row)
+	`
+	var filmExists, actorExists bool
+	err := r.db.QueryRowContext(ctx, query, casting.FilmID, casting.ActorID,
+		casting.RoleName, casting.Billing, string(casting.RoleType), casting.HasRole()).Scan(&filmExists, &actorExists)
+	if err != nil {
+		r.logger.Error("Failed to add film actor", zap.Error(err))
+		return err
+	}
+	if !filmExists {
+		return fmt.Errorf("%w: film %d", domain.ErrNotFound, casting.FilmID)
+	}
+	if !actorExists {
+		return fmt.Errorf("%w: actor %d", domain.ErrNotFound, casting.ActorID)
+	}
+	return nil
+}
+
+// RemoveFilmActor removes the actor from the cast of the film, removing an
+// actor that is not there changes nothing.
+func (r *FilmRepository) RemoveFilmActor(ctx context.Context, filmID, actorID int) error {
+	query := `DELETE FROM film_actor WHERE film_id = $1 AND actor_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, filmID, actorID); err != nil {
+		r.logger.Error("Failed to remove film actor", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// AddFilmCrew credits the person with the job on the film, adding a credit
+// that is already there changes nothing.
+func (r *FilmRepository) AddFilmCrew(ctx context.Context, credit domain.Credit) error {
+	query := `
+		WITH film_row AS (
+			SELECT id FROM film WHERE id = $1
+		), person_row AS (
+			SELECT id FROM person WHERE id = $2
+		), inserted AS (
+			INSERT INTO film_crew (film_id, person_id, job)
+			SELECT film_row.id, person_row.id, $3 FROM film_row, person_row
+			ON CONFLICT DO NOTHING
+		)
+		SELECT EXISTS (SELECT 1 FROM film_row), EXISTS (SELECT 1 FROM person_row)
+	`
+	var filmExists, personExists bool
+	err := r.db.QueryRowContext(ctx, query, credit.FilmID, credit.PersonID, string(credit.Job)).Scan(&filmExists, &personExists)
+	if err != nil {
+		r.logger.Error("Failed to add film crew", zap.Error(err))
+		return err
+	}
+	if !filmExists {
+		return fmt.Errorf("%w: film %d", domain.ErrNotFound, credit.FilmID)
+	}
+	if !personExists {
+		return fmt.Errorf("%w: person %d", domain.ErrNotFound, credit.PersonID)
+	}
+	return nil
+}
+
+// RemoveFilmCrew removes the credit of the person on the film. Without a job
+// every credit of the person on the film is removed.
+func (r *FilmRepository) RemoveFilmCrew(ctx context.Context, credit domain.Credit) error {
+	query := `DELETE FROM film_crew WHERE film_id = $1 AND person_id = $2 AND ($3 = '' OR job = $3)`
+	if _, err := r.db.ExecContext(ctx, query, credit.FilmID, credit.PersonID, string(credit.Job)); err != nil {
+		r.logger.Error("Failed to remove film crew", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UpdateFilmGenres replaces the genres of the film in one transaction. Unknown
+// genre ids fail the whole replace with ErrUnknownGenres listing them.
+func (r *FilmRepository) UpdateFilmGenres(ctx context.Context, id int, genreIDs []int) (*domain.Film, error) {
+	err := withTx(ctx, r.db, r.logger, nil, func(tx *sqlx.Tx) error {
+		var filmID int
+		if err := tx.GetContext(ctx, &filmID, `SELECT id FROM film WHERE id = $1 FOR UPDATE`, id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrNotFound
+			}
+			r.logger.Error("Failed to lock film", zap.Error(err))
+			return err
+		}
+
+		var existing []int
+		if err := tx.SelectContext(ctx, &existing, `SELECT id FROM genre WHERE id = ANY($1)`, pq.Array(genreIDs)); err != nil {
+			r.logger.Error("Failed to find film genres", zap.Error(err))
+			return err
+		}
+		if missing := missingIDs(genreIDs, existing); len(missing) > 0 {
+			return fmt.Errorf("%w: %s", domain.ErrUnknownGenres, joinInts(missing))
+		}
+
+		deleteQuery := `DELETE FROM film_genre WHERE film_id = $1 AND NOT (genre_id = ANY($2))`
+		if _, err := tx.ExecContext(ctx, deleteQuery, id, pq.Array(genreIDs)); err != nil {
+			r.logger.Error("Failed to delete film genres", zap.Error(err))
+			return err
+		}
+		if len(genreIDs) == 0 {
+			return nil
+		}
+
+		insertQuery := `INSERT INTO film_genre (film_id, genre_id) SELECT $1, unnest($2::int[]) ON CONFLICT DO NOTHING`
+		if _, err := tx.ExecContext(ctx, insertQuery, id, pq.Array(genreIDs)); err != nil {
+			r.logger.Error("Failed to insert film genres", zap.Error(err))
+			return err
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return r.FindFilmByID(ctx, id)
 }
 
+// AddFilmGenre adds the genre to the film, adding a genre that is already
+// there changes nothing.
+func (r *FilmRepository) AddFilmGenre(ctx context.Context, filmID, genreID int) error {
+	query := `
+		WITH film_row AS (
+			SELECT id FROM film WHERE id = $1
+		), genre_row AS (
+			SELECT id FROM genre WHERE id = $2
+		), inserted AS (
+			INSERT INTO film_genre (film_id, genre_id)
+			SELECT film_row.id, genre_row.id FROM film_row, genre_row
+			ON CONFLICT DO NOTHING
+		)
+		SELECT EXISTS (SELECT 1 FROM film_row), EXISTS (SELECT 1 FROM genre_row)
+	`
+	var filmExists, genreExists bool
+	if err := r.db.QueryRowContext(ctx, query, filmID, genreID).Scan(&filmExists, &genreExists); err != nil {
+		r.logger.Error("Failed to add film genre", zap.Error(err))
+		return err
+	}
+	if !filmExists {
+		return fmt.Errorf("%w: film %d", domain.ErrNotFound, filmID)
+	}
+	if !genreExists {
+		return fmt.Errorf("%w: genre %d", domain.ErrNotFound, genreID)
+	}
+	return nil
+}
+
+// RemoveFilmGenre removes the genre from the film, removing a genre that is
+// not there changes nothing.
+func (r *FilmRepository) RemoveFilmGenre(ctx context.Context, filmID, genreID int) error {
+	query := `DELETE FROM film_genre WHERE film_id = $1 AND genre_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, filmID, genreID); err != nil {
+		r.logger.Error("Failed to remove film genre", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func (r *FilmRepository) DeleteFilm(ctx context.Context, id int) error {
 	query := `DELETE FROM film WHERE id = $1`
 	_, err := r.db.ExecContext(ctx, query, id)
@@ -89,19 +338,58 @@ func (r *FilmRepository) DeleteFilm(ctx context.Context, id int) error {
 	return nil
 }
 
-func (r *FilmRepository) GetAllFilms(ctx context.Context, sortBy, order string) ([]*domain.Film, error) {
+func (r *FilmRepository) GetAllFilms(ctx context.Context, sort []domain.FilmSort, filter domain.FilmFilter, page domain.PageRequest) ([]*domain.Film, *domain.PageInfo, error) {
+	keys, columns, err := filmSortKeys(sort)
+	if err != nil {
+		return nil, nil, err
+	}
+	cursorOf := func(film *domain.Film) *domain.Cursor {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = column.value(film)
+		}
+		return &domain.Cursor{Values: values, ID: film.GetId()}
+	}
+
+	where, args := filmFilterCondition(filter, "f", []any{})
+
+	var total int
+	if err = r.db.GetContext(ctx, &total, `SELECT count(*) FROM film AS f WHERE `+where, args...); err != nil {
+		r.logger.Error("Failed to count films", zap.Error(err))
+		return nil, nil, err
+	}
+
+	if page.Cursor != nil {
+		var keyset string
+		if keyset, args, err = keysetCondition(keys, "f", page.Cursor, args); err != nil {
+			return nil, nil, err
+		}
+		where += " AND " + keyset
+	}
+	backward := page.Cursor != nil && page.Cursor.Backward
+	limit, offset := limitOffset(page)
+	args = append(args, limit, offset)
+
 	query := fmt.Sprintf(`
-	SELECT f.id, f.title, f.description, f.release_date, f.rating,
-		   a.id AS actor_id, COALESCE(a.name, ''), COALESCE(a.gender, ''), COALESCE(a.birth_date, '0001-01-01')
-	FROM film AS f
-	LEFT JOIN film_actor AS fa ON f.id = fa.film_id
+	WITH page AS (
+		SELECT f.id, f.title, COALESCE(f.description, '') AS description, f.release_date, f.rating, f.user_rating, f.vote_count
+		FROM film AS f
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	)
+	SELECT p.id, p.title, p.description, p.release_date, p.rating, p.user_rating, p.vote_count,
+		   a.id AS actor_id, COALESCE(a.name, ''), COALESCE(a.gender, ''), COALESCE(a.birth_date, '0001-01-01'),
+		   COALESCE(fa.role_name, ''), fa.billing, COALESCE(fa.role_type, '')
+	FROM page AS p
+	LEFT JOIN film_actor AS fa ON p.id = fa.film_id
 	LEFT JOIN actor AS a ON fa.actor_id = a.id
-	ORDER BY %s %s
-`, sortBy, order)
-	rows, err := r.db.QueryContext(ctx, query)
+	ORDER BY %s, fa.billing NULLS LAST, a.id
+`, where, orderBy(keys, "f", backward), len(args)-1, len(args), orderBy(keys, "p", backward))
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		r.logger.Error("Failed to get all films with actors", zap.Error(err))
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -111,69 +399,533 @@ func (r *FilmRepository) GetAllFilms(ctx context.Context, sortBy, order string)
 		var filmID int
 		var filmTitle, filmDescription string
 		var filmReleaseDate time.Time
-		var filmRating float64
+		var filmRating, filmUserRating float64
+		var filmVoteCount int
 		var actorID sql.NullInt64
 		var actorName, actorGender sql.NullString
 		var actorBirthDate time.Time
+		var roleName, roleType string
+		var billing *int
 
-		if err = rows.Scan(&filmID, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating, &actorID, &actorName, &actorGender, &actorBirthDate); err != nil {
+		if err = rows.Scan(&filmID, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating, &filmUserRating, &filmVoteCount, &actorID, &actorName, &actorGender, &actorBirthDate,
+			&roleName, &billing, &roleType); err != nil {
 			r.logger.Error("Failed to scan row", zap.Error(err))
 			continue
 		}
 
 		if currentFilm == nil || currentFilm.GetId() != filmID {
 			currentFilm, _ = domain.NewFilm(filmID, filmTitle, filmDescription, filmReleaseDate, filmRating, nil)
+			currentFilm.SetUserRating(filmUserRating, filmVoteCount)
 			films = append(films, currentFilm)
 		}
 
 		if actorID.Valid {
 			actor, _ := domain.NewActor(int(actorID.Int64), actorName.String, actorGender.String, actorBirthDate, nil)
 			currentFilm.AddActor(actor)
+			currentFilm.AddCasting(&domain.Casting{
+				FilmID:   filmID,
+				ActorID:  int(actorID.Int64),
+				RoleName: roleName,
+				Billing:  billing,
+				RoleType: domain.RoleType(roleType),
+			})
 		}
 	}
 	if err = rows.Err(); err != nil {
 		r.logger.Error("Error while iterating rows", zap.Error(err))
-		return nil, err
+		return nil, nil, err
 	}
 
-	return films, nil
+	films, info := paginate(films, page, total, keys, cursorOf)
+	if err = r.addFilmsGenres(ctx, films); err != nil {
+		return nil, nil, err
+	}
+	return films, info, nil
+}
+
+// addFilmsGenres reads the genres of all the films in one query.
+func (r *FilmRepository) addFilmsGenres(ctx context.Context, films []*domain.Film) error {
+	if len(films) == 0 {
+		return nil
+	}
+	byID := make(map[int]*domain.Film, len(films))
+	filmIDs := make([]int, len(films))
+	for i, film := range films {
+		byID[film.GetId()] = film
+		filmIDs[i] = film.GetId()
+	}
+
+	query := `
+		SELECT fg.film_id, g.id, g.name
+		FROM film_genre AS fg
+		JOIN genre AS g ON fg.genre_id = g.id
+		WHERE fg.film_id = ANY($1)
+		ORDER BY g.name, g.id
+	`
+	var rows []struct {
+		FilmID int `db:"film_id"`
+		store.Genre
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, pq.Array(filmIDs)); err != nil {
+		r.logger.Error("Failed to get films genres", zap.Error(err))
+		return err
+	}
+	for i := range rows {
+		genre, err := store.GenreStoreToDomain(&rows[i].Genre)
+		if err != nil {
+			r.logger.Error("Failed to convert genre", zap.Error(err))
+			continue
+		}
+		byID[rows[i].FilmID].AddGenre(genre)
+	}
+	return nil
 }
 
-func (r *FilmRepository) SearchFilms(ctx context.Context, fragment string) ([]*domain.Film, error) {
+// AddFilmUserStates tells for each film where the user keeps it.
+func (r *FilmRepository) AddFilmUserStates(ctx context.Context, userID int, films []*domain.Film) error {
+	if len(films) == 0 {
+		return nil
+	}
+	byID := make(map[int]*domain.Film, len(films))
+	filmIDs := make([]int, len(films))
+	for i, film := range films {
+		byID[film.GetId()] = film
+		filmIDs[i] = film.GetId()
+	}
+
 	query := `
-		SELECT DISTINCT f.id, f.title, f.description, f.release_date, f.rating
+		SELECT f.id,
+			   EXISTS (SELECT 1 FROM watchlist AS w WHERE w.user_id = $1 AND w.film_id = f.id),
+			   (SELECT count(*) FROM watched AS wd WHERE wd.user_id = $1 AND wd.film_id = f.id),
+			   ARRAY(
+				   SELECT i.list_id FROM film_list_item AS i
+				   JOIN film_list AS l ON i.list_id = l.id
+				   WHERE l.user_id = $1 AND i.film_id = f.id
+				   ORDER BY i.list_id
+			   )
 		FROM film AS f
-		LEFT JOIN film_actor AS fa ON f.id = fa.film_id
-		LEFT JOIN actor AS a ON fa.actor_id = a.id
-		WHERE f.title ILIKE '%' || $1 || '%' OR a.name ILIKE '%' || $1 || '%'
-		ORDER BY f.rating DESC
+		WHERE f.id = ANY($2)
 	`
-	rows, err := r.db.QueryContext(ctx, query, fragment)
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(filmIDs))
 	if err != nil {
-		r.logger.Error("Failed to search films", zap.Error(err))
+		r.logger.Error("Failed to get film user states", zap.Error(err))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var filmID int
+		var inWatchlist bool
+		var watchCount int
+		var listIDs pq.Int64Array
+		if err = rows.Scan(&filmID, &inWatchlist, &watchCount, &listIDs); err != nil {
+			r.logger.Error("Failed to scan row", zap.Error(err))
+			continue
+		}
+
+		state := &domain.FilmUserState{InWatchlist: inWatchlist, WatchCount: watchCount, ListIDs: make([]int, len(listIDs))}
+		for i, id := range listIDs {
+			state.ListIDs[i] = int(id)
+		}
+		byID[filmID].SetUserState(state)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Error while iterating rows", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetFilmGenreFacets counts the films matching the filter in every genre.
+// Genres without such films are left out.
+func (r *FilmRepository) GetFilmGenreFacets(ctx context.Context, filter domain.FilmFilter) ([]*domain.GenreCount, error) {
+	where, args := filmFilterCondition(filter, "f", []any{})
+	query := `
+		SELECT g.id, g.name, g.created_at, g.updated_at, count(*) AS film_count
+		FROM genre AS g
+		JOIN film_genre AS facet ON g.id = facet.genre_id
+		JOIN film AS f ON facet.film_id = f.id
+		WHERE ` + where + `
+		GROUP BY g.id
+		ORDER BY film_count DESC, g.name, g.id
+	`
+	var storeCounts []*store.GenreCount
+	if err := r.db.SelectContext(ctx, &storeCounts, query, args...); err != nil {
+		r.logger.Error("Failed to count films by genre", zap.Error(err))
 		return nil, err
 	}
+
+	counts := make([]*domain.GenreCount, 0, len(storeCounts))
+	for _, storeCount := range storeCounts {
+		genre, err := store.GenreStoreToDomain(&storeCount.Genre)
+		if err != nil {
+			r.logger.Error("Failed to convert genre", zap.Error(err))
+			continue
+		}
+		counts = append(counts, &domain.GenreCount{Genre: genre, Count: storeCount.Count})
+	}
+	return counts, nil
+}
+
+func (r *FilmRepository) SearchFilms(ctx context.Context, query string, page domain.PageRequest) ([]*domain.FilmMatch, *domain.PageInfo, error) {
+	tsQuery := prefixTSQuery(query)
+	if tsQuery == "" {
+		return nil, nil, domain.ErrInvalidQuery
+	}
+	keys := []sortKey{{name: "rank", cast: "real", desc: true}, {name: "id", cast: "int", desc: true}}
+	cursorOf := func(match *domain.FilmMatch) *domain.Cursor {
+		return &domain.Cursor{Values: []string{strconv.FormatFloat(match.Rank, 'g', -1, 32)}, ID: match.Film.GetId()}
+	}
+
+	var total int
+	countQuery := `SELECT count(*) FROM film WHERE search_vector @@ to_tsquery('simple', $1)`
+	if err := r.db.GetContext(ctx, &total, countQuery, tsQuery); err != nil {
+		r.logger.Error("Failed to count found films", zap.Error(err))
+		return nil, nil, err
+	}
+	if total == 0 {
+		matches, info := paginate([]*domain.FilmMatch{}, page, total, keys, cursorOf)
+		return matches, info, nil
+	}
+
+	where, args := "TRUE", []any{tsQuery}
+	if page.Cursor != nil {
+		var err error
+		if where, args, err = keysetCondition(keys, "f", page.Cursor, args); err != nil {
+			return nil, nil, err
+		}
+	}
+	backward := page.Cursor != nil && page.Cursor.Backward
+	limit, offset := limitOffset(page)
+	args = append(args, limit, offset)
+
+	searchQuery := fmt.Sprintf(`
+		WITH query AS (
+			SELECT to_tsquery('simple', $1) AS q
+		), page AS (
+			SELECT f.id, f.title, f.description, f.release_date, f.rating, f.rank
+			FROM (
+				SELECT film.id, film.title, COALESCE(film.description, '') AS description, film.release_date, film.rating,
+					   ts_rank_cd(film.search_vector, query.q) AS rank
+				FROM film, query
+				WHERE film.search_vector @@ query.q
+			) AS f
+			WHERE %s
+			ORDER BY %s
+			LIMIT $%d OFFSET $%d
+		)
+		SELECT p.id, p.title, p.description, p.release_date, p.rating, p.rank,
+			   ts_headline('simple', p.title, query.q, 'StartSel=<b>, StopSel=</b>, HighlightAll=true'),
+			   ts_headline('simple', p.description, query.q, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5')
+		FROM page AS p, query
+		ORDER BY %s
+	`, where, orderBy(keys, "f", backward), len(args)-1, len(args), orderBy(keys, "p", backward))
+	rows, err := r.db.QueryContext(ctx, searchQuery, args...)
+	if err != nil {
+		r.logger.Error("Failed to search films", zap.Error(err))
+		return nil, nil, err
+	}
 	defer rows.Close()
 
-	var films []*domain.Film
+	var matches []*domain.FilmMatch
 	for rows.Next() {
 		var filmID int
 		var filmTitle, filmDescription string
 		var filmReleaseDate time.Time
 		var filmRating float64
+		var rank float32
+		var titleHeadline, descriptionHeadline string
 
-		if err = rows.Scan(&filmID, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating); err != nil {
+		if err = rows.Scan(&filmID, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating, &rank, &titleHeadline, &descriptionHeadline); err != nil {
 			r.logger.Error("Failed to scan row", zap.Error(err))
 			continue
 		}
 
 		film, _ := domain.NewFilm(filmID, filmTitle, filmDescription, filmReleaseDate, filmRating, nil)
-		films = append(films, film)
+		matches = append(matches, &domain.FilmMatch{
+			Film:                film,
+			Rank:                float64(rank),
+			TitleHeadline:       titleHeadline,
+			DescriptionHeadline: descriptionHeadline,
+		})
 	}
 	if err = rows.Err(); err != nil {
 		r.logger.Error("Error while iterating rows", zap.Error(err))
+		return nil, nil, err
+	}
+
+	matches, info := paginate(matches, page, total, keys, cursorOf)
+	return matches, info, nil
+}
+
+func (r *FilmRepository) FuzzySearchFilms(ctx context.Context, query string, threshold float64, page domain.PageRequest) ([]*domain.FilmMatch, *domain.PageInfo, error) {
+	keys := []sortKey{{name: "similarity", cast: "real", desc: true}, {name: "id", cast: "int", desc: true}}
+	cursorOf := func(match *domain.FilmMatch) *domain.Cursor {
+		return &domain.Cursor{Values: []string{strconv.FormatFloat(match.Rank, 'g', -1, 32)}, ID: match.Film.GetId()}
+	}
+	match := `(film.title % $1 OR EXISTS (
+			SELECT 1 FROM film_actor AS fa
+			JOIN actor AS a ON fa.actor_id = a.id
+			WHERE fa.film_id = film.id AND a.name % $1
+		) OR EXISTS (
+			SELECT 1 FROM film_crew AS fc
+			JOIN person AS p ON fc.person_id = p.id
+			WHERE fc.film_id = film.id AND p.name % $1
+		))`
+
+	var matches []*domain.FilmMatch
+	var total int
+	err := r.withSimilarityThreshold(ctx, threshold, func(tx *sqlx.Tx) error {
+		if err := tx.GetContext(ctx, &total, fmt.Sprintf(`SELECT count(*) FROM film WHERE %s`, match), query); err != nil {
+			r.logger.Error("Failed to count similar films", zap.Error(err))
+			return err
+		}
+
+		where, args := "TRUE", []any{query}
+		if page.Cursor != nil {
+			var err error
+			if where, args, err = keysetCondition(keys, "f", page.Cursor, args); err != nil {
+				return err
+			}
+		}
+		backward := page.Cursor != nil && page.Cursor.Backward
+		limit, offset := limitOffset(page)
+		args = append(args, limit, offset)
+
+		searchQuery := fmt.Sprintf(`
+			SELECT f.id, f.title, f.description, f.release_date, f.rating, f.similarity
+			FROM (
+				SELECT film.id, film.title, COALESCE(film.description, '') AS description, film.release_date, film.rating,
+					   GREATEST(similarity(film.title, $1), COALESCE((
+						   SELECT max(similarity(a.name, $1)) FROM actor AS a
+						   JOIN film_actor AS fa ON a.id = fa.actor_id
+						   WHERE fa.film_id = film.id
+					   ), 0), COALESCE((
+						   SELECT max(similarity(p.name, $1)) FROM person AS p
+						   JOIN film_crew AS fc ON p.id = fc.person_id
+						   WHERE fc.film_id = film.id
+					   ), 0)) AS similarity
+				FROM film
+				WHERE %s
+			) AS f
+			WHERE %s
+			ORDER BY %s
+			LIMIT $%d OFFSET $%d
+		`, match, where, orderBy(keys, "f", backward), len(args)-1, len(args))
+		rows, err := tx.QueryContext(ctx, searchQuery, args...)
+		if err != nil {
+			r.logger.Error("Failed to search similar films", zap.Error(err))
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var filmID int
+			var filmTitle, filmDescription string
+			var filmReleaseDate time.Time
+			var filmRating float64
+			var similarity float32
+
+			if err = rows.Scan(&filmID, &filmTitle, &filmDescription, &filmReleaseDate, &filmRating, &similarity); err != nil {
+				r.logger.Error("Failed to scan row", zap.Error(err))
+				continue
+			}
+
+			film, _ := domain.NewFilm(filmID, filmTitle, filmDescription, filmReleaseDate, filmRating, nil)
+			matches = append(matches, &domain.FilmMatch{Film: film, Rank: float64(similarity)})
+		}
+		if err = rows.Err(); err != nil {
+			r.logger.Error("Error while iterating rows", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matches, info := paginate(matches, page, total, keys, cursorOf)
+	return matches, info, nil
+}
+
+// SuggestSearchTerms returns film titles, actor and crew names similar to the query,
+// the most similar first.
+func (r *FilmRepository) SuggestSearchTerms(ctx context.Context, query string, threshold float64, limit int) ([]string, error) {
+	suggestQuery := `
+		SELECT term FROM (
+			SELECT title AS term, similarity(title, $1) AS score FROM film WHERE title % $1
+			UNION
+			SELECT name, similarity(name, $1) FROM actor WHERE name % $1
+			UNION
+			SELECT name, similarity(name, $1) FROM person WHERE name % $1
+		) AS t
+		ORDER BY score DESC, term
+		LIMIT $2
+	`
+	var terms []string
+	err := r.withSimilarityThreshold(ctx, threshold, func(tx *sqlx.Tx) error {
+		if err := tx.SelectContext(ctx, &terms, suggestQuery, query, limit); err != nil {
+			r.logger.Error("Failed to suggest search terms", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
+	return terms, nil
+}
+
+// withSimilarityThreshold runs fn in a read-only transaction where the pg_trgm
+// % operator matches strings at least threshold similar, so the trigram
+// indexes serve any threshold.
+func (r *FilmRepository) withSimilarityThreshold(ctx context.Context, threshold float64, fn func(tx *sqlx.Tx) error) error {
+	return withTx(ctx, r.db, r.logger, &sql.TxOptions{ReadOnly: true}, func(tx *sqlx.Tx) error {
+		setQuery := `SELECT set_config('pg_trgm.similarity_threshold', $1, true)`
+		if _, err := tx.ExecContext(ctx, setQuery, strconv.FormatFloat(threshold, 'f', -1, 64)); err != nil {
+			r.logger.Error("Failed to set similarity threshold", zap.Error(err))
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// prefixTSQuery turns free text into a tsquery matching films that contain
+// every word as a prefix, e.g. "star wa" becomes "star:* & wa:*". Everything
+// but letters and digits is dropped, so user input can not break the tsquery
+// syntax. An empty string means nothing to search for.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.Join(words, ":* & ") + ":*"
+}
+
+// filmFilterCondition renders the filter as a condition on the film table
+// under alias. Filter values are appended to args as placeholders.
+func filmFilterCondition(filter domain.FilmFilter, alias string, args []any) (string, []any) {
+	conditions := []string{"TRUE"}
+	add := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, alias, len(args)))
+	}
+
+	if filter.ReleasedFrom != nil {
+		add("%s.release_date >= $%d", *filter.ReleasedFrom)
+	}
+	if filter.ReleasedTo != nil {
+		add("%s.release_date <= $%d", *filter.ReleasedTo)
+	}
+	if filter.MinRating != nil {
+		add("%s.rating >= $%d", *filter.MinRating)
+	}
+	if filter.MaxRating != nil {
+		add("%s.rating <= $%d", *filter.MaxRating)
+	}
+	if filter.MinUserRating != nil {
+		add("%s.user_rating >= $%d", *filter.MinUserRating)
+	}
+	if filter.MaxUserRating != nil {
+		add("%s.user_rating <= $%d", *filter.MaxUserRating)
+	}
+	if filter.TitlePrefix != "" {
+		add(`%s.title ILIKE $%d ESCAPE '\'`, likeEscaper.Replace(filter.TitlePrefix)+"%")
+	}
+	for _, actorID := range filter.ActorIDs {
+		add("EXISTS (SELECT 1 FROM film_actor AS fa WHERE fa.film_id = %s.id AND fa.actor_id = $%d)", actorID)
+	}
+	if filter.WithoutActors {
+		conditions = append(conditions, fmt.Sprintf("NOT EXISTS (SELECT 1 FROM film_actor AS fa WHERE fa.film_id = %s.id)", alias))
+	}
+	if len(filter.GenreIDs) > 0 {
+		if filter.GenreMatch == domain.GenreMatchAll {
+			add("ARRAY(SELECT fg.genre_id FROM film_genre AS fg WHERE fg.film_id = %s.id) @> $%d::int[]", pq.Array(filter.GenreIDs))
+		} else {
+			add("EXISTS (SELECT 1 FROM film_genre AS fg WHERE fg.film_id = %s.id AND fg.genre_id = ANY($%d))", pq.Array(filter.GenreIDs))
+		}
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// likeEscaper escapes the LIKE wildcards, so user input is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+type filmSortColumn struct {
+	name  string
+	cast  string
+	value func(film *domain.Film) string
+}
 
-	return films, nil
+// filmSortColumns maps the sort fields to film columns. Only the columns
+// listed here ever get into ORDER BY.
+var filmSortColumns = map[domain.FilmSortField]filmSortColumn{
+	domain.FilmSortTitle: {
+		name:  "title",
+		cast:  "text",
+		value: func(film *domain.Film) string { return film.GetTitle() },
+	},
+	domain.FilmSortRating: {
+		name:  "rating",
+		cast:  "numeric",
+		value: func(film *domain.Film) string { return strconv.FormatFloat(film.GetRating(), 'f', -1, 64) },
+	},
+	domain.FilmSortUserRating: {
+		name:  "user_rating",
+		cast:  "numeric",
+		value: func(film *domain.Film) string { return strconv.FormatFloat(film.GetUserRating(), 'f', -1, 64) },
+	},
+	domain.FilmSortReleaseDate: {
+		name:  "release_date",
+		cast:  "date",
+		value: func(film *domain.Film) string { return film.GetReleaseDate().Format("2006-01-02") },
+	},
+}
+
+// filmSortKeys turns the ordering into sort keys ending with id, which makes
+// the order stable. The id follows the direction of the last field. Only the
+// known fields map to columns, anything else is refused.
+func filmSortKeys(sort []domain.FilmSort) ([]sortKey, []filmSortColumn, error) {
+	if len(sort) == 0 {
+		sort = domain.DefaultFilmSort
+	}
+
+	keys := make([]sortKey, 0, len(sort)+1)
+	columns := make([]filmSortColumn, 0, len(sort))
+	for _, s := range sort {
+		column, ok := filmSortColumns[s.Field]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: unknown field %q", domain.ErrInvalidSort, s.Field)
+		}
+		keys = append(keys, sortKey{name: column.name, cast: column.cast, desc: s.Desc})
+		columns = append(columns, column)
+	}
+	keys = append(keys, sortKey{name: "id", cast: "int", desc: sort[len(sort)-1].Desc})
+	return keys, columns, nil
+}
+
+// missingIDs returns the ids that are not among the found ones.
+func missingIDs(ids, found []int) []int {
+	present := make(map[int]bool, len(found))
+	for _, id := range found {
+		present[id] = true
+	}
+	var missing []int
+	for _, id := range ids {
+		if !present[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.Itoa(value)
+	}
+	return strings.Join(parts, ", ")
 }
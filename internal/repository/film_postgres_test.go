@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"github.com/Max425/film-library.git/internal/repository/store"
+	"github.com/lib/pq"
 	"github.com/zhashkevych/go-sqlxmock"
 	"testing"
 	"time"
@@ -66,10 +69,36 @@ func TestFilmRepository_FindFilmByID(t *testing.T) {
 		WithArgs(filmID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating"}).
 			AddRow(storeFilm.ID, storeFilm.Title, storeFilm.Description, storeFilm.ReleaseDate, storeFilm.Rating))
+	mock.ExpectQuery("SELECT (.+) FROM actor (.+) ORDER BY fa.billing NULLS LAST, a.id").
+		WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "birth_date", "film_id", "actor_id", "role_name", "billing", "role_type"}).
+			AddRow(2, "Lead Actor", "female", time.Unix(0, 0), filmID, 2, "Mal", 1, "regular").
+			AddRow(1, "Test Actor", "male", time.Unix(0, 0), filmID, 1, "", nil, "cameo"))
+	mock.ExpectQuery("SELECT (.+) FROM film_crew (.+) WHERE fc.film_id = \\$1 ORDER BY array_position").
+		WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "person_id", "job", "film_title", "person_name"}).
+			AddRow(filmID, 3, "director", "Test Film", "Test Director"))
+	mock.ExpectQuery("SELECT (.+) FROM genre AS g JOIN film_genre AS fg (.+) WHERE fg.film_id = \\$1").
+		WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(4, "Sci-Fi"))
 
 	result, err := r.FindFilmByID(context.Background(), filmID)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
+	assert.Len(t, result.GetActors(), 2)
+	assert.Equal(t, "Test Actor", result.GetActors()[1].GetName())
+
+	billing := 1
+	assert.Equal(t, []*domain.Casting{
+		{FilmID: filmID, ActorID: 2, RoleName: "Mal", Billing: &billing, RoleType: domain.RoleTypeRegular},
+		{FilmID: filmID, ActorID: 1, RoleType: domain.RoleTypeCameo},
+	}, result.GetCast())
+	assert.Equal(t, []*domain.Credit{
+		{FilmID: filmID, PersonID: 3, Job: domain.JobDirector, FilmTitle: "Test Film", PersonName: "Test Director"},
+	}, result.GetCrew())
+	assert.Len(t, result.GetGenres(), 1)
+	assert.Equal(t, "Sci-Fi", result.GetGenres()[0].GetName())
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestFilmRepository_UpdateFilm(t *testing.T) {
@@ -132,35 +161,187 @@ func TestFilmRepository_SearchFilms(t *testing.T) {
 	logger := zap.NewNop()
 	r := NewFilmRepository(db, logger)
 
-	query := `
-		SELECT DISTINCT f.id, f.title, f.description, f.release_date, f.rating
-		FROM film AS f
-		LEFT JOIN film_actor AS fa ON f.id = fa.film_id
-		LEFT JOIN actor AS a ON fa.actor_id = a.id
-		WHERE f.title ILIKE '%' || $1 || '%' OR a.name ILIKE '%' || $1 || '%'
-		ORDER BY f.rating DESC
-	`
+	mock.ExpectQuery("SELECT count(.+) FROM film WHERE search_vector @@ to_tsquery").
+		WithArgs("star:* & wa:*").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
-	rows := sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating"}).
-		AddRow(1, "Film 1", "Description 1", time.Unix(0, 0), 7.5).
-		AddRow(2, "Film 2", "Description 2", time.Unix(0, 0), 8.0)
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "rank", "title_headline", "description_headline"}).
+		AddRow(2, "Star Wars", "Description 2", time.Unix(0, 0), 8.0, float32(0.5), "<b>Star</b> <b>Wars</b>", "Description 2").
+		AddRow(1, "Film 1", "A star wanders", time.Unix(0, 0), 7.5, float32(0.1), "Film 1", "A <b>star</b> <b>wanders</b>")
 
-	mock.ExpectQuery(query).WillReturnRows(rows)
+	mock.ExpectQuery("WITH query AS (.+) ts_rank_cd(.+) ORDER BY f.rank DESC, f.id DESC (.+) ts_headline(.+) ORDER BY p.rank DESC, p.id DESC").
+		WithArgs("star:* & wa:*", 21, 0).
+		WillReturnRows(rows)
 
-	results, err := r.SearchFilms(context.Background(), "test")
+	results, info, err := r.SearchFilms(context.Background(), "Star, wa!", domain.PageRequest{Limit: 20})
 
 	assert.NoError(t, err)
-	assert.NotNil(t, results)
+	assert.Equal(t, 2, info.Total)
+	assert.Nil(t, info.NextCursor)
 	assert.Len(t, results, 2)
 
-	assert.Equal(t, 1, results[0].GetId())
-	assert.Equal(t, "Film 1", results[0].GetTitle())
-	assert.Equal(t, "Description 1", results[0].GetDescription())
+	assert.Equal(t, 2, results[0].Film.GetId())
+	assert.Equal(t, "Star Wars", results[0].Film.GetTitle())
+	assert.Equal(t, float64(float32(0.5)), results[0].Rank)
+	assert.Equal(t, "<b>Star</b> <b>Wars</b>", results[0].TitleHeadline)
+
+	assert.Equal(t, 1, results[1].Film.GetId())
+	assert.Equal(t, "A <b>star</b> <b>wanders</b>", results[1].DescriptionHeadline)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_SearchFilms_Cursor(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	mock.ExpectQuery("SELECT count(.+) FROM film").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "rank", "title_headline", "description_headline"}).
+		AddRow(1, "Film 1", "Description 1", time.Unix(0, 0), 7.5, float32(0.1), "<b>Film</b> 1", "Description 1").
+		AddRow(3, "Film 3", "Description 3", time.Unix(0, 0), 7.5, float32(0.1), "<b>Film</b> 3", "Description 3")
+
+	mock.ExpectQuery("WITH query AS (.+) WHERE \\(\\(f.rank < \\$2::real\\) OR \\(f.rank = \\$2::real AND f.id < \\$3::int\\)\\)").
+		WithArgs("film:*", "0.2", "5", 2, 0).
+		WillReturnRows(rows)
+
+	cursor := &domain.Cursor{Sort: "rank:desc,id:desc", Values: []string{"0.2"}, ID: 5}
+	results, info, err := r.SearchFilms(context.Background(), "film", domain.PageRequest{Limit: 1, Cursor: cursor})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, &domain.Cursor{Sort: "rank:desc,id:desc", Values: []string{"0.1"}, ID: 1}, info.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_SearchFilms_EmptyQuery(t *testing.T) {
+	db, _, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	_, _, err = r.SearchFilms(context.Background(), " & !:* ", domain.PageRequest{Limit: 20})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidQuery)
+}
+
+func TestFilmRepository_SearchFilms_NoMatches(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	mock.ExpectQuery("SELECT count(.+) FROM film WHERE search_vector @@ to_tsquery").
+		WithArgs("incepton:*").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	results, info, err := r.SearchFilms(context.Background(), "incepton", domain.PageRequest{Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, 0, info.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_FuzzySearchFilms(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT set_config\\('pg_trgm.similarity_threshold', \\$1, true\\)").
+		WithArgs("0.4").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT count(.+) FROM film WHERE \\(film.title % \\$1 OR EXISTS (.+) a.name % \\$1").
+		WithArgs("incepton").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "similarity"}).
+		AddRow(1, "Inception", "Description 1", time.Unix(0, 0), 8.8, float32(0.6)).
+		AddRow(2, "Interception", "Description 2", time.Unix(0, 0), 5.0, float32(0.45))
+
+	mock.ExpectQuery("SELECT (.+)GREATEST\\(similarity\\(film.title, \\$1\\)(.+) ORDER BY f.similarity DESC, f.id DESC LIMIT \\$2 OFFSET \\$3").
+		WithArgs("incepton", 2, 0).
+		WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	results, info, err := r.FuzzySearchFilms(context.Background(), "incepton", 0.4, domain.PageRequest{Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Inception", results[0].Film.GetTitle())
+	assert.Equal(t, float64(float32(0.6)), results[0].Rank)
+	assert.Equal(t, 2, info.Total)
+	assert.Equal(t, &domain.Cursor{Sort: "similarity:desc,id:desc", Values: []string{"0.6"}, ID: 1}, info.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_FuzzySearchFilms_InvalidCursor(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT set_config").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT count(.+) FROM film").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+
+	cursor := &domain.Cursor{Sort: "rank:desc,id:desc", Values: []string{"0.1"}, ID: 1}
+	_, _, err = r.FuzzySearchFilms(context.Background(), "incepton", 0.3, domain.PageRequest{Limit: 1, Cursor: cursor})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_SuggestSearchTerms(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
 
-	assert.Equal(t, 2, results[1].GetId())
-	assert.Equal(t, "Film 2", results[1].GetTitle())
-	assert.Equal(t, "Description 2", results[1].GetDescription())
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT set_config").
+		WithArgs("0.3").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT term FROM (.+) FROM film WHERE title % \\$1 UNION (.+) FROM actor WHERE name % \\$1 (.+) LIMIT \\$2").
+		WithArgs("lenardo", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"term"}).AddRow("Leonardo DiCaprio").AddRow("Leonard"))
+	mock.ExpectCommit()
 
+	terms, err := r.SuggestSearchTerms(context.Background(), "lenardo", 0.3, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Leonardo DiCaprio", "Leonard"}, terms)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -177,19 +358,593 @@ func TestFilmRepository_UpdateFilmActors(t *testing.T) {
 	filmID := 1
 	actorIDs := []int{1, 2, 3}
 
-	deleteQuery := "DELETE FROM film_actor"
-	mock.ExpectExec(deleteQuery).WillReturnResult(sqlmock.NewResult(0, 0))
-
-	insertQuery := "INSERT INTO film_actor"
-	for _, actorID := range actorIDs {
-		mock.ExpectExec(insertQuery).WithArgs(filmID, actorID).WillReturnResult(sqlmock.NewResult(0, 1))
-	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM film WHERE id = (.+) FOR UPDATE").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("SELECT id FROM actor WHERE id = ANY").WithArgs(pq.Array(actorIDs)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+	mock.ExpectExec("DELETE FROM film_actor WHERE film_id = (.+) AND NOT \\(actor_id = ANY").WithArgs(filmID, pq.Array(actorIDs)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO film_actor (.+) unnest(.+) ON CONFLICT DO NOTHING").WithArgs(filmID, pq.Array(actorIDs)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
 
 	// Ожидаем вызов метода FindFilmByID
 	mock.ExpectQuery("SELECT (.+) FROM film").WithArgs(filmID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating"}).
 			AddRow(filmID, "Test Film", "Test Description", time.Now(), 4.5))
+	mock.ExpectQuery("SELECT (.+) FROM actor").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "birth_date"}))
+	mock.ExpectQuery("SELECT (.+) FROM film_crew").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "person_id", "job"}))
+	mock.ExpectQuery("SELECT (.+) FROM genre").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
 
 	_, err = r.UpdateFilmActors(context.Background(), filmID, actorIDs)
 	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_UpdateFilmActors_UnknownActors(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	filmID := 1
+	actorIDs := []int{1, 7, 2, 9}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM film WHERE id = (.+) FOR UPDATE").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("SELECT id FROM actor WHERE id = ANY").WithArgs(pq.Array(actorIDs)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectRollback()
+
+	_, err = r.UpdateFilmActors(context.Background(), filmID, actorIDs)
+	assert.ErrorIs(t, err, domain.ErrUnknownActors)
+	assert.EqualError(t, err, "unknown actors: 7, 9")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_UpdateFilmActors_ClearCast(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	filmID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM film WHERE id = (.+) FOR UPDATE").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("SELECT id FROM actor WHERE id = ANY").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec("DELETE FROM film_actor").WithArgs(filmID, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT (.+) FROM film").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating"}).
+			AddRow(filmID, "Test Film", "Test Description", time.Now(), 4.5))
+	mock.ExpectQuery("SELECT (.+) FROM actor").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "gender", "birth_date"}))
+	mock.ExpectQuery("SELECT (.+) FROM film_crew").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "person_id", "job"}))
+	mock.ExpectQuery("SELECT (.+) FROM genre").WithArgs(filmID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+	_, err = r.UpdateFilmActors(context.Background(), filmID, []int{})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_UpdateFilmActors_FilmNotFound(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM film WHERE id = (.+) FOR UPDATE").WithArgs(5).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err = r.UpdateFilmActors(context.Background(), 5, []int{1})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_AddFilmActor(t *testing.T) {
+	billing := 1
+	tests := []struct {
+		name          string
+		casting       domain.Casting
+		filmExists    bool
+		actorExists   bool
+		expectedArgs  []driver.Value
+		expectedError string
+	}{
+		{
+			name:         "Ok",
+			casting:      domain.Casting{FilmID: 1, ActorID: 2},
+			filmExists:   true,
+			actorExists:  true,
+			expectedArgs: []driver.Value{1, 2, "", nil, "", false},
+		},
+		{
+			name:         "Ok with role",
+			casting:      domain.Casting{FilmID: 1, ActorID: 2, RoleName: "Cobb", Billing: &billing, RoleType: domain.RoleTypeRegular},
+			filmExists:   true,
+			actorExists:  true,
+			expectedArgs: []driver.Value{1, 2, "Cobb", 1, "regular", true},
+		},
+		{
+			name:          "Film not found",
+			casting:       domain.Casting{FilmID: 1, ActorID: 2},
+			actorExists:   true,
+			expectedArgs:  []driver.Value{1, 2, "", nil, "", false},
+			expectedError: "not found: film 1",
+		},
+		{
+			name:          "Actor not found",
+			casting:       domain.Casting{FilmID: 1, ActorID: 2},
+			filmExists:    true,
+			expectedArgs:  []driver.Value{1, 2, "", nil, "", false},
+			expectedError: "not found: actor 2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewFilmRepository(db, zap.NewNop())
+
+			mock.ExpectQuery("INSERT INTO film_actor (.+) ON CONFLICT \\(film_id, actor_id\\) DO UPDATE (.+) WHERE \\$6").
+				WithArgs(test.expectedArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"film_exists", "actor_exists"}).AddRow(test.filmExists, test.actorExists))
+
+			err = r.AddFilmActor(context.Background(), test.casting)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrNotFound)
+				assert.EqualError(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilmRepository_RemoveFilmActor(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewFilmRepository(db, zap.NewNop())
+
+	mock.ExpectExec("DELETE FROM film_actor WHERE film_id = (.+) AND actor_id = (.+)").
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = r.RemoveFilmActor(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_AddFilmCrew(t *testing.T) {
+	tests := []struct {
+		name          string
+		filmExists    bool
+		personExists  bool
+		expectedError string
+	}{
+		{name: "Ok", filmExists: true, personExists: true},
+		{name: "Film not found", personExists: true, expectedError: "not found: film 1"},
+		{name: "Person not found", filmExists: true, expectedError: "not found: person 2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewFilmRepository(db, zap.NewNop())
+
+			mock.ExpectQuery("INSERT INTO film_crew (.+) ON CONFLICT DO NOTHING").
+				WithArgs(1, 2, "director").
+				WillReturnRows(sqlmock.NewRows([]string{"film_exists", "person_exists"}).AddRow(test.filmExists, test.personExists))
+
+			err = r.AddFilmCrew(context.Background(), domain.Credit{FilmID: 1, PersonID: 2, Job: domain.JobDirector})
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrNotFound)
+				assert.EqualError(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilmRepository_RemoveFilmCrew(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewFilmRepository(db, zap.NewNop())
+
+	mock.ExpectExec("DELETE FROM film_crew WHERE film_id = (.+) AND person_id = (.+) AND \\(\\$3 = '' OR job = \\$3\\)").
+		WithArgs(1, 2, "").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = r.RemoveFilmCrew(context.Background(), domain.Credit{FilmID: 1, PersonID: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_GetAllFilms(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	mock.ExpectQuery("SELECT count(.+) FROM film").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "user_rating", "vote_count", "actor_id", "name", "gender", "birth_date", "role_name", "billing", "role_type"}).
+		AddRow(4, "Film 4", "Description 4", time.Unix(0, 0), 8.5, 7.5, 2, 2, "Actor 2", "female", time.Unix(0, 0), "Ariadne", 1, "regular").
+		AddRow(4, "Film 4", "Description 4", time.Unix(0, 0), 8.5, 7.5, 2, 1, "Actor 1", "male", time.Unix(0, 0), "", nil, "voice").
+		AddRow(3, "Film 3", "Description 3", time.Unix(0, 0), 8.0, 0.0, 0, nil, "", "", time.Time{}, "", nil, "")
+
+	mock.ExpectQuery("WITH page AS (.+) WHERE (.+) ORDER BY f.rating DESC, f.id DESC (.+) FROM page AS p (.+) ORDER BY p.rating DESC, p.id DESC, fa.billing NULLS LAST, a.id").
+		WithArgs("8.5", "5", 2, 0).
+		WillReturnRows(rows)
+	mock.ExpectQuery("SELECT fg.film_id, (.+) FROM film_genre AS fg JOIN genre AS g (.+) WHERE fg.film_id = ANY").
+		WithArgs(pq.Array([]int{4})).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "id", "name"}).AddRow(4, 1, "Drama"))
+
+	cursor := &domain.Cursor{Sort: "rating:desc,id:desc", Values: []string{"8.5"}, ID: 5}
+	results, info, err := r.GetAllFilms(context.Background(), []domain.FilmSort{{Field: domain.FilmSortRating, Desc: true}}, domain.FilmFilter{}, domain.PageRequest{Limit: 1, Cursor: cursor})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, 4, results[0].GetId())
+	assert.Equal(t, 7.5, results[0].GetUserRating())
+	assert.Equal(t, 2, results[0].GetVoteCount())
+	assert.Len(t, results[0].GetActors(), 2)
+	billing := 1
+	assert.Equal(t, []*domain.Casting{
+		{FilmID: 4, ActorID: 2, RoleName: "Ariadne", Billing: &billing, RoleType: domain.RoleTypeRegular},
+		{FilmID: 4, ActorID: 1, RoleType: domain.RoleTypeVoice},
+	}, results[0].GetCast())
+	assert.Len(t, results[0].GetGenres(), 1)
+	assert.Equal(t, "Drama", results[0].GetGenres()[0].GetName())
+	assert.Equal(t, 10, info.Total)
+	assert.Equal(t, &domain.Cursor{Sort: "rating:desc,id:desc", Values: []string{"8.5"}, ID: 4}, info.NextCursor)
+	assert.Equal(t, &domain.Cursor{Sort: "rating:desc,id:desc", Values: []string{"8.5"}, ID: 4, Backward: true}, info.PrevCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_GetAllFilms_InvalidCursor(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	mock.ExpectQuery("SELECT count(.+) FROM film").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+
+	cursor := &domain.Cursor{Sort: "title:asc,id:asc", Values: []string{"Film"}, ID: 5}
+	_, _, err = r.GetAllFilms(context.Background(), []domain.FilmSort{{Field: domain.FilmSortRating, Desc: true}}, domain.FilmFilter{}, domain.PageRequest{Limit: 1, Cursor: cursor})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestFilmRepository_GetAllFilms_Filter(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	from := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	minRating := 7.5
+	filter := domain.FilmFilter{
+		ReleasedFrom: &from,
+		MinRating:    &minRating,
+		ActorIDs:     []int{3, 4},
+		TitlePrefix:  "100%_",
+	}
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM film AS f WHERE TRUE AND f.release_date >= \$1 AND f.rating >= \$2 AND f.title ILIKE \$3 (.+) AND EXISTS (.+) fa.actor_id = \$4\) AND EXISTS (.+) fa.actor_id = \$5\)`).
+		WithArgs(from, minRating, `100\%\_%`, 3, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("WITH page AS (.+) WHERE TRUE AND f.release_date >= (.+) LIMIT \\$6 OFFSET \\$7").
+		WithArgs(from, minRating, `100\%\_%`, 3, 4, 21, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "user_rating", "vote_count", "actor_id", "name", "gender", "birth_date", "role_name", "billing", "role_type"}))
+
+	results, info, err := r.GetAllFilms(context.Background(), []domain.FilmSort{{Field: domain.FilmSortRating, Desc: true}}, filter, domain.PageRequest{Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, 0, info.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_GetAllFilms_WithoutActors(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	mock.ExpectQuery("SELECT count(.+) FROM film AS f WHERE TRUE AND NOT EXISTS (.+)").
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("WITH page AS (.+) WHERE TRUE AND NOT EXISTS (.+)").
+		WithArgs(21, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "user_rating", "vote_count", "actor_id", "name", "gender", "birth_date", "role_name", "billing", "role_type"}).
+			AddRow(1, "Film 1", "Description 1", time.Unix(0, 0), 5.0, 0.0, 0, nil, "", "", time.Time{}, "", nil, ""))
+	mock.ExpectQuery("FROM film_genre").
+		WithArgs(pq.Array([]int{1})).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "id", "name"}))
+
+	results, info, err := r.GetAllFilms(context.Background(), []domain.FilmSort{{Field: domain.FilmSortTitle}}, domain.FilmFilter{WithoutActors: true}, domain.PageRequest{Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Empty(t, results[0].GetActors())
+	assert.Equal(t, 1, info.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_GetAllFilms_MultiColumnSort(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	mock.ExpectQuery("SELECT count(.+) FROM film").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "user_rating", "vote_count", "actor_id", "name", "gender", "birth_date", "role_name", "billing", "role_type"}).
+		AddRow(2, "Film B", "Description 2", time.Unix(0, 0), 8.5, 0.0, 0, nil, "", "", time.Time{}, "", nil, "").
+		AddRow(3, "Film C", "Description 3", time.Unix(0, 0), 8.5, 0.0, 0, nil, "", "", time.Time{}, "", nil, "")
+
+	mock.ExpectQuery("WITH page AS (.+) WHERE TRUE AND \\(\\(f.rating < \\$1::numeric\\) OR \\(f.rating = \\$1::numeric AND f.title > \\$2::text\\) OR (.+) ORDER BY f.rating DESC, f.title ASC, f.id ASC (.+) ORDER BY p.rating DESC, p.title ASC, p.id ASC, fa.billing NULLS LAST, a.id").
+		WithArgs("8.5", "Film A", "1", 3, 0).
+		WillReturnRows(rows)
+	mock.ExpectQuery("FROM film_genre").
+		WithArgs(pq.Array([]int{2, 3})).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "id", "name"}))
+
+	sort := []domain.FilmSort{{Field: domain.FilmSortRating, Desc: true}, {Field: domain.FilmSortTitle}}
+	cursor := &domain.Cursor{Sort: "rating:desc,title:asc,id:asc", Values: []string{"8.5", "Film A"}, ID: 1}
+	results, info, err := r.GetAllFilms(context.Background(), sort, domain.FilmFilter{}, domain.PageRequest{Limit: 2, Cursor: cursor})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Nil(t, info.NextCursor)
+	assert.Equal(t, &domain.Cursor{Sort: "rating:desc,title:asc,id:asc", Values: []string{"8.5", "Film B"}, ID: 2, Backward: true}, info.PrevCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_GetAllFilms_UserRating(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	minUserRating := 7.0
+	filter := domain.FilmFilter{MinUserRating: &minUserRating}
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM film AS f WHERE TRUE AND f.user_rating >= \$1`).
+		WithArgs(minUserRating).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("WITH page AS (.+) WHERE TRUE AND f.user_rating >= \\$1 ORDER BY f.user_rating DESC, f.id DESC (.+) ORDER BY p.user_rating DESC, p.id DESC").
+		WithArgs(minUserRating, 21, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "user_rating", "vote_count", "actor_id", "name", "gender", "birth_date", "role_name", "billing", "role_type"}).
+			AddRow(2, "Film B", "Description 2", time.Unix(0, 0), 5.0, 8.25, 4, nil, "", "", time.Time{}, "", nil, ""))
+	mock.ExpectQuery("FROM film_genre").
+		WithArgs(pq.Array([]int{2})).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "id", "name"}))
+
+	sort := []domain.FilmSort{{Field: domain.FilmSortUserRating, Desc: true}}
+	results, info, err := r.GetAllFilms(context.Background(), sort, filter, domain.PageRequest{Limit: 20})
+
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, 8.25, results[0].GetUserRating())
+	}
+	assert.Equal(t, 1, info.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_GetAllFilms_InvalidSort(t *testing.T) {
+	db, _, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	logger := zap.NewNop()
+	r := NewFilmRepository(db, logger)
+
+	sort := []domain.FilmSort{{Field: "title; DROP TABLE film"}}
+	_, _, err = r.GetAllFilms(context.Background(), sort, domain.FilmFilter{}, domain.PageRequest{Limit: 20})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+}
+
+func TestFilmRepository_GetAllFilms_Genres(t *testing.T) {
+	tests := []struct {
+		name      string
+		match     domain.GenreMatch
+		condition string
+	}{
+		{name: "Any", condition: "EXISTS \\(SELECT 1 FROM film_genre AS fg WHERE fg.film_id = f.id AND fg.genre_id = ANY\\(\\$1\\)\\)"},
+		{name: "All", match: domain.GenreMatchAll, condition: "ARRAY\\(SELECT fg.genre_id FROM film_genre AS fg WHERE fg.film_id = f.id\\) @> \\$1::int\\[\\]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewFilmRepository(db, zap.NewNop())
+
+			filter := domain.FilmFilter{GenreIDs: []int{1, 2}, GenreMatch: test.match}
+			mock.ExpectQuery("SELECT count(.+) FROM film AS f WHERE TRUE AND " + test.condition).
+				WithArgs(pq.Array([]int{1, 2})).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			mock.ExpectQuery("WITH page AS (.+) WHERE TRUE AND "+test.condition).
+				WithArgs(pq.Array([]int{1, 2}), 21, 0).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "user_rating", "vote_count", "actor_id", "name", "gender", "birth_date", "role_name", "billing", "role_type"}))
+
+			results, _, err := r.GetAllFilms(context.Background(), []domain.FilmSort{{Field: domain.FilmSortRating, Desc: true}}, filter, domain.PageRequest{Limit: 20})
+			assert.NoError(t, err)
+			assert.Empty(t, results)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilmRepository_GetFilmGenreFacets(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewFilmRepository(db, zap.NewNop())
+
+	minRating := 8.0
+	mock.ExpectQuery("SELECT (.+) count\\(\\*\\) AS film_count FROM genre AS g (.+) WHERE TRUE AND f.rating >= \\$1 GROUP BY g.id ORDER BY film_count DESC").
+		WithArgs(minRating).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "film_count"}).
+			AddRow(1, "Drama", 5).
+			AddRow(2, "Thriller", 2))
+
+	counts, err := r.GetFilmGenreFacets(context.Background(), domain.FilmFilter{MinRating: &minRating})
+	assert.NoError(t, err)
+	assert.Len(t, counts, 2)
+	assert.Equal(t, "Drama", counts[0].Genre.GetName())
+	assert.Equal(t, 5, counts[0].Count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_UpdateFilmGenres_UnknownGenres(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewFilmRepository(db, zap.NewNop())
+
+	genreIDs := []int{1, 5}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM film WHERE id = (.+) FOR UPDATE").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT id FROM genre WHERE id = ANY").WithArgs(pq.Array(genreIDs)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err = r.UpdateFilmGenres(context.Background(), 1, genreIDs)
+	assert.ErrorIs(t, err, domain.ErrUnknownGenres)
+	assert.EqualError(t, err, "unknown genres: 5")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_AddFilmGenre(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewFilmRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("INSERT INTO film_genre (.+) ON CONFLICT DO NOTHING").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"film_exists", "genre_exists"}).AddRow(true, false))
+
+	err = r.AddFilmGenre(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.EqualError(t, err, "not found: genre 2")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFilmRepository_AddFilmUserStates(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewFilmRepository(db, zap.NewNop())
+
+	heat, _ := domain.NewFilm(1, "Heat", "", time.Now(), 8.3, nil)
+	alien, _ := domain.NewFilm(2, "Alien", "", time.Now(), 8.5, nil)
+
+	mock.ExpectQuery("FROM watchlist (.+) FROM watched (.+) FROM film_list_item (.+) WHERE f.id = ANY\\(\\$2\\)").
+		WithArgs(7, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "in_watchlist", "watch_count", "list_ids"}).
+			AddRow(1, true, 2, "{3,4}").
+			AddRow(2, false, 0, "{}"))
+
+	err = r.AddFilmUserStates(context.Background(), 7, []*domain.Film{heat, alien})
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.FilmUserState{InWatchlist: true, WatchCount: 2, ListIDs: []int{3, 4}}, heat.GetUserState())
+	assert.Equal(t, &domain.FilmUserState{ListIDs: []int{}}, alien.GetUserState())
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/repository/store"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+type GenreRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+func NewGenreRepository(db *sqlx.DB, logger *zap.Logger) *GenreRepository {
+	return &GenreRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *GenreRepository) CreateGenre(ctx context.Context, genre *domain.Genre) (*domain.Genre, error) {
+	storeGenre := store.GenreDomainToStore(genre)
+	query := `INSERT INTO genre (name) VALUES ($1) RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, storeGenre.Name).Scan(&storeGenre.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: genre %q", domain.ErrAlreadyExists, storeGenre.Name)
+		}
+		r.logger.Error("Failed to create genre", zap.Error(err))
+		return nil, err
+	}
+	return store.GenreStoreToDomain(storeGenre)
+}
+
+func (r *GenreRepository) FindGenreByID(ctx context.Context, id int) (*domain.Genre, error) {
+	storeGenre := &store.Genre{}
+	query := `SELECT id, name, created_at, updated_at FROM genre WHERE id = $1`
+	err := r.db.GetContext(ctx, storeGenre, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.Error("Failed to find genre by ID", zap.Error(err))
+		return nil, err
+	}
+	return store.GenreStoreToDomain(storeGenre)
+}
+
+func (r *GenreRepository) UpdateGenre(ctx context.Context, genre *domain.Genre) (*domain.Genre, error) {
+	storeGenre := store.GenreDomainToStore(genre)
+	query := `UPDATE genre SET name = $1 WHERE id = $2`
+	_, err := r.db.ExecContext(ctx, query, storeGenre.Name, storeGenre.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: genre %q", domain.ErrAlreadyExists, storeGenre.Name)
+		}
+		r.logger.Error("Failed to update genre", zap.Error(err))
+		return nil, err
+	}
+	return genre, nil
+}
+
+func (r *GenreRepository) DeleteGenre(ctx context.Context, id int) error {
+	query := `DELETE FROM genre WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error("Failed to delete genre", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetAllGenres lists every genre ordered by name. There are few genres, so
+// the list is not paginated.
+func (r *GenreRepository) GetAllGenres(ctx context.Context) ([]*domain.Genre, error) {
+	var storeGenres []*store.Genre
+	query := `SELECT id, name, created_at, updated_at FROM genre ORDER BY name, id`
+	if err := r.db.SelectContext(ctx, &storeGenres, query); err != nil {
+		r.logger.Error("Failed to get all genres", zap.Error(err))
+		return nil, err
+	}
+
+	genres := make([]*domain.Genre, 0, len(storeGenres))
+	for _, storeGenre := range storeGenres {
+		genre, err := store.GenreStoreToDomain(storeGenre)
+		if err != nil {
+			r.logger.Error("Failed to convert genre", zap.Error(err))
+			continue
+		}
+		genres = append(genres, genre)
+	}
+	return genres, nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/go-sqlxmock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestGenreRepository_CreateGenre(t *testing.T) {
+	tests := []struct {
+		name          string
+		queryError    error
+		expectedError string
+	}{
+		{name: "Ok"},
+		{name: "Duplicate name", queryError: &pq.Error{Code: uniqueViolation}, expectedError: `already exists: genre "Drama"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewGenreRepository(db, zap.NewNop())
+
+			genre, _ := domain.NewGenre(0, "Drama")
+
+			expectation := mock.ExpectQuery("INSERT INTO genre").WithArgs("Drama")
+			if test.queryError != nil {
+				expectation.WillReturnError(test.queryError)
+			} else {
+				expectation.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			result, err := r.CreateGenre(context.Background(), genre)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, result.GetId())
+			} else {
+				assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+				assert.EqualError(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGenreRepository_FindGenreByID(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewGenreRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("SELECT (.+) FROM genre WHERE id = (.+)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+			AddRow(1, "Drama", time.Unix(0, 0), time.Unix(0, 0)))
+	mock.ExpectQuery("SELECT (.+) FROM genre WHERE id = (.+)").
+		WithArgs(2).
+		WillReturnError(sql.ErrNoRows)
+
+	result, err := r.FindGenreByID(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Drama", result.GetName())
+
+	_, err = r.FindGenreByID(context.Background(), 2)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenreRepository_UpdateGenre(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewGenreRepository(db, zap.NewNop())
+
+	genre, _ := domain.NewGenre(1, "Noir")
+
+	mock.ExpectExec("UPDATE genre").
+		WithArgs("Noir", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	result, err := r.UpdateGenre(context.Background(), genre)
+	assert.NoError(t, err)
+	assert.Equal(t, genre, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenreRepository_DeleteGenre(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewGenreRepository(db, zap.NewNop())
+
+	mock.ExpectExec("DELETE FROM genre").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = r.DeleteGenre(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGenreRepository_GetAllGenres(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewGenreRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("SELECT (.+) FROM genre ORDER BY name, id").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+			AddRow(2, "Comedy", time.Unix(0, 0), time.Unix(0, 0)).
+			AddRow(1, "Drama", time.Unix(0, 0), time.Unix(0, 0)))
+
+	results, err := r.GetAllGenres(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "Comedy", results[0].GetName())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
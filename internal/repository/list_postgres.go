@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/repository/store"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// listFilmColumns are the film columns read for the films kept in user lists.
+const listFilmColumns = `f.id, f.title, COALESCE(f.description, '') AS description, f.release_date, f.rating, f.user_rating, f.vote_count`
+
+type ListRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+func NewListRepository(db *sqlx.DB, logger *zap.Logger) *ListRepository {
+	return &ListRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// AddToWatchlist adds the film to the watchlist of the user, adding a film
+// that is already there changes nothing.
+func (r *ListRepository) AddToWatchlist(ctx context.Context, userID, filmID int) error {
+	query := `
+		WITH film_row AS (
+			SELECT id FROM film WHERE id = $2
+		), inserted AS (
+			INSERT INTO watchlist (user_id, film_id)
+			SELECT $1, film_row.id FROM film_row
+			ON CONFLICT DO NOTHING
+		)
+		SELECT EXISTS (SELECT 1 FROM film_row)
+	`
+	var filmExists bool
+	if err := r.db.QueryRowContext(ctx, query, userID, filmID).Scan(&filmExists); err != nil {
+		r.logger.Error("Failed to add film to watchlist", zap.Error(err))
+		return err
+	}
+	if !filmExists {
+		return fmt.Errorf("%w: film %d", domain.ErrNotFound, filmID)
+	}
+	return nil
+}
+
+// RemoveFromWatchlist removes the film from the watchlist of the user,
+// removing a film that is not there changes nothing.
+func (r *ListRepository) RemoveFromWatchlist(ctx context.Context, userID, filmID int) error {
+	query := `DELETE FROM watchlist WHERE user_id = $1 AND film_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, userID, filmID); err != nil {
+		r.logger.Error("Failed to remove film from watchlist", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetWatchlist returns the films in the watchlist of the user, the latest added first.
+func (r *ListRepository) GetWatchlist(ctx context.Context, userID int) ([]*domain.Film, error) {
+	query := `
+		SELECT ` + listFilmColumns + `
+		FROM watchlist AS w
+		JOIN film AS f ON w.film_id = f.id
+		WHERE w.user_id = $1
+		ORDER BY w.added_at DESC, f.id DESC
+	`
+	return r.selectFilms(ctx, query, userID)
+}
+
+// AddWatched logs that the user watched the film.
+func (r *ListRepository) AddWatched(ctx context.Context, entry domain.WatchEntry) (*domain.WatchEntry, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO watched (user_id, film_id, watched_at)
+			SELECT $1, id, $3 FROM film WHERE id = $2
+			RETURNING id, film_id
+		)
+		SELECT inserted.id, film.title FROM inserted JOIN film ON inserted.film_id = film.id
+	`
+	err := r.db.QueryRowContext(ctx, query, entry.UserID, entry.FilmID, entry.WatchedAt).Scan(&entry.ID, &entry.FilmTitle)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: film %d", domain.ErrNotFound, entry.FilmID)
+		}
+		r.logger.Error("Failed to add watched film", zap.Error(err))
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// DeleteWatched removes an entry of the watched log of the user.
+func (r *ListRepository) DeleteWatched(ctx context.Context, userID, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM watched WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		r.logger.Error("Failed to delete watched film", zap.Error(err))
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetWatched returns the watched log of the user, the latest watched first.
+func (r *ListRepository) GetWatched(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.WatchEntry, *domain.PageInfo, error) {
+	keys := []sortKey{{name: "watched_at", cast: "date", desc: true}, {name: "id", cast: "int", desc: true}}
+	cursorOf := func(entry *domain.WatchEntry) *domain.Cursor {
+		return &domain.Cursor{Values: []string{entry.WatchedAt.Format("2006-01-02")}, ID: entry.ID}
+	}
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT count(*) FROM watched WHERE user_id = $1`, userID); err != nil {
+		r.logger.Error("Failed to count watched films", zap.Error(err))
+		return nil, nil, err
+	}
+
+	where, args := "w.user_id = $1", []any{userID}
+	if page.Cursor != nil {
+		keyset, keysetArgs, err := keysetCondition(keys, "w", page.Cursor, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		where, args = where+" AND "+keyset, keysetArgs
+	}
+	backward := page.Cursor != nil && page.Cursor.Backward
+	limit, offset := limitOffset(page)
+	args = append(args, limit, offset)
+
+	query := fmt.Sprintf(`
+		SELECT w.id, w.user_id, w.film_id, f.title AS film_title, w.watched_at
+		FROM watched AS w
+		JOIN film AS f ON w.film_id = f.id
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, where, orderBy(keys, "w", backward), len(args)-1, len(args))
+	var storeEntries []*store.WatchEntry
+	if err := r.db.SelectContext(ctx, &storeEntries, query, args...); err != nil {
+		r.logger.Error("Failed to get watched films", zap.Error(err))
+		return nil, nil, err
+	}
+
+	entries := make([]*domain.WatchEntry, len(storeEntries))
+	for i, storeEntry := range storeEntries {
+		entries[i] = store.WatchEntryStoreToDomain(storeEntry)
+	}
+
+	entries, info := paginate(entries, page, total, keys, cursorOf)
+	return entries, info, nil
+}
+
+func (r *ListRepository) CreateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error) {
+	storeList := store.FilmListDomainToStore(list)
+	query := `INSERT INTO film_list (user_id, name) VALUES ($1, $2) RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, storeList.UserID, storeList.Name).Scan(&storeList.ID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: list %q", domain.ErrAlreadyExists, storeList.Name)
+		}
+		r.logger.Error("Failed to create film list", zap.Error(err))
+		return nil, err
+	}
+	return store.FilmListStoreToDomain(storeList)
+}
+
+// FindFilmListByID returns the list of the user with its films. Lists of
+// other users are not found.
+func (r *ListRepository) FindFilmListByID(ctx context.Context, userID, id int) (*domain.FilmList, error) {
+	query := `SELECT id, user_id, name, share_token, created_at, updated_at FROM film_list WHERE id = $1 AND user_id = $2`
+	return r.findFilmList(ctx, query, id, userID)
+}
+
+// FindSharedFilmList returns the list shared by the token with its films.
+func (r *ListRepository) FindSharedFilmList(ctx context.Context, token string) (*domain.FilmList, error) {
+	query := `SELECT id, user_id, name, share_token, created_at, updated_at FROM film_list WHERE share_token = $1`
+	return r.findFilmList(ctx, query, token)
+}
+
+func (r *ListRepository) findFilmList(ctx context.Context, query string, args ...any) (*domain.FilmList, error) {
+	storeList := &store.FilmList{}
+	if err := r.db.GetContext(ctx, storeList, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.Error("Failed to find film list", zap.Error(err))
+		return nil, err
+	}
+	list, err := store.FilmListStoreToDomain(storeList)
+	if err != nil {
+		return nil, err
+	}
+
+	filmsQuery := `
+		SELECT ` + listFilmColumns + `
+		FROM film_list_item AS i
+		JOIN film AS f ON i.film_id = f.id
+		WHERE i.list_id = $1
+		ORDER BY i.added_at, f.id
+	`
+	films, err := r.selectFilms(ctx, filmsQuery, storeList.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, film := range films {
+		list.AddFilm(film)
+	}
+	return list, nil
+}
+
+// UpdateFilmList renames the list of the user.
+func (r *ListRepository) UpdateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error) {
+	storeList := store.FilmListDomainToStore(list)
+	query := `UPDATE film_list SET name = $1, updated_at = timezone('europe/moscow'::text, now()) WHERE id = $2 AND user_id = $3`
+	result, err := r.db.ExecContext(ctx, query, storeList.Name, storeList.ID, storeList.UserID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: list %q", domain.ErrAlreadyExists, storeList.Name)
+		}
+		r.logger.Error("Failed to update film list", zap.Error(err))
+		return nil, err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return r.FindFilmListByID(ctx, storeList.UserID, storeList.ID)
+}
+
+func (r *ListRepository) DeleteFilmList(ctx context.Context, userID, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM film_list WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		r.logger.Error("Failed to delete film list", zap.Error(err))
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetFilmLists returns the lists of the user ordered by name, without their films.
+func (r *ListRepository) GetFilmLists(ctx context.Context, userID int) ([]*domain.FilmList, error) {
+	query := `SELECT id, user_id, name, share_token, created_at, updated_at FROM film_list WHERE user_id = $1 ORDER BY name, id`
+	var storeLists []*store.FilmList
+	if err := r.db.SelectContext(ctx, &storeLists, query, userID); err != nil {
+		r.logger.Error("Failed to get film lists", zap.Error(err))
+		return nil, err
+	}
+
+	lists := make([]*domain.FilmList, 0, len(storeLists))
+	for _, storeList := range storeLists {
+		list, err := store.FilmListStoreToDomain(storeList)
+		if err != nil {
+			r.logger.Error("Failed to convert film list", zap.Error(err))
+			continue
+		}
+		lists = append(lists, list)
+	}
+	return lists, nil
+}
+
+// AddFilmListItem adds the film to the list of the user, adding a film that
+// is already there changes nothing.
+func (r *ListRepository) AddFilmListItem(ctx context.Context, userID, listID, filmID int) error {
+	query := `
+		WITH list_row AS (
+			SELECT id FROM film_list WHERE id = $1 AND user_id = $2
+		), film_row AS (
+			SELECT id FROM film WHERE id = $3
+		), inserted AS (
+			INSERT INTO film_list_item (list_id, film_id)
+			SELECT list_row.id, film_row.id FROM list_row, film_row
+			ON CONFLICT DO NOTHING
+		)
+		SELECT EXISTS (SELECT 1 FROM list_row), EXISTS (SELECT 1 FROM film_row)
+	`
+	var listExists, filmExists bool
+	if err := r.db.QueryRowContext(ctx, query, listID, userID, filmID).Scan(&listExists, &filmExists); err != nil {
+		r.logger.Error("Failed to add film to list", zap.Error(err))
+		return err
+	}
+	if !listExists {
+		return fmt.Errorf("%w: list %d", domain.ErrNotFound, listID)
+	}
+	if !filmExists {
+		return fmt.Errorf("%w: film %d", domain.ErrNotFound, filmID)
+	}
+	return nil
+}
+
+// RemoveFilmListItem removes the film from the list of the user, removing a
+// film that is not there changes nothing.
+func (r *ListRepository) RemoveFilmListItem(ctx context.Context, userID, listID, filmID int) error {
+	query := `
+		WITH list_row AS (
+			SELECT id FROM film_list WHERE id = $1 AND user_id = $2
+		), deleted AS (
+			DELETE FROM film_list_item WHERE list_id IN (SELECT id FROM list_row) AND film_id = $3
+		)
+		SELECT EXISTS (SELECT 1 FROM list_row)
+	`
+	var listExists bool
+	if err := r.db.QueryRowContext(ctx, query, listID, userID, filmID).Scan(&listExists); err != nil {
+		r.logger.Error("Failed to remove film from list", zap.Error(err))
+		return err
+	}
+	if !listExists {
+		return fmt.Errorf("%w: list %d", domain.ErrNotFound, listID)
+	}
+	return nil
+}
+
+// SetFilmListShareToken shares the list of the user by the token, an empty
+// token stops sharing it.
+func (r *ListRepository) SetFilmListShareToken(ctx context.Context, userID, id int, token string) error {
+	query := `UPDATE film_list SET share_token = NULLIF($1, '')::uuid WHERE id = $2 AND user_id = $3`
+	result, err := r.db.ExecContext(ctx, query, token, id, userID)
+	if err != nil {
+		r.logger.Error("Failed to set film list share token", zap.Error(err))
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ListRepository) selectFilms(ctx context.Context, query string, args ...any) ([]*domain.Film, error) {
+	var storeFilms []*store.Film
+	if err := r.db.SelectContext(ctx, &storeFilms, query, args...); err != nil {
+		r.logger.Error("Failed to get listed films", zap.Error(err))
+		return nil, err
+	}
+
+	films := make([]*domain.Film, 0, len(storeFilms))
+	for _, storeFilm := range storeFilms {
+		film, err := store.FilmStoreToDomain(storeFilm)
+		if err != nil {
+			r.logger.Error("Failed to convert film", zap.Error(err))
+			continue
+		}
+		films = append(films, film)
+	}
+	return films, nil
+}
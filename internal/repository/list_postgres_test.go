@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/go-sqlxmock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+var listFilmRowColumns = []string{"id", "title", "description", "release_date", "rating", "user_rating", "vote_count"}
+
+func TestListRepository_AddToWatchlist(t *testing.T) {
+	tests := []struct {
+		name          string
+		filmExists    bool
+		expectedError string
+	}{
+		{name: "Ok", filmExists: true},
+		{name: "Film not found", expectedError: "not found: film 5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewListRepository(db, zap.NewNop())
+
+			mock.ExpectQuery("INSERT INTO watchlist (.+) ON CONFLICT DO NOTHING").
+				WithArgs(7, 5).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(test.filmExists))
+
+			err = r.AddToWatchlist(context.Background(), 7, 5)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListRepository_GetWatchlist(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewListRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("FROM watchlist AS w JOIN film AS f ON w.film_id = f.id WHERE w.user_id = \\$1 ORDER BY w.added_at DESC").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(listFilmRowColumns).
+			AddRow(2, "Heat", "", time.Now(), 8.3, 0, 0).
+			AddRow(1, "Alien", "", time.Now(), 8.5, 9.1, 3))
+
+	films, err := r.GetWatchlist(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Len(t, films, 2)
+	assert.Equal(t, "Heat", films[0].GetTitle())
+	assert.Equal(t, 3, films[1].GetVoteCount())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListRepository_AddWatched(t *testing.T) {
+	watchedAt := time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		queryError    error
+		expectedError string
+	}{
+		{name: "Ok"},
+		{name: "Film not found", queryError: sql.ErrNoRows, expectedError: "not found: film 5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewListRepository(db, zap.NewNop())
+
+			query := mock.ExpectQuery("INSERT INTO watched (.+) RETURNING id, film_id").WithArgs(7, 5, watchedAt)
+			if test.queryError != nil {
+				query.WillReturnError(test.queryError)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(11, "Heat"))
+			}
+
+			entry, err := r.AddWatched(context.Background(), domain.WatchEntry{UserID: 7, FilmID: 5, WatchedAt: watchedAt})
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, 11, entry.ID)
+				assert.Equal(t, "Heat", entry.FilmTitle)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListRepository_DeleteWatched_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewListRepository(db, zap.NewNop())
+
+	mock.ExpectExec("DELETE FROM watched WHERE id = \\$1 AND user_id = \\$2").
+		WithArgs(11, 7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = r.DeleteWatched(context.Background(), 7, 11)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListRepository_CreateFilmList(t *testing.T) {
+	tests := []struct {
+		name          string
+		queryError    error
+		expectedError string
+	}{
+		{name: "Ok"},
+		{name: "Name taken", queryError: &pq.Error{Code: uniqueViolation}, expectedError: `already exists: list "Noir"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewListRepository(db, zap.NewNop())
+
+			list, _ := domain.NewFilmList(0, 7, "Noir")
+
+			query := mock.ExpectQuery("INSERT INTO film_list \\(user_id, name\\) VALUES (.+) RETURNING id").WithArgs(7, "Noir")
+			if test.queryError != nil {
+				query.WillReturnError(test.queryError)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+			}
+
+			result, err := r.CreateFilmList(context.Background(), list)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, 4, result.GetId())
+				assert.Equal(t, "Noir", result.GetName())
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListRepository_FindFilmListByID(t *testing.T) {
+	tests := []struct {
+		name          string
+		listExists    bool
+		expectedError error
+	}{
+		{name: "Ok", listExists: true},
+		{name: "List of another user", expectedError: domain.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewListRepository(db, zap.NewNop())
+
+			query := mock.ExpectQuery("FROM film_list WHERE id = \\$1 AND user_id = \\$2").WithArgs(4, 7)
+			if !test.listExists {
+				query.WillReturnError(sql.ErrNoRows)
+			} else {
+				query.WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "share_token", "created_at", "updated_at"}).
+					AddRow(4, 7, "Noir", "0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7", time.Now(), time.Now()))
+				mock.ExpectQuery("FROM film_list_item AS i JOIN film AS f ON i.film_id = f.id WHERE i.list_id = \\$1").
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows(listFilmRowColumns).AddRow(2, "Heat", "", time.Now(), 8.3, 0, 0))
+			}
+
+			list, err := r.FindFilmListByID(context.Background(), 7, 4)
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, "0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7", list.GetShareToken())
+				assert.Len(t, list.GetFilms(), 1)
+			} else {
+				assert.ErrorIs(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListRepository_AddFilmListItem(t *testing.T) {
+	tests := []struct {
+		name          string
+		listExists    bool
+		filmExists    bool
+		expectedError string
+	}{
+		{name: "Ok", listExists: true, filmExists: true},
+		{name: "List not found", filmExists: true, expectedError: "not found: list 4"},
+		{name: "Film not found", listExists: true, expectedError: "not found: film 5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewListRepository(db, zap.NewNop())
+
+			mock.ExpectQuery("INSERT INTO film_list_item (.+) ON CONFLICT DO NOTHING").
+				WithArgs(4, 7, 5).
+				WillReturnRows(sqlmock.NewRows([]string{"list_exists", "film_exists"}).AddRow(test.listExists, test.filmExists))
+
+			err = r.AddFilmListItem(context.Background(), 7, 4, 5)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListRepository_SetFilmListShareToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		affected      int64
+		expectedError error
+	}{
+		{name: "Share", token: "0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7", affected: 1},
+		{name: "Unshare", affected: 1},
+		{name: "List not found", affected: 0, expectedError: domain.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewListRepository(db, zap.NewNop())
+
+			mock.ExpectExec("UPDATE film_list SET share_token = NULLIF\\(\\$1, ''\\)::uuid WHERE id = \\$2 AND user_id = \\$3").
+				WithArgs(test.token, 4, 7).
+				WillReturnResult(sqlmock.NewResult(0, test.affected))
+
+			err = r.SetFilmListShareToken(context.Background(), 7, 4, test.token)
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
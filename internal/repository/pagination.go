@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Max425/film-library.git/internal/domain"
+)
+
+// sortKey is a column taking part in ordering and keyset pagination.
+type sortKey struct {
+	name string
+	// cast is the SQL type cursor values are converted to before comparison.
+	cast string
+	desc bool
+}
+
+// sortName identifies an ordering, cursors are only valid for the ordering they were issued for.
+func sortName(keys []sortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		direction := "asc"
+		if key.desc {
+			direction = "desc"
+		}
+		parts[i] = key.name + ":" + direction
+	}
+	return strings.Join(parts, ",")
+}
+
+// orderBy renders the ORDER BY list. Backward pages are read in reverse order.
+func orderBy(keys []sortKey, alias string, backward bool) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		direction := "ASC"
+		if key.desc != backward {
+			direction = "DESC"
+		}
+		parts[i] = fmt.Sprintf("%s.%s %s", alias, key.name, direction)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// keysetCondition renders the condition selecting rows that follow the cursor
+// in the read order, e.g. (a > $1) OR (a = $1 AND id > $2). The cursor values
+// are appended to args as placeholders, so no value is ever put into the query text.
+func keysetCondition(keys []sortKey, alias string, cursor *domain.Cursor, args []any) (string, []any, error) {
+	if cursor.Sort != sortName(keys) || len(cursor.Values) != len(keys)-1 {
+		return "", nil, domain.ErrInvalidCursor
+	}
+
+	placeholders := make([]string, len(keys))
+	for i := range keys {
+		if i < len(cursor.Values) {
+			args = append(args, cursor.Values[i])
+		} else {
+			args = append(args, strconv.Itoa(cursor.ID))
+		}
+		placeholders[i] = fmt.Sprintf("$%d::%s", len(args), keys[i].cast)
+	}
+
+	conditions := make([]string, len(keys))
+	for i, key := range keys {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, fmt.Sprintf("%s.%s = %s", alias, keys[j].name, placeholders[j]))
+		}
+		operator := ">"
+		if key.desc != cursor.Backward {
+			operator = "<"
+		}
+		parts = append(parts, fmt.Sprintf("%s.%s %s %s", alias, key.name, operator, placeholders[i]))
+		conditions[i] = "(" + strings.Join(parts, " AND ") + ")"
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")", args, nil
+}
+
+// limitOffset returns the LIMIT and OFFSET arguments of a page query. One extra
+// row is read to find out whether more data follows, a zero limit reads everything.
+func limitOffset(page domain.PageRequest) (any, int) {
+	var limit any
+	if page.Limit > 0 {
+		limit = page.Limit + 1
+	}
+	if page.Cursor != nil {
+		return limit, 0
+	}
+	return limit, page.Offset
+}
+
+// paginate drops the look-ahead row, restores the order of backward pages and
+// describes the window with cursors pointing at its first and last rows.
+func paginate[T any](items []T, page domain.PageRequest, total int, keys []sortKey, cursorOf func(T) *domain.Cursor) ([]T, *domain.PageInfo) {
+	backward := page.Cursor != nil && page.Cursor.Backward
+	hasMore := page.Limit > 0 && len(items) > page.Limit
+	if hasMore {
+		items = items[:page.Limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	info := &domain.PageInfo{Total: total, Limit: page.Limit}
+	if page.Cursor == nil {
+		info.Offset = page.Offset
+	}
+	if len(items) == 0 {
+		return items, info
+	}
+
+	hasNext, hasPrev := hasMore, page.Cursor != nil || page.Offset > 0
+	if backward {
+		hasNext, hasPrev = true, hasMore
+	}
+	if hasNext {
+		info.NextCursor = cursorOf(items[len(items)-1])
+		info.NextCursor.Sort = sortName(keys)
+	}
+	if hasPrev {
+		info.PrevCursor = cursorOf(items[0])
+		info.PrevCursor.Sort = sortName(keys)
+		info.PrevCursor.Backward = true
+	}
+	return items, info
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysetCondition(t *testing.T) {
+	keys := []sortKey{{name: "title", cast: "text"}, {name: "rating", cast: "numeric", desc: true}, {name: "id", cast: "int"}}
+
+	tests := []struct {
+		name         string
+		cursor       *domain.Cursor
+		expectedSQL  string
+		expectedArgs []any
+		expectedErr  error
+	}{
+		{
+			name:         "Forward",
+			cursor:       &domain.Cursor{Sort: "title:asc,rating:desc,id:asc", Values: []string{"Alien", "8.5"}, ID: 3},
+			expectedSQL:  "((f.title > $2::text) OR (f.title = $2::text AND f.rating < $3::numeric) OR (f.title = $2::text AND f.rating = $3::numeric AND f.id > $4::int))",
+			expectedArgs: []any{"fragment", "Alien", "8.5", "3"},
+		},
+		{
+			name:         "Backward",
+			cursor:       &domain.Cursor{Sort: "title:asc,rating:desc,id:asc", Values: []string{"Alien", "8.5"}, ID: 3, Backward: true},
+			expectedSQL:  "((f.title < $2::text) OR (f.title = $2::text AND f.rating > $3::numeric) OR (f.title = $2::text AND f.rating = $3::numeric AND f.id < $4::int))",
+			expectedArgs: []any{"fragment", "Alien", "8.5", "3"},
+		},
+		{
+			name:        "Other ordering",
+			cursor:      &domain.Cursor{Sort: "rating:desc,id:desc", Values: []string{"8.5"}, ID: 3},
+			expectedErr: domain.ErrInvalidCursor,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sql, args, err := keysetCondition(keys, "f", test.cursor, []any{"fragment"})
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSQL, sql)
+			assert.Equal(t, test.expectedArgs, args)
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	keys := []sortKey{{name: "id", cast: "int"}}
+	cursorOf := func(id int) *domain.Cursor { return &domain.Cursor{ID: id} }
+
+	items, info := paginate([]int{1, 2, 3}, domain.PageRequest{Limit: 2}, 5, keys, cursorOf)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, &domain.PageInfo{Total: 5, Limit: 2, NextCursor: &domain.Cursor{Sort: "id:asc", ID: 2}}, info)
+
+	backward := &domain.Cursor{Sort: "id:asc", ID: 4, Backward: true}
+	items, info = paginate([]int{3, 2, 1}, domain.PageRequest{Limit: 2, Cursor: backward}, 5, keys, cursorOf)
+	assert.Equal(t, []int{2, 3}, items)
+	assert.Equal(t, &domain.Cursor{Sort: "id:asc", ID: 3}, info.NextCursor)
+	assert.Equal(t, &domain.Cursor{Sort: "id:asc", ID: 2, Backward: true}, info.PrevCursor)
+
+	items, info = paginate([]int{5}, domain.PageRequest{Limit: 2, Offset: 4}, 5, keys, cursorOf)
+	assert.Equal(t, []int{5}, items)
+	assert.Nil(t, info.NextCursor)
+	assert.Equal(t, &domain.Cursor{Sort: "id:asc", ID: 5, Backward: true}, info.PrevCursor)
+	assert.Equal(t, 4, info.Offset)
+}
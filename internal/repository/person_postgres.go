@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/repository/store"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"strings"
+)
+
+type PersonRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+func NewPersonRepository(db *sqlx.DB, logger *zap.Logger) *PersonRepository {
+	return &PersonRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PersonRepository) CreatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error) {
+	storePerson := store.PersonDomainToStore(person)
+	query := `INSERT INTO person (name) VALUES ($1) RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, storePerson.Name).Scan(&storePerson.ID)
+	if err != nil {
+		r.logger.Error("Failed to create person", zap.Error(err))
+		return nil, err
+	}
+	return store.PersonStoreToDomain(storePerson)
+}
+
+func (r *PersonRepository) FindPersonByID(ctx context.Context, id int) (*domain.Person, error) {
+	storePerson := &store.Person{}
+	query := `SELECT id, name, created_at, updated_at FROM person WHERE id = $1`
+	err := r.db.GetContext(ctx, storePerson, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.Error("Failed to find person by ID", zap.Error(err))
+		return nil, err
+	}
+
+	creditsQuery := `
+		SELECT fc.film_id, fc.person_id, fc.job, f.title AS film_title, p.name AS person_name
+		FROM film_crew AS fc
+		JOIN film AS f ON fc.film_id = f.id
+		JOIN person AS p ON fc.person_id = p.id
+		WHERE fc.person_id = $1
+		ORDER BY f.release_date, f.id, fc.job
+	`
+	if err = r.db.SelectContext(ctx, &storePerson.Credits, creditsQuery, id); err != nil {
+		r.logger.Error("Failed to find person credits", zap.Error(err))
+		return nil, err
+	}
+	return store.PersonStoreToDomain(storePerson)
+}
+
+func (r *PersonRepository) UpdatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error) {
+	storePerson := store.PersonDomainToStore(person)
+	query := `UPDATE person SET name = $1 WHERE id = $2`
+	_, err := r.db.ExecContext(ctx, query, storePerson.Name, storePerson.ID)
+	if err != nil {
+		r.logger.Error("Failed to update person", zap.Error(err))
+		return nil, err
+	}
+	return person, nil
+}
+
+func (r *PersonRepository) DeletePerson(ctx context.Context, id int) error {
+	query := `DELETE FROM person WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error("Failed to delete person", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetAllPersons lists people ordered by id, without their credits.
+func (r *PersonRepository) GetAllPersons(ctx context.Context, filter domain.PersonFilter, page domain.PageRequest) ([]*domain.Person, *domain.PageInfo, error) {
+	keys := []sortKey{{name: "id", cast: "int"}}
+	cursorOf := func(person *domain.Person) *domain.Cursor {
+		return &domain.Cursor{ID: person.GetId()}
+	}
+
+	where, args := personFilterCondition(filter, "p", []any{})
+
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT count(*) FROM person AS p WHERE `+where, args...); err != nil {
+		r.logger.Error("Failed to count persons", zap.Error(err))
+		return nil, nil, err
+	}
+
+	if page.Cursor != nil {
+		keyset, keysetArgs, err := keysetCondition(keys, "p", page.Cursor, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		where, args = where+" AND "+keyset, keysetArgs
+	}
+	backward := page.Cursor != nil && page.Cursor.Backward
+	limit, offset := limitOffset(page)
+	args = append(args, limit, offset)
+
+	query := fmt.Sprintf(`
+		SELECT p.id, p.name, p.created_at, p.updated_at
+		FROM person AS p
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, where, orderBy(keys, "p", backward), len(args)-1, len(args))
+	var storePersons []*store.Person
+	if err := r.db.SelectContext(ctx, &storePersons, query, args...); err != nil {
+		r.logger.Error("Failed to get all persons", zap.Error(err))
+		return nil, nil, err
+	}
+
+	persons := make([]*domain.Person, 0, len(storePersons))
+	for _, storePerson := range storePersons {
+		person, err := store.PersonStoreToDomain(storePerson)
+		if err != nil {
+			r.logger.Error("Failed to convert person", zap.Error(err))
+			continue
+		}
+		persons = append(persons, person)
+	}
+
+	persons, info := paginate(persons, page, total, keys, cursorOf)
+	return persons, info, nil
+}
+
+// personFilterCondition renders the filter as a condition on the person table
+// under alias. Filter values are appended to args as placeholders.
+func personFilterCondition(filter domain.PersonFilter, alias string, args []any) (string, []any) {
+	conditions := []string{"TRUE"}
+	if filter.Name != "" {
+		args = append(args, "%"+likeEscaper.Replace(filter.Name)+"%")
+		conditions = append(conditions, fmt.Sprintf(`%s.name ILIKE $%d ESCAPE '\'`, alias, len(args)))
+	}
+	if filter.Job != "" {
+		args = append(args, string(filter.Job))
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM film_crew AS fc WHERE fc.person_id = %s.id AND fc.job = $%d)", alias, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/go-sqlxmock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestPersonRepository_CreatePerson(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewPersonRepository(db, zap.NewNop())
+
+	person, _ := domain.NewPerson(0, "Christopher Nolan", nil)
+
+	mock.ExpectQuery("INSERT INTO person").
+		WithArgs("Christopher Nolan").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	result, err := r.CreatePerson(context.Background(), person)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.GetId())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPersonRepository_FindPersonByID(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewPersonRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("SELECT (.+) FROM person WHERE id = (.+)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+			AddRow(1, "Christopher Nolan", time.Unix(0, 0), time.Unix(0, 0)))
+	mock.ExpectQuery("SELECT (.+) FROM film_crew (.+) WHERE fc.person_id = (.+)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id", "person_id", "job", "film_title", "person_name"}).
+			AddRow(2, 1, "director", "Inception", "Christopher Nolan").
+			AddRow(2, 1, "writer", "Inception", "Christopher Nolan"))
+
+	result, err := r.FindPersonByID(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Christopher Nolan", result.GetName())
+	assert.Equal(t, []*domain.Credit{
+		{FilmID: 2, PersonID: 1, Job: domain.JobDirector, FilmTitle: "Inception", PersonName: "Christopher Nolan"},
+		{FilmID: 2, PersonID: 1, Job: domain.JobWriter, FilmTitle: "Inception", PersonName: "Christopher Nolan"},
+	}, result.GetCredits())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPersonRepository_FindPersonByID_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewPersonRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("SELECT (.+) FROM person WHERE id = (.+)").
+		WithArgs(1).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = r.FindPersonByID(context.Background(), 1)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPersonRepository_UpdatePerson(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewPersonRepository(db, zap.NewNop())
+
+	person, _ := domain.NewPerson(1, "Hans Zimmer", nil)
+
+	mock.ExpectExec("UPDATE person").
+		WithArgs("Hans Zimmer", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	result, err := r.UpdatePerson(context.Background(), person)
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPersonRepository_DeletePerson(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewPersonRepository(db, zap.NewNop())
+
+	mock.ExpectExec("DELETE FROM person").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = r.DeletePerson(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPersonRepository_GetAllPersons(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewPersonRepository(db, zap.NewNop())
+
+	filter := domain.PersonFilter{Name: "no_", Job: domain.JobDirector}
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM person AS p WHERE TRUE AND p.name ILIKE \$1 (.+) AND EXISTS (.+) fc.job = \$2\)`).
+		WithArgs(`%no\_%`, "director").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT (.+) FROM person AS p WHERE (.+) ORDER BY p.id ASC LIMIT \\$3 OFFSET \\$4").
+		WithArgs(`%no\_%`, "director", 3, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+			AddRow(1, "Christopher Nolan", time.Unix(0, 0), time.Unix(0, 0)).
+			AddRow(2, "Jonathan Nolan", time.Unix(0, 0), time.Unix(0, 0)).
+			AddRow(3, "Nolan North", time.Unix(0, 0), time.Unix(0, 0)))
+
+	results, info, err := r.GetAllPersons(context.Background(), filter, domain.PageRequest{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, 3, info.Total)
+	assert.NotNil(t, info.NextCursor)
+	assert.Equal(t, 2, info.NextCursor.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
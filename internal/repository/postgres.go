@@ -1,12 +1,16 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"github.com/Max425/film-library.git/internal/comfig"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
+// uniqueViolation is the postgres error code of a broken unique constraint.
+const uniqueViolation = "23505"
+
 func NewPostgresDB(cfg config.PostgresConfig) (*sqlx.DB, error) {
 	db, err := sqlx.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.Username, cfg.DBName, cfg.Password, cfg.SSLMode))
@@ -21,3 +25,9 @@ func NewPostgresDB(cfg config.PostgresConfig) (*sqlx.DB, error) {
 
 	return db, nil
 }
+
+// isUniqueViolation reports whether err comes from a broken unique constraint.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// loginFailuresKey counts the failed sign in attempts of an account or an address.
+	loginFailuresKey = "login_failures:"
+	// loginLockKey exists while sign in is locked for an account or an address.
+	loginLockKey = "login_lock:"
+)
+
+// AddLoginFailure counts a failed sign in attempt under the key and returns
+// the count. The count starts over once the window since the first failure
+// is over.
+func (r *RedisStore) AddLoginFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	failures, err := r.client.Incr(ctx, loginFailuresKey+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if failures == 1 {
+		if err = r.client.Expire(ctx, loginFailuresKey+key, window).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return int(failures), nil
+}
+
+// SetLoginLock locks sign in under the key for the lifetime.
+func (r *RedisStore) SetLoginLock(ctx context.Context, key string, lifetime time.Duration) error {
+	return r.client.Set(ctx, loginLockKey+key, 1, lifetime).Err()
+}
+
+// GetLoginLock returns how long sign in stays locked under the key, zero
+// when it is not locked.
+func (r *RedisStore) GetLoginLock(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, loginLockKey+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// ResetLoginFailures forgets the failed attempts under the key and lifts
+// its lock.
+func (r *RedisStore) ResetLoginFailures(ctx context.Context, key string) error {
+	return r.client.Del(ctx, loginFailuresKey+key, loginLockKey+key).Err()
+}
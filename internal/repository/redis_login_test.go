@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisStore_AddLoginFailure(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+
+	repo := NewRedisStore(client)
+
+	mock.ExpectIncr("login_failures:mail:bob@example.com").SetVal(1)
+	mock.ExpectExpire("login_failures:mail:bob@example.com", 15*time.Minute).SetVal(true)
+	mock.ExpectIncr("login_failures:mail:bob@example.com").SetVal(2)
+
+	failures, err := repo.AddLoginFailure(ctx, "mail:bob@example.com", 15*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failures)
+
+	failures, err = repo.AddLoginFailure(ctx, "mail:bob@example.com", 15*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, failures)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisStore_GetLoginLock(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+
+	repo := NewRedisStore(client)
+
+	mock.ExpectPTTL("login_lock:ip:10.0.0.1").SetVal(90 * time.Second)
+	mock.ExpectPTTL("login_lock:ip:10.0.0.2").SetVal(-2)
+
+	retryAfter, err := repo.GetLoginLock(ctx, "ip:10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second, retryAfter)
+
+	retryAfter, err = repo.GetLoginLock(ctx, "ip:10.0.0.2")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), retryAfter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisStore_SetLoginLock(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+
+	repo := NewRedisStore(client)
+
+	mock.ExpectSet("login_lock:mail:bob@example.com", 1, time.Hour).SetVal("OK")
+	mock.ExpectDel("login_failures:mail:bob@example.com", "login_lock:mail:bob@example.com").SetVal(2)
+
+	assert.NoError(t, repo.SetLoginLock(ctx, "mail:bob@example.com", time.Hour))
+	assert.NoError(t, repo.ResetLoginFailures(ctx, "mail:bob@example.com"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,12 +2,26 @@ package repository
 
 import (
 	"context"
-	"strconv"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
 	"time"
 
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/repository/store"
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	// userSessionsKey is the set of the session ids of a user.
+	userSessionsKey = "user_sessions:%d"
+	// spentSessionKey is a session taken already, kept to notice its id presented again.
+	spentSessionKey = "spent:%s"
+)
+
 type RedisStore struct {
 	client *redis.Client
 }
@@ -16,29 +30,229 @@ func NewRedisStore(client *redis.Client) *RedisStore {
 	return &RedisStore{client: client}
 }
 
-func (r *RedisStore) SetSession(ctx context.Context, SID string, role int, lifetime time.Duration) error {
-	if err := r.client.Set(ctx, SID, role, lifetime).Err(); err != nil {
+// SetSession stores the session under the SID and adds the SID to the
+// sessions of the user, the session gets its public id. The index lives as
+// long as the session that lives longest, a session shorter than the others
+// does not cut it.
+func (r *RedisStore) SetSession(ctx context.Context, SID string, session *domain.Session, lifetime time.Duration) error {
+	data, err := json.Marshal(store.SessionDomainToStore(session))
+	if err != nil {
+		return err
+	}
+	if err = r.client.Set(ctx, SID, data, lifetime).Err(); err != nil {
+		return err
+	}
+
+	index := fmt.Sprintf(userSessionsKey, session.UserID)
+	if err = r.client.SAdd(ctx, index, SID).Err(); err != nil {
+		return err
+	}
+	// NX gives a new index its lifetime, GT only ever extends it
+	if err = r.client.ExpireNX(ctx, index, lifetime).Err(); err != nil {
+		return err
+	}
+	if err = r.client.ExpireGT(ctx, index, lifetime).Err(); err != nil {
 		return err
 	}
+	session.ID = sessionID(SID)
 	return nil
 }
 
-func (r *RedisStore) GetSession(ctx context.Context, SID string) (int, error) {
-	val, err := r.client.Get(ctx, SID).Result()
+func (r *RedisStore) GetSession(ctx context.Context, SID string) (*domain.Session, error) {
+	val, err := r.client.Get(ctx, SID).Bytes()
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	var session store.Session
+	if err = json.Unmarshal(val, &session); err != nil {
+		return nil, err
+	}
+	domainSession := store.SessionStoreToDomain(&session)
+	domainSession.ID = sessionID(SID)
+	return domainSession, nil
+}
+
+// TakeSession returns the session and removes it, so only one caller gets
+// it. ErrNotFound when there is no such session.
+func (r *RedisStore) TakeSession(ctx context.Context, SID string) (*domain.Session, error) {
+	val, err := r.client.GetDel(ctx, SID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, domain.ErrNotFound
 	}
-	intVal, err := strconv.Atoi(val)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	var session store.Session
+	if err = json.Unmarshal(val, &session); err != nil {
+		return nil, err
+	}
+	if err = r.client.SRem(ctx, fmt.Sprintf(userSessionsKey, session.UserID), SID).Err(); err != nil {
+		return nil, err
 	}
-	return intVal, nil
+	domainSession := store.SessionStoreToDomain(&session)
+	domainSession.ID = sessionID(SID)
+	return domainSession, nil
 }
 
-func (r *RedisStore) DeleteSession(ctx context.Context, SID string) error {
-	err := r.client.Del(ctx, SID).Err()
+// SetSpentSession remembers the session taken under the SID for the lifetime.
+func (r *RedisStore) SetSpentSession(ctx context.Context, SID string, session *domain.Session, lifetime time.Duration) error {
+	data, err := json.Marshal(store.SessionDomainToStore(session))
 	if err != nil {
 		return err
 	}
+	return r.client.Set(ctx, fmt.Sprintf(spentSessionKey, SID), data, lifetime).Err()
+}
+
+// GetSpentSession returns the session taken under the SID before,
+// ErrNotFound when it was not taken or is forgotten already.
+func (r *RedisStore) GetSpentSession(ctx context.Context, SID string) (*domain.Session, error) {
+	val, err := r.client.Get(ctx, fmt.Sprintf(spentSessionKey, SID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var session store.Session
+	if err = json.Unmarshal(val, &session); err != nil {
+		return nil, err
+	}
+	return store.SessionStoreToDomain(&session), nil
+}
+
+// DeleteSessionFamily removes the sessions of the user from the family.
+func (r *RedisStore) DeleteSessionFamily(ctx context.Context, userID int, family string) error {
+	if family == "" {
+		return nil
+	}
+	index := fmt.Sprintf(userSessionsKey, userID)
+	SIDs, err := r.client.SMembers(ctx, index).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, SID := range SIDs {
+		session, err := r.GetSession(ctx, SID)
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if session.Family != family {
+			continue
+		}
+		if err = r.client.Del(ctx, SID).Err(); err != nil {
+			return err
+		}
+		if err = r.client.SRem(ctx, index, SID).Err(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// DeleteSession removes the session and drops it from the sessions of its user.
+func (r *RedisStore) DeleteSession(ctx context.Context, SID string) error {
+	session, err := r.GetSession(ctx, SID)
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = r.client.Del(ctx, SID).Err(); err != nil {
+		return err
+	}
+	return r.client.SRem(ctx, fmt.Sprintf(userSessionsKey, session.UserID), SID).Err()
+}
+
+// GetUserSessions returns the live sessions of the user, the latest started
+// first. Expired sessions are dropped from the index on the way.
+func (r *RedisStore) GetUserSessions(ctx context.Context, userID int) ([]*domain.Session, error) {
+	index := fmt.Sprintf(userSessionsKey, userID)
+	SIDs, err := r.client.SMembers(ctx, index).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*domain.Session, 0, len(SIDs))
+	for _, SID := range SIDs {
+		session, err := r.GetSession(ctx, SID)
+		if errors.Is(err, redis.Nil) {
+			if err = r.client.SRem(ctx, index, SID).Err(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+// GetUserSession returns the session of the user with the public id,
+// ErrNotFound when the user has no such session.
+func (r *RedisStore) GetUserSession(ctx context.Context, userID int, id string) (*domain.Session, error) {
+	SIDs, err := r.client.SMembers(ctx, fmt.Sprintf(userSessionsKey, userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, SID := range SIDs {
+		if sessionID(SID) != id {
+			continue
+		}
+		session, err := r.GetSession(ctx, SID)
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrNotFound
+		}
+		return session, err
+	}
+	return nil, domain.ErrNotFound
+}
+
+// DeleteUserSession removes the session of the user with the public id,
+// ErrNotFound when the user has no such session.
+func (r *RedisStore) DeleteUserSession(ctx context.Context, userID int, id string) error {
+	index := fmt.Sprintf(userSessionsKey, userID)
+	SIDs, err := r.client.SMembers(ctx, index).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, SID := range SIDs {
+		if sessionID(SID) != id {
+			continue
+		}
+		if err = r.client.Del(ctx, SID).Err(); err != nil {
+			return err
+		}
+		return r.client.SRem(ctx, index, SID).Err()
+	}
+	return domain.ErrNotFound
+}
+
+// DeleteUserSessions removes every session of the user.
+func (r *RedisStore) DeleteUserSessions(ctx context.Context, userID int) error {
+	index := fmt.Sprintf(userSessionsKey, userID)
+	SIDs, err := r.client.SMembers(ctx, index).Result()
+	if err != nil {
+		return err
+	}
+	return r.client.Del(ctx, append(SIDs, index)...).Err()
+}
+
+// sessionID is the public id of the session. The SID itself works as a
+// credential, so it never leaves the cookie.
+func sessionID(SID string) string {
+	sum := sha256.Sum256([]byte(SID))
+	return hex.EncodeToString(sum[:8])
+}
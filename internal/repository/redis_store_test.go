@@ -2,9 +2,9 @@ package repository
 
 import (
 	"context"
+	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/go-redis/redismock/v9"
 	"log"
-	"strconv"
 	"testing"
 	"time"
 
@@ -17,15 +17,16 @@ func TestRedisStore_SetSession(t *testing.T) {
 	tests := []struct {
 		name        string
 		key         string
-		value       int
-		expectValue int
+		value       *domain.Session
+		stored      string
 		time        time.Duration
 		expectedErr bool
 	}{
 		{
 			name:        "success test: redis set session",
 			key:         "examplekey",
-			value:       123,
+			value:       &domain.Session{UserID: 7, Role: 1, CreatedAt: time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC), UserAgent: "curl/8.0", IP: "10.0.0.1"},
+			stored:      `{"user_id":7,"role":1,"created_at":"2024-03-18T12:00:00Z","user_agent":"curl/8.0","ip":"10.0.0.1"}`,
 			time:        30 * time.Minute,
 			expectedErr: false,
 		},
@@ -43,7 +44,10 @@ func TestRedisStore_SetSession(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			mock.ExpectSet(test.key, test.value, test.time).SetVal("OK")
+			mock.ExpectSet(test.key, []byte(test.stored), test.time).SetVal("OK")
+			mock.ExpectSAdd("user_sessions:7", test.key).SetVal(1)
+			mock.ExpectExpireNX("user_sessions:7", test.time).SetVal(true)
+			mock.ExpectExpireGT("user_sessions:7", test.time).SetVal(false)
 			err = repo.SetSession(ctx, test.key, test.value, test.time)
 
 			if test.expectedErr {
@@ -59,19 +63,67 @@ func TestRedisStore_SetSession(t *testing.T) {
 	}
 }
 
+func TestRedisStore_SetSession_ShorterSession(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+	repo := NewRedisStore(client)
+
+	long := &domain.Session{UserID: 7, Role: 1, CreatedAt: time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)}
+	longStored := `{"user_id":7,"role":1,"created_at":"2024-03-18T12:00:00Z"}`
+	short := &domain.Session{UserID: 7, Role: 1, CreatedAt: time.Date(2024, time.March, 19, 12, 0, 0, 0, time.UTC)}
+	shortStored := `{"user_id":7,"role":1,"created_at":"2024-03-19T12:00:00Z"}`
+
+	// the long session sets the lifetime of the new index
+	mock.ExpectSet("long", []byte(longStored), 30*24*time.Hour).SetVal("OK")
+	mock.ExpectSAdd("user_sessions:7", "long").SetVal(1)
+	mock.ExpectExpireNX("user_sessions:7", 30*24*time.Hour).SetVal(true)
+	mock.ExpectExpireGT("user_sessions:7", 30*24*time.Hour).SetVal(false)
+	// the short one leaves it as it is
+	mock.ExpectSet("short", []byte(shortStored), time.Hour).SetVal("OK")
+	mock.ExpectSAdd("user_sessions:7", "short").SetVal(1)
+	mock.ExpectExpireNX("user_sessions:7", time.Hour).SetVal(false)
+	mock.ExpectExpireGT("user_sessions:7", time.Hour).SetVal(false)
+	// an hour later the short session is gone, the long one is listed still
+	mock.ExpectSMembers("user_sessions:7").SetVal([]string{"long", "short"})
+	mock.ExpectGet("long").SetVal(longStored)
+	mock.ExpectGet("short").RedisNil()
+	mock.ExpectSRem("user_sessions:7", "short").SetVal(1)
+	// and revoked with the other sessions of the user
+	mock.ExpectSMembers("user_sessions:7").SetVal([]string{"long"})
+	mock.ExpectDel("long", "user_sessions:7").SetVal(2)
+
+	assert.NoError(t, repo.SetSession(ctx, "long", long, 30*24*time.Hour))
+	assert.NoError(t, repo.SetSession(ctx, "short", short, time.Hour))
+	sessions, err := repo.GetUserSessions(ctx, 7)
+	assert.NoError(t, err)
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, sessionID("long"), sessions[0].ID)
+	}
+	assert.NoError(t, repo.DeleteUserSessions(ctx, 7))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestRedisStore_GetSession(t *testing.T) {
 	tests := []struct {
 		name        string
 		key         string
-		expectedVal int
+		stored      string
+		expectedVal *domain.Session
 		expectedErr bool
 	}{
 		{
 			name:        "success test: redis get session",
 			key:         "examplekey",
-			expectedVal: 123,
+			stored:      `{"user_id":7,"role":1,"created_at":"2024-03-18T12:00:00Z","user_agent":"curl/8.0","ip":"10.0.0.1"}`,
+			expectedVal: &domain.Session{ID: sessionID("examplekey"), UserID: 7, Role: 1, CreatedAt: time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC), UserAgent: "curl/8.0", IP: "10.0.0.1"},
 			expectedErr: false,
 		},
+		{
+			name:        "fail test: redis get malformed session",
+			key:         "examplekey",
+			stored:      "123",
+			expectedErr: true,
+		},
 	}
 
 	client, mock := redismock.NewClientMock()
@@ -86,7 +138,7 @@ func TestRedisStore_GetSession(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			mock.ExpectGet(test.key).SetVal(strconv.Itoa(test.expectedVal))
+			mock.ExpectGet(test.key).SetVal(test.stored)
 			val, err := repo.GetSession(ctx, test.key)
 
 			if test.expectedErr {
@@ -103,15 +155,47 @@ func TestRedisStore_GetSession(t *testing.T) {
 	}
 }
 
+func TestRedisStore_TakeSession(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+	repo := NewRedisStore(client)
+
+	t.Run("success test: redis take session", func(t *testing.T) {
+		mock.ExpectGetDel("refresh:abc").SetVal(`{"user_id":7,"role":1,"created_at":"2024-03-18T12:00:00Z"}`)
+		mock.ExpectSRem("user_sessions:7", "refresh:abc").SetVal(1)
+		val, err := repo.TakeSession(ctx, "refresh:abc")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Session{ID: sessionID("refresh:abc"), UserID: 7, Role: 1, CreatedAt: time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)}, val)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail test: redis take used session", func(t *testing.T) {
+		mock.ExpectGetDel("refresh:abc").RedisNil()
+		val, err := repo.TakeSession(ctx, "refresh:abc")
+
+		assert.Nil(t, val)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestRedisStore_DeleteSession(t *testing.T) {
 	tests := []struct {
 		name        string
 		key         string
+		stored      string
 		expectedErr bool
 	}{
 		{
 			name:        "success test: redis delete session",
 			key:         "examplekey",
+			stored:      `{"user_id":7,"role":1}`,
+			expectedErr: false,
+		},
+		{
+			name:        "success test: redis delete expired session",
+			key:         "examplekey",
 			expectedErr: false,
 		},
 	}
@@ -128,7 +212,13 @@ func TestRedisStore_DeleteSession(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			mock.ExpectDel(test.key).SetVal(int64(1))
+			if test.stored == "" {
+				mock.ExpectGet(test.key).RedisNil()
+			} else {
+				mock.ExpectGet(test.key).SetVal(test.stored)
+				mock.ExpectDel(test.key).SetVal(int64(1))
+				mock.ExpectSRem("user_sessions:7", test.key).SetVal(1)
+			}
 			err := repo.DeleteSession(ctx, test.key)
 
 			if test.expectedErr {
@@ -143,3 +233,137 @@ func TestRedisStore_DeleteSession(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisStore_GetUserSessions(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+
+	repo := NewRedisStore(client)
+
+	mock.ExpectSMembers("user_sessions:7").SetVal([]string{"old", "expired", "new"})
+	mock.ExpectGet("old").SetVal(`{"user_id":7,"role":0,"created_at":"2024-03-18T12:00:00Z","user_agent":"curl/8.0"}`)
+	mock.ExpectGet("expired").RedisNil()
+	mock.ExpectSRem("user_sessions:7", "expired").SetVal(1)
+	mock.ExpectGet("new").SetVal(`{"user_id":7,"role":0,"created_at":"2024-03-19T12:00:00Z","user_agent":"Firefox"}`)
+
+	sessions, err := repo.GetUserSessions(ctx, 7)
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+	assert.Equal(t, sessionID("new"), sessions[0].ID)
+	assert.Equal(t, "Firefox", sessions[0].UserAgent)
+	assert.Equal(t, sessionID("old"), sessions[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisStore_SpentSession(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+	repo := NewRedisStore(client)
+
+	createdAt := time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)
+	stored := `{"user_id":7,"role":1,"created_at":"2024-03-18T12:00:00Z","family":"f"}`
+	mock.ExpectSet("spent:refresh:abc", []byte(stored), time.Hour).SetVal("OK")
+	mock.ExpectGet("spent:refresh:abc").SetVal(stored)
+	mock.ExpectGet("spent:refresh:def").RedisNil()
+
+	assert.NoError(t, repo.SetSpentSession(ctx, "refresh:abc", &domain.Session{UserID: 7, Role: 1, CreatedAt: createdAt, Family: "f"}, time.Hour))
+	session, err := repo.GetSpentSession(ctx, "refresh:abc")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Session{UserID: 7, Role: 1, CreatedAt: createdAt, Family: "f"}, session)
+	_, err = repo.GetSpentSession(ctx, "refresh:def")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisStore_DeleteSessionFamily(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+	repo := NewRedisStore(client)
+
+	mock.ExpectSMembers("user_sessions:7").SetVal([]string{"cookie", "refresh:a", "refresh:b", "expired"})
+	mock.ExpectGet("cookie").SetVal(`{"user_id":7,"role":0,"created_at":"2024-03-18T12:00:00Z"}`)
+	mock.ExpectGet("refresh:a").SetVal(`{"user_id":7,"role":0,"created_at":"2024-03-18T12:00:00Z","family":"f"}`)
+	mock.ExpectDel("refresh:a").SetVal(1)
+	mock.ExpectSRem("user_sessions:7", "refresh:a").SetVal(1)
+	mock.ExpectGet("refresh:b").SetVal(`{"user_id":7,"role":0,"created_at":"2024-03-18T12:00:00Z","family":"g"}`)
+	mock.ExpectGet("expired").RedisNil()
+
+	assert.NoError(t, repo.DeleteSessionFamily(ctx, 7, "f"))
+	assert.NoError(t, repo.DeleteSessionFamily(ctx, 7, ""))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisStore_GetUserSession(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+	repo := NewRedisStore(client)
+
+	mock.ExpectSMembers("user_sessions:7").SetVal([]string{"first", "second"})
+	mock.ExpectGet("second").SetVal(`{"user_id":7,"role":0,"created_at":"2024-03-18T12:00:00Z"}`)
+	mock.ExpectSMembers("user_sessions:7").SetVal([]string{"first", "second"})
+	mock.ExpectGet("second").RedisNil()
+	mock.ExpectSMembers("user_sessions:7").SetVal([]string{"first"})
+
+	session, err := repo.GetUserSession(ctx, 7, sessionID("second"))
+	assert.NoError(t, err)
+	assert.Equal(t, sessionID("second"), session.ID)
+	_, err = repo.GetUserSession(ctx, 7, sessionID("second"))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	_, err = repo.GetUserSession(ctx, 7, sessionID("second"))
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisStore_DeleteUserSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		expectedErr error
+	}{
+		{
+			name: "success test: redis delete user session",
+			id:   sessionID("second"),
+		},
+		{
+			name:        "fail test: redis delete unknown user session",
+			id:          sessionID("third"),
+			expectedErr: domain.ErrNotFound,
+		},
+	}
+
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+
+	repo := NewRedisStore(client)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock.ExpectSMembers("user_sessions:7").SetVal([]string{"first", "second"})
+			if test.expectedErr == nil {
+				mock.ExpectDel("second").SetVal(1)
+				mock.ExpectSRem("user_sessions:7", "second").SetVal(1)
+			}
+
+			err := repo.DeleteUserSession(ctx, 7, test.id)
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRedisStore_DeleteUserSessions(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+
+	repo := NewRedisStore(client)
+
+	mock.ExpectSMembers("user_sessions:7").SetVal([]string{"first", "second"})
+	mock.ExpectDel("first", "second", "user_sessions:7").SetVal(3)
+
+	assert.NoError(t, repo.DeleteUserSessions(ctx, 7))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// tokenKey is the user id a token of a purpose was issued to, by the hash of the token.
+	tokenKey = "token:%s:%s"
+	// userTokenKey is the key of the last token of a purpose issued to the user.
+	userTokenKey = "user_token:%s:%d"
+)
+
+// SetToken stores the user the token is issued to for the lifetime of the
+// token. Only a hash of the token is kept, it works as a credential. The
+// token of the purpose issued to the user before is removed, only the last
+// one sent works.
+func (r *RedisStore) SetToken(ctx context.Context, purpose domain.TokenPurpose, token string, userID int, lifetime time.Duration) error {
+	key := hashedTokenKey(purpose, token)
+	if err := r.client.Set(ctx, key, userID, lifetime).Err(); err != nil {
+		return err
+	}
+	previous, err := r.client.SetArgs(ctx, fmt.Sprintf(userTokenKey, purpose, userID), key, redis.SetArgs{TTL: lifetime, Get: true}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if previous == key {
+		return nil
+	}
+	return r.client.Del(ctx, previous).Err()
+}
+
+// TakeToken returns the user the token was issued to and removes the token,
+// so it is used once. ErrNotFound when the token is unknown or expired.
+func (r *RedisStore) TakeToken(ctx context.Context, purpose domain.TokenPurpose, token string) (int, error) {
+	val, err := r.client.GetDel(ctx, hashedTokenKey(purpose, token)).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, domain.ErrNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(val)
+}
+
+func hashedTokenKey(purpose domain.TokenPurpose, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return fmt.Sprintf(tokenKey, purpose, hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisStore_SetToken(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+
+	repo := NewRedisStore(client)
+
+	key := hashedTokenKey(domain.TokenPasswordReset, "secret")
+	assert.NotContains(t, key, "secret")
+	mock.ExpectSet(key, 7, time.Hour).SetVal("OK")
+	mock.ExpectSetArgs("user_token:password_reset:7", key, redis.SetArgs{TTL: time.Hour, Get: true}).RedisNil()
+
+	assert.NoError(t, repo.SetToken(ctx, domain.TokenPasswordReset, "secret", 7, time.Hour))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisStore_SetToken_DropsPrevious(t *testing.T) {
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+
+	repo := NewRedisStore(client)
+
+	previous := hashedTokenKey(domain.TokenPasswordReset, "old")
+	key := hashedTokenKey(domain.TokenPasswordReset, "new")
+	mock.ExpectSet(key, 7, time.Hour).SetVal("OK")
+	mock.ExpectSetArgs("user_token:password_reset:7", key, redis.SetArgs{TTL: time.Hour, Get: true}).SetVal(previous)
+	mock.ExpectDel(previous).SetVal(1)
+
+	assert.NoError(t, repo.SetToken(ctx, domain.TokenPasswordReset, "new", 7, time.Hour))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRedisStore_TakeToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		purpose     domain.TokenPurpose
+		found       bool
+		expectedID  int
+		expectedErr error
+	}{
+		{name: "success test: redis take token", purpose: domain.TokenPasswordReset, found: true, expectedID: 7},
+		{name: "fail test: redis take used token", purpose: domain.TokenPasswordReset, expectedErr: domain.ErrNotFound},
+		{name: "fail test: redis take token of another purpose", purpose: domain.TokenMailVerification, expectedErr: domain.ErrNotFound},
+	}
+
+	client, mock := redismock.NewClientMock()
+	defer client.Close()
+
+	repo := NewRedisStore(client)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.found {
+				mock.ExpectGetDel(hashedTokenKey(test.purpose, "secret")).SetVal("7")
+			} else {
+				mock.ExpectGetDel(hashedTokenKey(test.purpose, "secret")).RedisNil()
+			}
+
+			id, err := repo.TakeToken(ctx, test.purpose, "secret")
+			assert.Equal(t, test.expectedID, id)
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHashedTokenKey(t *testing.T) {
+	assert.NotEqual(t, hashedTokenKey(domain.TokenPasswordReset, "secret"), hashedTokenKey(domain.TokenMailVerification, "secret"))
+	assert.Equal(t, hashedTokenKey(domain.TokenPasswordReset, "secret"), hashedTokenKey(domain.TokenPasswordReset, "secret"))
+}
@@ -9,6 +9,10 @@ import (
 type Repository struct {
 	FilmRepository
 	ActorRepository
+	PersonRepository
+	GenreRepository
+	ReviewRepository
+	ListRepository
 	UserRepository
 	RedisStore
 }
@@ -17,6 +21,10 @@ func NewRepository(db *sqlx.DB, logger *zap.Logger, client *redis.Client) *Repos
 	return &Repository{
 		*NewFilmRepository(db, logger),
 		*NewActorRepository(db, logger),
+		*NewPersonRepository(db, logger),
+		*NewGenreRepository(db, logger),
+		*NewReviewRepository(db, logger),
+		*NewListRepository(db, logger),
 		*NewUserRepository(db, logger),
 		*NewRedisStore(client),
 	}
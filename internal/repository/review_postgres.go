@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/internal/repository/store"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+	"strings"
+)
+
+type ReviewRepository struct {
+	db     *sqlx.DB
+	logger *zap.Logger
+}
+
+func NewReviewRepository(db *sqlx.DB, logger *zap.Logger) *ReviewRepository {
+	return &ReviewRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// CreateReview adds the review and recalculates the user rating of the film
+// in one transaction. A user reviews a film once.
+func (r *ReviewRepository) CreateReview(ctx context.Context, review *domain.Review) (*domain.Review, error) {
+	storeReview := store.ReviewDomainToStore(review)
+	err := withTx(ctx, r.db, r.logger, nil, func(tx *sqlx.Tx) error {
+		if err := r.lockFilm(ctx, tx, storeReview.FilmID); err != nil {
+			return err
+		}
+
+		query := `INSERT INTO review (film_id, user_id, rating, text) VALUES ($1, $2, $3, $4) RETURNING id, created_at, updated_at`
+		err := tx.QueryRowxContext(ctx, query, storeReview.FilmID, storeReview.UserID, storeReview.Rating, storeReview.Text).
+			Scan(&storeReview.ID, &storeReview.CreatedAt, &storeReview.UpdatedAt)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return fmt.Errorf("%w: review of film %d", domain.ErrAlreadyExists, storeReview.FilmID)
+			}
+			r.logger.Error("Failed to create review", zap.Error(err))
+			return err
+		}
+
+		return r.updateFilmRating(ctx, tx, storeReview.FilmID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return store.ReviewStoreToDomain(storeReview)
+}
+
+func (r *ReviewRepository) FindReviewByID(ctx context.Context, id int) (*domain.Review, error) {
+	storeReview := &store.Review{}
+	query := `SELECT id, film_id, user_id, rating, text, created_at, updated_at FROM review WHERE id = $1`
+	err := r.db.GetContext(ctx, storeReview, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.Error("Failed to find review by ID", zap.Error(err))
+		return nil, err
+	}
+	return store.ReviewStoreToDomain(storeReview)
+}
+
+// UpdateReview changes the rating and the text of the review and recalculates
+// the user rating of the film in one transaction.
+func (r *ReviewRepository) UpdateReview(ctx context.Context, review *domain.Review) (*domain.Review, error) {
+	storeReview := store.ReviewDomainToStore(review)
+	err := withTx(ctx, r.db, r.logger, nil, func(tx *sqlx.Tx) error {
+		if err := r.lockFilm(ctx, tx, storeReview.FilmID); err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE review SET rating = $1, text = $2, updated_at = timezone('europe/moscow'::text, now())
+			WHERE id = $3
+			RETURNING created_at, updated_at
+		`
+		err := tx.QueryRowxContext(ctx, query, storeReview.Rating, storeReview.Text, storeReview.ID).
+			Scan(&storeReview.CreatedAt, &storeReview.UpdatedAt)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrNotFound
+			}
+			r.logger.Error("Failed to update review", zap.Error(err))
+			return err
+		}
+
+		return r.updateFilmRating(ctx, tx, storeReview.FilmID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return store.ReviewStoreToDomain(storeReview)
+}
+
+// DeleteReview removes the review and recalculates the user rating of the
+// film in one transaction.
+func (r *ReviewRepository) DeleteReview(ctx context.Context, review *domain.Review) error {
+	return withTx(ctx, r.db, r.logger, nil, func(tx *sqlx.Tx) error {
+		if err := r.lockFilm(ctx, tx, review.GetFilmID()); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM review WHERE id = $1`, review.GetId()); err != nil {
+			r.logger.Error("Failed to delete review", zap.Error(err))
+			return err
+		}
+
+		return r.updateFilmRating(ctx, tx, review.GetFilmID())
+	})
+}
+
+// GetFilmReviews returns the reviews of the film, the newest first.
+func (r *ReviewRepository) GetFilmReviews(ctx context.Context, filmID int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error) {
+	return r.getReviews(ctx, "film", "film_id", filmID, page)
+}
+
+// GetUserReviews returns the reviews written by the user, the newest first.
+func (r *ReviewRepository) GetUserReviews(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error) {
+	return r.getReviews(ctx, "users", "user_id", userID, page)
+}
+
+// getReviews returns a page of the reviews whose column refers to the row
+// of the owner table with the id, ErrNotFound when there is no such row.
+func (r *ReviewRepository) getReviews(ctx context.Context, owner, column string, id int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error) {
+	keys := []sortKey{{name: "id", cast: "int", desc: true}}
+	cursorOf := func(review *domain.Review) *domain.Cursor {
+		return &domain.Cursor{ID: review.GetId()}
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT (SELECT count(*) FROM review WHERE %s = $1) FROM %s WHERE id = $1`, column, owner)
+	if err := r.db.GetContext(ctx, &total, countQuery, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("%w: %s %d", domain.ErrNotFound, strings.TrimSuffix(column, "_id"), id)
+		}
+		r.logger.Error("Failed to count reviews", zap.Error(err))
+		return nil, nil, err
+	}
+
+	where, args := fmt.Sprintf("r.%s = $1", column), []any{id}
+	if page.Cursor != nil {
+		keyset, keysetArgs, err := keysetCondition(keys, "r", page.Cursor, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		where, args = where+" AND "+keyset, keysetArgs
+	}
+	backward := page.Cursor != nil && page.Cursor.Backward
+	limit, offset := limitOffset(page)
+	args = append(args, limit, offset)
+
+	query := fmt.Sprintf(`
+		SELECT r.id, r.film_id, r.user_id, r.rating, r.text, r.created_at, r.updated_at
+		FROM review AS r
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, where, orderBy(keys, "r", backward), len(args)-1, len(args))
+	var storeReviews []*store.Review
+	if err := r.db.SelectContext(ctx, &storeReviews, query, args...); err != nil {
+		r.logger.Error("Failed to get reviews", zap.Error(err))
+		return nil, nil, err
+	}
+
+	reviews := make([]*domain.Review, 0, len(storeReviews))
+	for _, storeReview := range storeReviews {
+		review, err := store.ReviewStoreToDomain(storeReview)
+		if err != nil {
+			r.logger.Error("Failed to convert review", zap.Error(err))
+			continue
+		}
+		reviews = append(reviews, review)
+	}
+
+	reviews, info := paginate(reviews, page, total, keys, cursorOf)
+	return reviews, info, nil
+}
+
+// lockFilm locks the film row, so the user rating is recalculated by one
+// review write at a time and always sees the reviews committed before it.
+func (r *ReviewRepository) lockFilm(ctx context.Context, tx *sqlx.Tx, filmID int) error {
+	var id int
+	if err := tx.GetContext(ctx, &id, `SELECT id FROM film WHERE id = $1 FOR UPDATE`, filmID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: film %d", domain.ErrNotFound, filmID)
+		}
+		r.logger.Error("Failed to lock film", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// updateFilmRating recalculates the user rating and the vote count of the film.
+func (r *ReviewRepository) updateFilmRating(ctx context.Context, tx *sqlx.Tx, filmID int) error {
+	query := `
+		UPDATE film SET (user_rating, vote_count) = (
+			SELECT COALESCE(round(avg(rating), 2), 0), count(*) FROM review WHERE film_id = $1
+		)
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, filmID); err != nil {
+		r.logger.Error("Failed to update film rating", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhashkevych/go-sqlxmock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestReviewRepository_CreateReview(t *testing.T) {
+	tests := []struct {
+		name          string
+		filmExists    bool
+		queryError    error
+		expectedError string
+	}{
+		{name: "Ok", filmExists: true},
+		{name: "Already reviewed", filmExists: true, queryError: &pq.Error{Code: uniqueViolation}, expectedError: "already exists: review of film 1"},
+		{name: "Film not found", expectedError: "not found: film 1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewReviewRepository(db, zap.NewNop())
+
+			review, _ := domain.NewReview(0, 1, 7, 8, "Great")
+
+			mock.ExpectBegin()
+			lock := mock.ExpectQuery("SELECT id FROM film WHERE id = (.+) FOR UPDATE").WithArgs(1)
+			if !test.filmExists {
+				lock.WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			} else {
+				lock.WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				insert := mock.ExpectQuery("INSERT INTO review (.+) RETURNING id, created_at, updated_at").WithArgs(1, 7, 8, "Great")
+				if test.queryError != nil {
+					insert.WillReturnError(test.queryError)
+					mock.ExpectRollback()
+				} else {
+					insert.WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(3, time.Unix(0, 0), time.Unix(0, 0)))
+					mock.ExpectExec("UPDATE film SET \\(user_rating, vote_count\\) = (.+) FROM review WHERE film_id = \\$1").
+						WithArgs(1).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+				}
+			}
+
+			result, err := r.CreateReview(context.Background(), review)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, result.GetId())
+				assert.Equal(t, 8, result.GetRating())
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReviewRepository_UpdateReview(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewReviewRepository(db, zap.NewNop())
+
+	review, _ := domain.NewReview(3, 1, 7, 6, "Worse on rewatch")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM film WHERE id = (.+) FOR UPDATE").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("UPDATE review SET rating = \\$1, text = \\$2, (.+) WHERE id = \\$3").
+		WithArgs(6, "Worse on rewatch", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(time.Unix(0, 0), time.Unix(60, 0)))
+	mock.ExpectExec("UPDATE film SET \\(user_rating, vote_count\\)").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := r.UpdateReview(context.Background(), review)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, result.GetRating())
+	assert.Equal(t, time.Unix(60, 0), result.GetUpdatedAt())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewRepository_DeleteReview(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewReviewRepository(db, zap.NewNop())
+
+	review, _ := domain.NewReview(3, 1, 7, 6, "")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM film WHERE id = (.+) FOR UPDATE").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("DELETE FROM review WHERE id = (.+)").WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE film SET \\(user_rating, vote_count\\)").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.DeleteReview(context.Background(), review))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewRepository_GetFilmReviews(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewReviewRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("SELECT \\(SELECT count(.+) FROM review WHERE film_id = \\$1\\) FROM film WHERE id = \\$1").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT (.+) FROM review AS r WHERE r.film_id = \\$1 AND \\(\\(r.id < \\$2::int\\)\\) ORDER BY r.id DESC LIMIT \\$3 OFFSET \\$4").
+		WithArgs(1, "9", 3, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "film_id", "user_id", "rating", "text", "created_at", "updated_at"}).
+			AddRow(8, 1, 7, 9, "Great", time.Unix(0, 0), time.Unix(0, 0)).
+			AddRow(5, 1, 2, 4, "", time.Unix(0, 0), time.Unix(0, 0)).
+			AddRow(2, 1, 3, 7, "", time.Unix(0, 0), time.Unix(0, 0)))
+
+	cursor := &domain.Cursor{Sort: "id:desc", ID: 9}
+	results, info, err := r.GetFilmReviews(context.Background(), 1, domain.PageRequest{Limit: 2, Cursor: cursor})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, 8, results[0].GetId())
+	assert.Equal(t, 3, info.Total)
+	assert.Equal(t, &domain.Cursor{Sort: "id:desc", ID: 5}, info.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReviewRepository_GetUserReviews_UserNotFound(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewReviewRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("SELECT \\(SELECT count(.+) FROM review WHERE user_id = \\$1\\) FROM users WHERE id = \\$1").
+		WithArgs(42).
+		WillReturnError(sql.ErrNoRows)
+
+	_, _, err = r.GetUserReviews(context.Background(), 42, domain.PageRequest{Limit: 20})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.EqualError(t, err, "not found: user 42")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
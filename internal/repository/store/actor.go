@@ -7,13 +7,14 @@ import (
 
 // Actor in DB
 type Actor struct {
-	ID        int       `db:"id"`
-	Name      string    `db:"name"`
-	Gender    string    `db:"gender"`
-	BirthDate time.Time `db:"birth_date"`
-	Films     []*Film   `db:"films"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID        int        `db:"id"`
+	Name      string     `db:"name"`
+	Gender    string     `db:"gender"`
+	BirthDate time.Time  `db:"birth_date"`
+	Films     []*Film    `db:"films"`
+	Roles     []*Casting `db:"roles"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
 }
 
 func ActorStoreToDomain(storeActor *Actor) (*domain.Actor, error) {
@@ -21,7 +22,14 @@ func ActorStoreToDomain(storeActor *Actor) (*domain.Actor, error) {
 	for i, film := range storeActor.Films {
 		filmDomain[i], _ = FilmStoreToDomain(film)
 	}
-	return domain.NewActor(storeActor.ID, storeActor.Name, storeActor.Gender, storeActor.BirthDate, filmDomain)
+	actor, err := domain.NewActor(storeActor.ID, storeActor.Name, storeActor.Gender, storeActor.BirthDate, filmDomain)
+	if err != nil {
+		return nil, err
+	}
+	for _, casting := range storeActor.Roles {
+		actor.AddRole(CastingStoreToDomain(casting))
+	}
+	return actor, nil
 }
 
 func ActorDomainToStore(domainActor *domain.Actor) *Actor {
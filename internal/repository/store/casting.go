@@ -0,0 +1,22 @@
+package store
+
+import "github.com/Max425/film-library.git/internal/domain"
+
+// Casting in DB, a film_actor row
+type Casting struct {
+	FilmID   int    `db:"film_id"`
+	ActorID  int    `db:"actor_id"`
+	RoleName string `db:"role_name"`
+	Billing  *int   `db:"billing"`
+	RoleType string `db:"role_type"`
+}
+
+func CastingStoreToDomain(storeCasting *Casting) *domain.Casting {
+	return &domain.Casting{
+		FilmID:   storeCasting.FilmID,
+		ActorID:  storeCasting.ActorID,
+		RoleName: storeCasting.RoleName,
+		Billing:  storeCasting.Billing,
+		RoleType: domain.RoleType(storeCasting.RoleType),
+	}
+}
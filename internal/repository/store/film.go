@@ -7,14 +7,19 @@ import (
 
 // Film in DB
 type Film struct {
-	ID          int       `db:"id"`
-	Title       string    `db:"title"`
-	Description string    `db:"description"`
-	ReleaseDate time.Time `db:"release_date"`
-	Rating      float64   `db:"rating"`
-	Actors      []*Actor  `db:"actors"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID          int        `db:"id"`
+	Title       string     `db:"title"`
+	Description string     `db:"description"`
+	ReleaseDate time.Time  `db:"release_date"`
+	Rating      float64    `db:"rating"`
+	UserRating  float64    `db:"user_rating"`
+	VoteCount   int        `db:"vote_count"`
+	Actors      []*Actor   `db:"actors"`
+	Cast        []*Casting `db:"cast"`
+	Crew        []*Credit  `db:"crew"`
+	Genres      []*Genre   `db:"genres"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
 }
 
 func FilmStoreToDomain(storeFilm *Film) (*domain.Film, error) {
@@ -22,7 +27,23 @@ func FilmStoreToDomain(storeFilm *Film) (*domain.Film, error) {
 	for i, film := range storeFilm.Actors {
 		actorDomain[i], _ = ActorStoreToDomain(film)
 	}
-	return domain.NewFilm(storeFilm.ID, storeFilm.Title, storeFilm.Description, storeFilm.ReleaseDate, storeFilm.Rating, actorDomain)
+	film, err := domain.NewFilm(storeFilm.ID, storeFilm.Title, storeFilm.Description, storeFilm.ReleaseDate, storeFilm.Rating, actorDomain)
+	if err != nil {
+		return nil, err
+	}
+	film.SetUserRating(storeFilm.UserRating, storeFilm.VoteCount)
+	for _, casting := range storeFilm.Cast {
+		film.AddCasting(CastingStoreToDomain(casting))
+	}
+	for _, credit := range storeFilm.Crew {
+		film.AddCredit(CreditStoreToDomain(credit))
+	}
+	for _, storeGenre := range storeFilm.Genres {
+		if genre, err := GenreStoreToDomain(storeGenre); err == nil {
+			film.AddGenre(genre)
+		}
+	}
+	return film, nil
 }
 
 func FilmDomainToStore(domainFilm *domain.Film) *Film {
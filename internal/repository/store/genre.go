@@ -0,0 +1,31 @@
+package store
+
+import (
+	"github.com/Max425/film-library.git/internal/domain"
+	"time"
+)
+
+// Genre in DB
+type Genre struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// GenreCount in DB, a genre with the number of films in it
+type GenreCount struct {
+	Genre
+	Count int `db:"film_count"`
+}
+
+func GenreStoreToDomain(storeGenre *Genre) (*domain.Genre, error) {
+	return domain.NewGenre(storeGenre.ID, storeGenre.Name)
+}
+
+func GenreDomainToStore(domainGenre *domain.Genre) *Genre {
+	return &Genre{
+		ID:   domainGenre.GetId(),
+		Name: domainGenre.GetName(),
+	}
+}
@@ -0,0 +1,57 @@
+package store
+
+import (
+	"database/sql"
+	"github.com/Max425/film-library.git/internal/domain"
+	"time"
+)
+
+// FilmList in DB
+type FilmList struct {
+	ID         int            `db:"id"`
+	UserID     int            `db:"user_id"`
+	Name       string         `db:"name"`
+	ShareToken sql.NullString `db:"share_token"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+// WatchEntry in DB, a watched row with the title of its film
+type WatchEntry struct {
+	ID        int       `db:"id"`
+	UserID    int       `db:"user_id"`
+	FilmID    int       `db:"film_id"`
+	FilmTitle string    `db:"film_title"`
+	WatchedAt time.Time `db:"watched_at"`
+}
+
+func FilmListStoreToDomain(storeList *FilmList) (*domain.FilmList, error) {
+	list, err := domain.NewFilmList(storeList.ID, storeList.UserID, storeList.Name)
+	if err != nil {
+		return nil, err
+	}
+	list.SetShareToken(storeList.ShareToken.String)
+	return list, nil
+}
+
+func FilmListDomainToStore(domainList *domain.FilmList) *FilmList {
+	return &FilmList{
+		ID:     domainList.GetId(),
+		UserID: domainList.GetUserID(),
+		Name:   domainList.GetName(),
+		ShareToken: sql.NullString{
+			String: domainList.GetShareToken(),
+			Valid:  domainList.GetShareToken() != "",
+		},
+	}
+}
+
+func WatchEntryStoreToDomain(storeEntry *WatchEntry) *domain.WatchEntry {
+	return &domain.WatchEntry{
+		ID:        storeEntry.ID,
+		UserID:    storeEntry.UserID,
+		FilmID:    storeEntry.FilmID,
+		FilmTitle: storeEntry.FilmTitle,
+		WatchedAt: storeEntry.WatchedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"github.com/Max425/film-library.git/internal/domain"
+	"time"
+)
+
+// Person in DB
+type Person struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	Credits   []*Credit `db:"credits"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Credit in DB, a film_crew row with the names of its film and person
+type Credit struct {
+	FilmID     int    `db:"film_id"`
+	PersonID   int    `db:"person_id"`
+	Job        string `db:"job"`
+	FilmTitle  string `db:"film_title"`
+	PersonName string `db:"person_name"`
+}
+
+func PersonStoreToDomain(storePerson *Person) (*domain.Person, error) {
+	credits := make([]*domain.Credit, len(storePerson.Credits))
+	for i, credit := range storePerson.Credits {
+		credits[i] = CreditStoreToDomain(credit)
+	}
+	return domain.NewPerson(storePerson.ID, storePerson.Name, credits)
+}
+
+func PersonDomainToStore(domainPerson *domain.Person) *Person {
+	return &Person{
+		ID:   domainPerson.GetId(),
+		Name: domainPerson.GetName(),
+	}
+}
+
+func CreditStoreToDomain(storeCredit *Credit) *domain.Credit {
+	return &domain.Credit{
+		FilmID:     storeCredit.FilmID,
+		PersonID:   storeCredit.PersonID,
+		Job:        domain.Job(storeCredit.Job),
+		FilmTitle:  storeCredit.FilmTitle,
+		PersonName: storeCredit.PersonName,
+	}
+}
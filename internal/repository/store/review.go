@@ -0,0 +1,38 @@
+package store
+
+import (
+	"github.com/Max425/film-library.git/internal/domain"
+	"time"
+)
+
+// Review in DB
+type Review struct {
+	ID        int       `db:"id"`
+	FilmID    int       `db:"film_id"`
+	UserID    int       `db:"user_id"`
+	Rating    int       `db:"rating"`
+	Text      string    `db:"text"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func ReviewStoreToDomain(storeReview *Review) (*domain.Review, error) {
+	review, err := domain.NewReview(storeReview.ID, storeReview.FilmID, storeReview.UserID, storeReview.Rating, storeReview.Text)
+	if err != nil {
+		return nil, err
+	}
+	review.SetTimestamps(storeReview.CreatedAt, storeReview.UpdatedAt)
+	return review, nil
+}
+
+func ReviewDomainToStore(domainReview *domain.Review) *Review {
+	return &Review{
+		ID:        domainReview.GetId(),
+		FilmID:    domainReview.GetFilmID(),
+		UserID:    domainReview.GetUserID(),
+		Rating:    domainReview.GetRating(),
+		Text:      domainReview.GetText(),
+		CreatedAt: domainReview.GetCreatedAt(),
+		UpdatedAt: domainReview.GetUpdatedAt(),
+	}
+}
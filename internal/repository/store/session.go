@@ -0,0 +1,38 @@
+package store
+
+import (
+	"github.com/Max425/film-library.git/internal/domain"
+	"time"
+)
+
+// Session in Redis, kept as JSON under the session id
+type Session struct {
+	UserID    int       `json:"user_id"`
+	Role      int       `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	Family    string    `json:"family,omitempty"`
+}
+
+func SessionStoreToDomain(storeSession *Session) *domain.Session {
+	return &domain.Session{
+		UserID:    storeSession.UserID,
+		Role:      storeSession.Role,
+		CreatedAt: storeSession.CreatedAt,
+		UserAgent: storeSession.UserAgent,
+		IP:        storeSession.IP,
+		Family:    storeSession.Family,
+	}
+}
+
+func SessionDomainToStore(domainSession *domain.Session) *Session {
+	return &Session{
+		UserID:    domainSession.UserID,
+		Role:      domainSession.Role,
+		CreatedAt: domainSession.CreatedAt,
+		UserAgent: domainSession.UserAgent,
+		IP:        domainSession.IP,
+		Family:    domainSession.Family,
+	}
+}
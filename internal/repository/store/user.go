@@ -1,19 +1,22 @@
 package store
 
 import (
+	"database/sql"
 	"github.com/Max425/film-library.git/internal/domain"
 	"time"
 )
 
 type User struct {
-	ID           int       `db:"id"`
-	Name         string    `db:"name"`
-	Mail         string    `db:"mail"`
-	PasswordHash string    `db:"password_hash"`
-	Salt         string    `db:"salt"`
-	Role         int       `db:"role"`
-	CreatedAt    time.Time `db:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at"`
+	ID           int          `db:"id"`
+	Name         string       `db:"name"`
+	Mail         string       `db:"mail"`
+	PasswordHash string       `db:"password_hash"`
+	Salt         string       `db:"salt"`
+	Role         int          `db:"role"`
+	CreatedAt    time.Time    `db:"created_at"`
+	UpdatedAt    time.Time    `db:"updated_at"`
+	VerifiedAt   sql.NullTime `db:"verified_at"`
+	DisabledAt   sql.NullTime `db:"disabled_at"`
 }
 
 func UserDomainToStore(domainUser *domain.User) *User {
@@ -28,7 +31,7 @@ func UserDomainToStore(domainUser *domain.User) *User {
 }
 
 func UserStoreToDomain(storeUser *User) (*domain.User, error) {
-	return domain.NewUser(
+	user, err := domain.NewUser(
 		storeUser.ID,
 		storeUser.Name,
 		storeUser.Mail,
@@ -36,4 +39,15 @@ func UserStoreToDomain(storeUser *User) (*domain.User, error) {
 		storeUser.Salt,
 		storeUser.Role,
 	)
+	if err != nil {
+		return nil, err
+	}
+	user.SetCreatedAt(storeUser.CreatedAt)
+	if storeUser.VerifiedAt.Valid {
+		user.SetVerifiedAt(storeUser.VerifiedAt.Time)
+	}
+	if storeUser.DisabledAt.Valid {
+		user.SetDisabledAt(storeUser.DisabledAt.Time)
+	}
+	return user, nil
 }
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// withTx runs fn in a transaction that is committed when fn succeeds and
+// rolled back otherwise.
+func withTx(ctx context.Context, db *sqlx.DB, logger *zap.Logger, opts *sql.TxOptions, fn func(tx *sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, opts)
+	if err != nil {
+		logger.Error("Failed to begin transaction", zap.Error(err))
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("Failed to commit transaction", zap.Error(err))
+		return err
+	}
+	return nil
+}
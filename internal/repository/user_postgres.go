@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/repository/store"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
+	"strings"
 )
 
 type UserRepository struct {
@@ -25,8 +28,11 @@ func NewUserRepository(db *sqlx.DB, logger *zap.Logger) *UserRepository {
 func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) (int, error) {
 	storeUser := store.UserDomainToStore(user)
 	query := `INSERT INTO users (name, mail, password_hash, salt, role) VALUES ($1, $2, $3, $4, $5) RETURNING id`
-	err := r.db.QueryRowContext(ctx, query, storeUser.Name, storeUser.Mail, storeUser.PasswordHash, storeUser.Salt, storeUser.Role).Scan(&storeUser.ID)
+	err := r.db.QueryRowContext(ctx, query, storeUser.Name, domain.NormalizeMail(storeUser.Mail), storeUser.PasswordHash, storeUser.Salt, storeUser.Role).Scan(&storeUser.ID)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, fmt.Errorf("%w: user with this mail", domain.ErrAlreadyExists)
+		}
 		r.logger.Error("Failed to create user", zap.Error(err))
 		return 0, err
 	}
@@ -35,8 +41,8 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) (int
 
 func (r *UserRepository) GetUser(ctx context.Context, mail string) (*domain.User, error) {
 	storeUser := &store.User{}
-	query := `SELECT * FROM users WHERE mail = $1`
-	err := r.db.GetContext(ctx, storeUser, query, mail)
+	query := `SELECT * FROM users WHERE lower(mail) = $1`
+	err := r.db.GetContext(ctx, storeUser, query, domain.NormalizeMail(mail))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrNotFound
@@ -46,3 +52,187 @@ func (r *UserRepository) GetUser(ctx context.Context, mail string) (*domain.User
 	}
 	return store.UserStoreToDomain(storeUser)
 }
+
+func (r *UserRepository) GetUserByID(ctx context.Context, id int) (*domain.User, error) {
+	storeUser := &store.User{}
+	query := `SELECT * FROM users WHERE id = $1`
+	err := r.db.GetContext(ctx, storeUser, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		r.logger.Error("Failed to find user by ID", zap.Error(err))
+		return nil, err
+	}
+	return store.UserStoreToDomain(storeUser)
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, user *domain.User) error {
+	storeUser := store.UserDomainToStore(user)
+	query := `UPDATE users SET password_hash = $1, salt = $2, updated_at = timezone('europe/moscow'::text, now()) WHERE id = $3`
+	result, err := r.db.ExecContext(ctx, query, storeUser.PasswordHash, storeUser.Salt, storeUser.ID)
+	if err != nil {
+		r.logger.Error("Failed to update password", zap.Error(err))
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SetVerified marks the mail of the user as verified, a mail verified
+// before keeps its time.
+func (r *UserRepository) SetVerified(ctx context.Context, id int) error {
+	query := `UPDATE users SET verified_at = COALESCE(verified_at, timezone('europe/moscow'::text, now())) WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error("Failed to verify user mail", zap.Error(err))
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetUsers returns a page of the users matching the filter in the order they
+// signed up.
+func (r *UserRepository) GetUsers(ctx context.Context, filter domain.UserFilter, page domain.PageRequest) ([]*domain.User, *domain.PageInfo, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	keys := []sortKey{{name: "id", cast: "int"}}
+	cursorOf := func(user *domain.User) *domain.Cursor {
+		return &domain.Cursor{ID: user.ID()}
+	}
+
+	where, args := userFilterCondition(filter, "u", nil)
+	var total int
+	countQuery := fmt.Sprintf(`SELECT count(*) FROM users AS u WHERE %s`, where)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		r.logger.Error("Failed to count users", zap.Error(err))
+		return nil, nil, err
+	}
+
+	if page.Cursor != nil {
+		keyset, keysetArgs, err := keysetCondition(keys, "u", page.Cursor, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		where, args = where+" AND "+keyset, keysetArgs
+	}
+	backward := page.Cursor != nil && page.Cursor.Backward
+	limit, offset := limitOffset(page)
+	args = append(args, limit, offset)
+
+	query := fmt.Sprintf(`
+		SELECT u.*
+		FROM users AS u
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, where, orderBy(keys, "u", backward), len(args)-1, len(args))
+	var storeUsers []*store.User
+	if err := r.db.SelectContext(ctx, &storeUsers, query, args...); err != nil {
+		r.logger.Error("Failed to get users", zap.Error(err))
+		return nil, nil, err
+	}
+
+	users := make([]*domain.User, 0, len(storeUsers))
+	for _, storeUser := range storeUsers {
+		user, err := store.UserStoreToDomain(storeUser)
+		if err != nil {
+			r.logger.Error("Failed to convert user", zap.Error(err))
+			continue
+		}
+		users = append(users, user)
+	}
+
+	users, info := paginate(users, page, total, keys, cursorOf)
+	return users, info, nil
+}
+
+// userFilterCondition renders the filter as a condition on the users table
+// under alias. Filter values are appended to args as placeholders.
+func userFilterCondition(filter domain.UserFilter, alias string, args []any) (string, []any) {
+	conditions := []string{"TRUE"}
+	if filter.Search != "" {
+		args = append(args, "%"+likeEscaper.Replace(filter.Search)+"%")
+		conditions = append(conditions, fmt.Sprintf(`(%[1]s.name ILIKE $%[2]d ESCAPE '\' OR %[1]s.mail ILIKE $%[2]d ESCAPE '\')`, alias, len(args)))
+	}
+	if filter.Role != nil {
+		args = append(args, *filter.Role)
+		conditions = append(conditions, fmt.Sprintf("%s.role = $%d", alias, len(args)))
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id, role int) error {
+	query := `UPDATE users SET role = $1, updated_at = timezone('europe/moscow'::text, now()) WHERE id = $2`
+	result, err := r.db.ExecContext(ctx, query, role, id)
+	if err != nil {
+		r.logger.Error("Failed to update user role", zap.Error(err))
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SetDisabled disables or enables the user, a user disabled before keeps
+// the time it happened.
+func (r *UserRepository) SetDisabled(ctx context.Context, id int, disabled bool) error {
+	query := `UPDATE users SET disabled_at = NULL WHERE id = $1`
+	if disabled {
+		query = `UPDATE users SET disabled_at = COALESCE(disabled_at, timezone('europe/moscow'::text, now())) WHERE id = $1`
+	}
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		r.logger.Error("Failed to set user disabled", zap.Error(err))
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteUser removes the user with the reviews and the lists of the user and
+// recalculates the user rating of the films the user reviewed in one
+// transaction.
+func (r *UserRepository) DeleteUser(ctx context.Context, id int) error {
+	return withTx(ctx, r.db, r.logger, nil, func(tx *sqlx.Tx) error {
+		var filmIDs []int64
+		lockQuery := `SELECT id FROM film WHERE id IN (SELECT film_id FROM review WHERE user_id = $1) ORDER BY id FOR UPDATE`
+		if err := tx.SelectContext(ctx, &filmIDs, lockQuery, id); err != nil {
+			r.logger.Error("Failed to lock reviewed films", zap.Error(err))
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+		if err != nil {
+			r.logger.Error("Failed to delete user", zap.Error(err))
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return domain.ErrNotFound
+		}
+		if len(filmIDs) == 0 {
+			return nil
+		}
+
+		ratingQuery := `
+			UPDATE film SET (user_rating, vote_count) = (
+				SELECT COALESCE(round(avg(rating), 2), 0), count(*) FROM review WHERE film_id = film.id
+			)
+			WHERE id = ANY($1)
+		`
+		if _, err = tx.ExecContext(ctx, ratingQuery, pq.Array(filmIDs)); err != nil {
+			r.logger.Error("Failed to update film ratings", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+}
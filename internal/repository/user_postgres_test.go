@@ -2,9 +2,13 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/internal/repository/store"
+	"github.com/lib/pq"
 	"github.com/zhashkevych/go-sqlxmock"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -38,6 +42,24 @@ func TestUserRepository_CreateUser(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestUserRepository_CreateUser_MailTaken(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("INSERT INTO users").WillReturnError(&pq.Error{Code: uniqueViolation})
+
+	user, _ := domain.NewUser(0, "Test User", "test@example.com", "hashedPassword", "", 1)
+	_, err = r.CreateUser(context.Background(), user)
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	assert.EqualError(t, err, "already exists: user with this mail")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUserRepository_GetUser(t *testing.T) {
 	db, mock, err := sqlmock.Newx()
 	if err != nil {
@@ -65,3 +87,266 @@ func TestUserRepository_GetUser(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 }
+
+func TestUserRepository_MailCase(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db, zap.NewNop())
+
+	mock.ExpectQuery("INSERT INTO users").
+		WithArgs("Test User", "test@example.com", "hashedPassword", "", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE lower\\(mail\\) = \\$1").
+		WithArgs("test@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "mail", "password_hash", "salt", "role"}).
+			AddRow(1, "Test User", "test@example.com", "hashedPassword", "", 0))
+
+	user, _ := domain.NewUser(0, "Test User", "Test@Example.COM", "hashedPassword", "", 0)
+	_, err = r.CreateUser(context.Background(), user)
+	assert.NoError(t, err)
+	_, err = r.GetUser(context.Background(), "TEST@example.com")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_GetUserByID(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db, zap.NewNop())
+
+	createdAt := time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "mail", "password_hash", "salt", "role", "created_at"}).
+			AddRow(7, "Test User", "test@example.com", "hashedPassword", "randomSalt", 0, createdAt))
+	mock.ExpectQuery("SELECT (.+) FROM users WHERE id = \\$1").
+		WithArgs(8).
+		WillReturnError(sql.ErrNoRows)
+
+	result, err := r.GetUserByID(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "test@example.com", result.Mail())
+	assert.Equal(t, createdAt, result.CreatedAt())
+
+	_, err = r.GetUserByID(context.Background(), 8)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_UpdatePassword(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db, zap.NewNop())
+
+	user, _ := domain.NewUser(7, "Test User", "test@example.com", "newHash", "newSalt", 0)
+	mock.ExpectExec("UPDATE users SET password_hash = \\$1, salt = \\$2, (.+) WHERE id = \\$3").
+		WithArgs("newHash", "newSalt", 7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, r.UpdatePassword(context.Background(), user))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_SetVerified(t *testing.T) {
+	tests := []struct {
+		name          string
+		affected      int64
+		expectedError error
+	}{
+		{name: "Verified", affected: 1},
+		{name: "No such user", affected: 0, expectedError: domain.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewUserRepository(db, zap.NewNop())
+
+			mock.ExpectExec("UPDATE users SET verified_at = COALESCE\\(verified_at, (.+)\\) WHERE id = \\$1").
+				WithArgs(7).
+				WillReturnResult(sqlmock.NewResult(0, test.affected))
+
+			assert.Equal(t, test.expectedError, r.SetVerified(context.Background(), 7))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_GetUsers(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db, zap.NewNop())
+
+	role := 0
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM users AS u WHERE TRUE AND \\(u.name ILIKE \\$1 (.+) OR u.mail ILIKE \\$1 (.+)\\) AND u.role = \\$2").
+		WithArgs("%bob\\_%", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	columns := []string{"id", "name", "mail", "password_hash", "salt", "role", "created_at", "updated_at", "verified_at", "disabled_at"}
+	rows := sqlmock.NewRows(columns).
+		AddRow(2, "bob_1", "bob1@example.com", "hash", "", 0, time.Unix(0, 0), time.Unix(0, 0), nil, nil).
+		AddRow(5, "bob_2", "bob2@example.com", "hash", "", 0, time.Unix(0, 0), time.Unix(0, 0), time.Unix(0, 0), time.Unix(0, 0)).
+		AddRow(9, "bob_3", "bob3@example.com", "hash", "", 0, time.Unix(0, 0), time.Unix(0, 0), nil, nil)
+	mock.ExpectQuery("SELECT u.\\* FROM users AS u WHERE (.+) ORDER BY u.id ASC LIMIT \\$3 OFFSET \\$4").
+		WithArgs("%bob\\_%", 0, 3, 0).
+		WillReturnRows(rows)
+
+	users, info, err := r.GetUsers(context.Background(), domain.UserFilter{Search: "bob_", Role: &role}, domain.PageRequest{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.False(t, users[0].Disabled())
+	assert.True(t, users[1].Disabled())
+	assert.Equal(t, 3, info.Total)
+	assert.Equal(t, &domain.Cursor{Sort: "id:asc", ID: 5}, info.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_GetUsers_InvalidRole(t *testing.T) {
+	db, mock, err := sqlmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	r := NewUserRepository(db, zap.NewNop())
+
+	role := 7
+	_, _, err = r.GetUsers(context.Background(), domain.UserFilter{Role: &role}, domain.PageRequest{Limit: 2})
+	assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_UpdateRole(t *testing.T) {
+	tests := []struct {
+		name          string
+		affected      int64
+		expectedError error
+	}{
+		{name: "Updated", affected: 1},
+		{name: "No such user", affected: 0, expectedError: domain.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewUserRepository(db, zap.NewNop())
+
+			mock.ExpectExec("UPDATE users SET role = \\$1, (.+) WHERE id = \\$2").
+				WithArgs(2, 7).
+				WillReturnResult(sqlmock.NewResult(0, test.affected))
+
+			assert.Equal(t, test.expectedError, r.UpdateRole(context.Background(), 7, 2))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_SetDisabled(t *testing.T) {
+	tests := []struct {
+		name          string
+		disabled      bool
+		query         string
+		affected      int64
+		expectedError error
+	}{
+		{name: "Disabled", disabled: true, query: "UPDATE users SET disabled_at = COALESCE\\(disabled_at, (.+)\\) WHERE id = \\$1", affected: 1},
+		{name: "Enabled", query: "UPDATE users SET disabled_at = NULL WHERE id = \\$1", affected: 1},
+		{name: "No such user", disabled: true, query: "UPDATE users SET disabled_at", expectedError: domain.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewUserRepository(db, zap.NewNop())
+
+			mock.ExpectExec(test.query).
+				WithArgs(7).
+				WillReturnResult(sqlmock.NewResult(0, test.affected))
+
+			assert.Equal(t, test.expectedError, r.SetDisabled(context.Background(), 7, test.disabled))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserRepository_DeleteUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		filmIDs       []int64
+		affected      int64
+		expectedError error
+	}{
+		{name: "With reviews", filmIDs: []int64{1, 4}, affected: 1},
+		{name: "Without reviews", affected: 1},
+		{name: "No such user", affected: 0, expectedError: domain.ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.Newx()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			r := NewUserRepository(db, zap.NewNop())
+
+			rows := sqlmock.NewRows([]string{"id"})
+			for _, id := range test.filmIDs {
+				rows.AddRow(id)
+			}
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT id FROM film WHERE id IN \\(SELECT film_id FROM review WHERE user_id = \\$1\\) ORDER BY id FOR UPDATE").
+				WithArgs(7).
+				WillReturnRows(rows)
+			mock.ExpectExec("DELETE FROM users WHERE id = \\$1").
+				WithArgs(7).
+				WillReturnResult(sqlmock.NewResult(0, test.affected))
+			switch {
+			case test.expectedError != nil:
+				mock.ExpectRollback()
+			case len(test.filmIDs) > 0:
+				mock.ExpectExec("UPDATE film SET \\(user_rating, vote_count\\) = (.+) WHERE id = ANY\\(\\$1\\)").
+					WithArgs(pq.Array(test.filmIDs)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			default:
+				mock.ExpectCommit()
+			}
+
+			assert.Equal(t, test.expectedError, r.DeleteUser(context.Background(), 7))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
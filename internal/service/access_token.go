@@ -0,0 +1,179 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AccessKey signs or verifies access tokens, the id goes into the kid header.
+type AccessKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+}
+
+// ParseAccessKey makes a key of the algorithm, HS256 with the secret of at
+// least 32 bytes or EdDSA with Ed25519 keys in PEM. An EdDSA key given the
+// public key alone only verifies tokens.
+func ParseAccessKey(id, algorithm, secret, privateKeyPEM, publicKeyPEM string) (AccessKey, error) {
+	if id == "" {
+		return AccessKey{}, errors.New("access key id is required")
+	}
+	switch algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		if len(secret) < 32 {
+			return AccessKey{}, fmt.Errorf("access key %q: HS256 secret must be at least 32 bytes", id)
+		}
+		return AccessKey{id: id, method: jwt.SigningMethodHS256, signKey: []byte(secret), verifyKey: []byte(secret)}, nil
+	case jwt.SigningMethodEdDSA.Alg():
+		key := AccessKey{id: id, method: jwt.SigningMethodEdDSA}
+		if privateKeyPEM != "" {
+			private, err := jwt.ParseEdPrivateKeyFromPEM([]byte(privateKeyPEM))
+			if err != nil {
+				return AccessKey{}, fmt.Errorf("access key %q: %w", id, err)
+			}
+			edPrivate, ok := private.(ed25519.PrivateKey)
+			if !ok {
+				return AccessKey{}, fmt.Errorf("access key %q: not an Ed25519 private key", id)
+			}
+			key.signKey = edPrivate
+			key.verifyKey = edPrivate.Public()
+		}
+		if publicKeyPEM != "" {
+			public, err := jwt.ParseEdPublicKeyFromPEM([]byte(publicKeyPEM))
+			if err != nil {
+				return AccessKey{}, fmt.Errorf("access key %q: %w", id, err)
+			}
+			key.verifyKey = public
+		}
+		if key.verifyKey == nil {
+			return AccessKey{}, fmt.Errorf("access key %q: EdDSA needs a private or a public key", id)
+		}
+		return key, nil
+	default:
+		return AccessKey{}, fmt.Errorf("access key %q: unknown algorithm %q, HS256 or EdDSA", id, algorithm)
+	}
+}
+
+// accessClaims are the claims of an access token, the subject is the user id.
+type accessClaims struct {
+	Role int `json:"role"`
+	// SessionID is the public id of the refresh session the token was issued for.
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// AccessTokens issues access tokens signed with the signing key and checks
+// tokens signed with any of the keys, so a key can be rotated out while
+// the tokens it signed are still alive.
+type AccessTokens struct {
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	signing    AccessKey
+	keys       map[string]AccessKey
+}
+
+// NewAccessTokens returns the tokens signed with the key of the signingKey
+// id, access tokens work for 15 minutes and refresh tokens for 30 days by
+// default.
+func NewAccessTokens(issuer string, accessTTL, refreshTTL time.Duration, signingKey string, keys ...AccessKey) (*AccessTokens, error) {
+	if accessTTL <= 0 {
+		accessTTL = 15 * time.Minute
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = 30 * 24 * time.Hour
+	}
+	t := &AccessTokens{issuer: issuer, accessTTL: accessTTL, refreshTTL: refreshTTL, keys: make(map[string]AccessKey, len(keys))}
+	for _, key := range keys {
+		if _, ok := t.keys[key.id]; ok {
+			return nil, fmt.Errorf("access key %q is given twice", key.id)
+		}
+		t.keys[key.id] = key
+	}
+	signing, ok := t.keys[signingKey]
+	if !ok {
+		return nil, fmt.Errorf("signing key %q is not among the access keys", signingKey)
+	}
+	if signing.signKey == nil {
+		return nil, fmt.Errorf("signing key %q has no private key", signingKey)
+	}
+	t.signing = signing
+	return t, nil
+}
+
+// Issue signs an access token for the session and returns it with the time it expires.
+func (t *AccessTokens) Issue(session *domain.Session, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(t.accessTTL)
+	claims := accessClaims{
+		Role:      session.Role,
+		SessionID: session.ID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    t.issuer,
+			Subject:   strconv.Itoa(session.UserID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token := jwt.NewWithClaims(t.signing.method, claims)
+	token.Header["kid"] = t.signing.id
+	signed, err := token.SignedString(t.signing.signKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// Parse checks the signature, the issuer and the expiry of the access token
+// and returns the session it stands for. The algorithm is taken from the
+// key the kid names, never from the token alone.
+func (t *AccessTokens) Parse(token string) (*domain.Session, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := t.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("key %q does not sign with %s", kid, token.Method.Alg())
+		}
+		return key.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(t.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidToken, err)
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: subject is not a user id", domain.ErrInvalidToken)
+	}
+	return &domain.Session{
+		ID:        claims.SessionID,
+		UserID:    userID,
+		Role:      claims.Role,
+		CreatedAt: claims.IssuedAt.Time,
+	}, nil
+}
+
+// AccessTTL is how long an access token works.
+func (t *AccessTokens) AccessTTL() time.Duration {
+	return t.accessTTL
+}
+
+// RefreshTTL is how long a refresh token works.
+func (t *AccessTokens) RefreshTTL() time.Duration {
+	return t.refreshTTL
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func ed25519PEM(t *testing.T) (string, string) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+}
+
+func TestParseAccessKey(t *testing.T) {
+	privatePEM, publicPEM := ed25519PEM(t)
+
+	tests := []struct {
+		name       string
+		id         string
+		algorithm  string
+		secret     string
+		private    string
+		public     string
+		canSign    bool
+		expectsErr bool
+	}{
+		{name: "HS256", id: "k1", algorithm: "HS256", secret: testSecret, canSign: true},
+		{name: "HS256 short secret", id: "k1", algorithm: "HS256", secret: "short", expectsErr: true},
+		{name: "EdDSA private key", id: "k2", algorithm: "EdDSA", private: privatePEM, canSign: true},
+		{name: "EdDSA public key only", id: "k2", algorithm: "EdDSA", public: publicPEM},
+		{name: "EdDSA no keys", id: "k2", algorithm: "EdDSA", expectsErr: true},
+		{name: "EdDSA broken key", id: "k2", algorithm: "EdDSA", private: "not a key", expectsErr: true},
+		{name: "Unknown algorithm", id: "k3", algorithm: "RS256", secret: testSecret, expectsErr: true},
+		{name: "No id", algorithm: "HS256", secret: testSecret, expectsErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := ParseAccessKey(test.id, test.algorithm, test.secret, test.private, test.public)
+			if test.expectsErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.canSign, key.signKey != nil)
+		})
+	}
+}
+
+func TestAccessTokens_IssueParse(t *testing.T) {
+	privatePEM, _ := ed25519PEM(t)
+	hsKey, err := ParseAccessKey("hs", "HS256", testSecret, "", "")
+	require.NoError(t, err)
+	edKey, err := ParseAccessKey("ed", "EdDSA", "", privatePEM, "")
+	require.NoError(t, err)
+
+	for _, signingKey := range []string{"hs", "ed"} {
+		t.Run(signingKey, func(t *testing.T) {
+			tokens, err := NewAccessTokens("film-library", time.Minute, time.Hour, signingKey, hsKey, edKey)
+			require.NoError(t, err)
+
+			now := time.Now().Truncate(time.Second)
+			token, expiresAt, err := tokens.Issue(&domain.Session{ID: "abc", UserID: 7, Role: 2}, now)
+			require.NoError(t, err)
+			assert.Equal(t, now.Add(time.Minute), expiresAt)
+
+			session, err := tokens.Parse(token)
+			require.NoError(t, err)
+			assert.Equal(t, "abc", session.ID)
+			assert.Equal(t, 7, session.UserID)
+			assert.Equal(t, 2, session.Role)
+			assert.True(t, now.Equal(session.CreatedAt))
+		})
+	}
+}
+
+func TestAccessTokens_Rotation(t *testing.T) {
+	oldKey, _ := ParseAccessKey("old", "HS256", testSecret, "", "")
+	newKey, _ := ParseAccessKey("new", "HS256", "fedcba9876543210fedcba9876543210", "", "")
+
+	before, err := NewAccessTokens("film-library", time.Minute, time.Hour, "old", oldKey)
+	require.NoError(t, err)
+	token, _, err := before.Issue(&domain.Session{UserID: 7}, time.Now())
+	require.NoError(t, err)
+
+	rotated, err := NewAccessTokens("film-library", time.Minute, time.Hour, "new", oldKey, newKey)
+	require.NoError(t, err)
+	_, err = rotated.Parse(token)
+	assert.NoError(t, err)
+
+	retired, err := NewAccessTokens("film-library", time.Minute, time.Hour, "new", newKey)
+	require.NoError(t, err)
+	_, err = retired.Parse(token)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+}
+
+func TestAccessTokens_Parse_Invalid(t *testing.T) {
+	_, publicPEM := ed25519PEM(t)
+	hsKey, _ := ParseAccessKey("hs", "HS256", testSecret, "", "")
+	edKey, _ := ParseAccessKey("ed", "EdDSA", "", "", publicPEM)
+	tokens, err := NewAccessTokens("film-library", time.Minute, time.Hour, "hs", hsKey, edKey)
+	require.NoError(t, err)
+
+	sign := func(kid string, claims jwt.RegisteredClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{RegisteredClaims: claims})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString([]byte(testSecret))
+		require.NoError(t, err)
+		return signed
+	}
+	now := time.Now()
+	valid := jwt.RegisteredClaims{
+		Issuer:    "film-library",
+		Subject:   "7",
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+	}
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+	otherIssuer := valid
+	otherIssuer.Issuer = "someone-else"
+	noExpiry := valid
+	noExpiry.ExpiresAt = nil
+	noUser := valid
+	noUser.Subject = "bob"
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "Unknown key", token: sign("gone", valid)},
+		{name: "No key", token: sign("", valid)},
+		{name: "Algorithm of another key", token: sign("ed", valid)},
+		{name: "Expired", token: sign("hs", expired)},
+		{name: "Other issuer", token: sign("hs", otherIssuer)},
+		{name: "No expiry", token: sign("hs", noExpiry)},
+		{name: "Subject not a user", token: sign("hs", noUser)},
+		{name: "Garbage", token: "not.a.token"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			session, err := tokens.Parse(test.token)
+			assert.Nil(t, session)
+			assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		})
+	}
+}
+
+func TestNewAccessTokens(t *testing.T) {
+	_, publicPEM := ed25519PEM(t)
+	hsKey, _ := ParseAccessKey("hs", "HS256", testSecret, "", "")
+	verifyOnly, _ := ParseAccessKey("ed", "EdDSA", "", "", publicPEM)
+
+	_, err := NewAccessTokens("film-library", 0, 0, "missing", hsKey)
+	assert.Error(t, err)
+	_, err = NewAccessTokens("film-library", 0, 0, "ed", hsKey, verifyOnly)
+	assert.Error(t, err)
+	_, err = NewAccessTokens("film-library", 0, 0, "hs", hsKey, hsKey)
+	assert.Error(t, err)
+
+	tokens, err := NewAccessTokens("film-library", 0, 0, "hs", hsKey)
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, tokens.AccessTTL())
+	assert.Equal(t, 30*24*time.Hour, tokens.RefreshTTL())
+}
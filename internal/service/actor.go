@@ -11,16 +11,23 @@ type ActorRepository interface {
 	FindActorByID(ctx context.Context, id int) (*domain.Actor, error)
 	UpdateActor(ctx context.Context, actor *domain.Actor) (*domain.Actor, error)
 	DeleteActor(ctx context.Context, id int) error
-	GetAllActors(ctx context.Context) ([]*domain.Actor, error)
+	GetAllActors(ctx context.Context, sort []domain.ActorSort, filter domain.ActorFilter, page domain.PageRequest) ([]*domain.Actor, *domain.PageInfo, error)
+}
+
+// CastRepository changes single film_actor links.
+type CastRepository interface {
+	AddFilmActor(ctx context.Context, casting domain.Casting) error
+	RemoveFilmActor(ctx context.Context, filmID, actorID int) error
 }
 
 type ActorService struct {
 	log       *zap.Logger
 	actorRepo ActorRepository
+	castRepo  CastRepository
 }
 
-func NewActorService(actorRepo ActorRepository, log *zap.Logger) *ActorService {
-	return &ActorService{actorRepo: actorRepo, log: log}
+func NewActorService(actorRepo ActorRepository, castRepo CastRepository, log *zap.Logger) *ActorService {
+	return &ActorService{actorRepo: actorRepo, castRepo: castRepo, log: log}
 }
 
 func (s *ActorService) CreateActor(ctx context.Context, actor *domain.Actor) (*domain.Actor, error) {
@@ -49,6 +56,32 @@ func (s *ActorService) DeleteActor(ctx context.Context, id int) error {
 	return s.actorRepo.DeleteActor(ctx, id)
 }
 
-func (s *ActorService) GetAllActors(ctx context.Context) ([]*domain.Actor, error) {
-	return s.actorRepo.GetAllActors(ctx)
+func (s *ActorService) GetAllActors(ctx context.Context, sort []domain.ActorSort, filter domain.ActorFilter, page domain.PageRequest) ([]*domain.Actor, *domain.PageInfo, error) {
+	if err := domain.ValidateActorSort(sort); err != nil {
+		return nil, nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return s.actorRepo.GetAllActors(ctx, sort, filter, page)
+}
+
+func (s *ActorService) AddActorFilm(ctx context.Context, casting domain.Casting) (*domain.Actor, error) {
+	if err := casting.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.castRepo.AddFilmActor(ctx, casting); err != nil {
+		return nil, err
+	}
+
+	return s.actorRepo.FindActorByID(ctx, casting.ActorID)
+}
+
+func (s *ActorService) RemoveActorFilm(ctx context.Context, actorID, filmID int) (*domain.Actor, error) {
+	if err := s.castRepo.RemoveFilmActor(ctx, filmID, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.actorRepo.FindActorByID(ctx, actorID)
 }
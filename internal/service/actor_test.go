@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/mocks/db"
 	"github.com/golang/mock/gomock"
@@ -49,7 +50,7 @@ func TestActorService_CreateActor(t *testing.T) {
 			repo := mock_service.NewMockActorRepository(ctrl)
 			test.mockBehavior(repo)
 
-			service := NewActorService(repo, nil)
+			service := NewActorService(repo, nil, nil)
 			actor, err := service.CreateActor(context.Background(), test.actor)
 
 			assert.Equal(t, test.expectedActor, actor)
@@ -97,7 +98,7 @@ func TestActorService_GetActorByID(t *testing.T) {
 			repo := mock_service.NewMockActorRepository(ctrl)
 			test.mockBehavior(repo)
 
-			service := NewActorService(repo, nil)
+			service := NewActorService(repo, nil, nil)
 			actor, err := service.GetActorByID(context.Background(), test.actorID)
 
 			assert.Equal(t, test.expectedActor, actor)
@@ -155,7 +156,7 @@ func TestActorService_UpdateActor(t *testing.T) {
 			repo := mock_service.NewMockActorRepository(ctrl)
 			test.mockBehavior(repo)
 
-			service := NewActorService(repo, nil)
+			service := NewActorService(repo, nil, nil)
 			actor, err := service.UpdateActor(context.Background(), test.actor)
 
 			assert.Equal(t, test.expectedActor, actor)
@@ -209,7 +210,7 @@ func TestActorService_DeleteActor(t *testing.T) {
 			repo := mock_service.NewMockActorRepository(ctrl)
 			test.mockBehavior(repo)
 
-			service := NewActorService(repo, nil)
+			service := NewActorService(repo, nil, nil)
 			err := service.DeleteActor(context.Background(), test.actorID)
 
 			assert.Equal(t, test.expectedError, err)
@@ -222,9 +223,12 @@ func TestActorService_GetAllActors(t *testing.T) {
 	mockActor2, _ := domain.NewActor(2, "test", "male", time.Unix(0, 0), nil)
 	mockActor3, _ := domain.NewActor(3, "test", "male", time.Unix(0, 0), nil)
 	mockActors := []*domain.Actor{mockActor1, mockActor2, mockActor3}
+	page := domain.PageRequest{Limit: 20}
 
 	tests := []struct {
 		name           string
+		sort           []domain.ActorSort
+		filter         domain.ActorFilter
 		mockBehavior   func(r *mock_service.MockActorRepository)
 		expectedActors []*domain.Actor
 		expectedError  error
@@ -232,7 +236,7 @@ func TestActorService_GetAllActors(t *testing.T) {
 		{
 			name: "Success",
 			mockBehavior: func(r *mock_service.MockActorRepository) {
-				r.EXPECT().GetAllActors(gomock.Any()).Return(mockActors, nil)
+				r.EXPECT().GetAllActors(gomock.Any(), nil, domain.ActorFilter{}, page).Return(mockActors, &domain.PageInfo{Total: 3, Limit: 20}, nil)
 			},
 			expectedActors: mockActors,
 			expectedError:  nil,
@@ -240,11 +244,36 @@ func TestActorService_GetAllActors(t *testing.T) {
 		{
 			name: "Error Getting Actors",
 			mockBehavior: func(r *mock_service.MockActorRepository) {
-				r.EXPECT().GetAllActors(gomock.Any()).Return(nil, errors.New("get actors error"))
+				r.EXPECT().GetAllActors(gomock.Any(), nil, domain.ActorFilter{}, page).Return(nil, nil, errors.New("get actors error"))
 			},
 			expectedActors: nil,
 			expectedError:  errors.New("get actors error"),
 		},
+		{
+			name:   "Search And Sort",
+			sort:   []domain.ActorSort{{Field: domain.ActorSortFilmCount, Desc: true}},
+			filter: domain.ActorFilter{Name: "te", Gender: "male"},
+			mockBehavior: func(r *mock_service.MockActorRepository) {
+				sort := []domain.ActorSort{{Field: domain.ActorSortFilmCount, Desc: true}}
+				r.EXPECT().GetAllActors(gomock.Any(), sort, domain.ActorFilter{Name: "te", Gender: "male"}, page).Return(mockActors, &domain.PageInfo{Total: 3, Limit: 20}, nil)
+			},
+			expectedActors: mockActors,
+			expectedError:  nil,
+		},
+		{
+			name:           "Invalid Sort",
+			sort:           []domain.ActorSort{{Field: "rating"}},
+			mockBehavior:   func(r *mock_service.MockActorRepository) {},
+			expectedActors: nil,
+			expectedError:  fmt.Errorf("%w: unknown field %q", domain.ErrInvalidSort, "rating"),
+		},
+		{
+			name:           "Invalid Filter",
+			filter:         domain.ActorFilter{Gender: "unknown"},
+			mockBehavior:   func(r *mock_service.MockActorRepository) {},
+			expectedActors: nil,
+			expectedError:  fmt.Errorf("%w: gender must be male/female/other", domain.ErrInvalidFilter),
+		},
 	}
 
 	for _, test := range tests {
@@ -255,11 +284,87 @@ func TestActorService_GetAllActors(t *testing.T) {
 			repo := mock_service.NewMockActorRepository(ctrl)
 			test.mockBehavior(repo)
 
-			service := NewActorService(repo, nil)
-			actors, err := service.GetAllActors(context.Background())
+			service := NewActorService(repo, nil, nil)
+			actors, _, err := service.GetAllActors(context.Background(), test.sort, test.filter, page)
 
 			assert.Equal(t, test.expectedActors, actors)
 			assert.Equal(t, test.expectedError, err)
 		})
 	}
 }
+
+func TestActorService_AddActorFilm(t *testing.T) {
+	mockActor, _ := domain.NewActor(1, "Bob", "male", time.Now(), nil)
+
+	tests := []struct {
+		name          string
+		casting       domain.Casting
+		mockBehavior  func(a *mock_service.MockActorRepository, c *mock_service.MockCastRepository)
+		expectedActor *domain.Actor
+		expectedError string
+	}{
+		{
+			name:    "Success",
+			casting: domain.Casting{FilmID: 2, ActorID: 1},
+			mockBehavior: func(a *mock_service.MockActorRepository, c *mock_service.MockCastRepository) {
+				c.EXPECT().AddFilmActor(gomock.Any(), domain.Casting{FilmID: 2, ActorID: 1}).Return(nil)
+				a.EXPECT().FindActorByID(gomock.Any(), 1).Return(mockActor, nil)
+			},
+			expectedActor: mockActor,
+		},
+		{
+			name:    "Film Not Found",
+			casting: domain.Casting{FilmID: 2, ActorID: 1},
+			mockBehavior: func(a *mock_service.MockActorRepository, c *mock_service.MockCastRepository) {
+				c.EXPECT().AddFilmActor(gomock.Any(), domain.Casting{FilmID: 2, ActorID: 1}).Return(domain.ErrNotFound)
+			},
+			expectedError: "not found",
+		},
+		{
+			name:          "Invalid Role Type",
+			casting:       domain.Casting{FilmID: 2, ActorID: 1, RoleType: "stunt"},
+			mockBehavior:  func(a *mock_service.MockActorRepository, c *mock_service.MockCastRepository) {},
+			expectedError: "invalid casting: role type must be regular/cameo/voice",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			actorRepo := mock_service.NewMockActorRepository(ctrl)
+			castRepo := mock_service.NewMockCastRepository(ctrl)
+			test.mockBehavior(actorRepo, castRepo)
+
+			service := NewActorService(actorRepo, castRepo, nil)
+			actor, err := service.AddActorFilm(context.Background(), test.casting)
+
+			assert.Equal(t, test.expectedActor, actor)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestActorService_RemoveActorFilm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockActor, _ := domain.NewActor(1, "Bob", "male", time.Now(), nil)
+	actorRepo := mock_service.NewMockActorRepository(ctrl)
+	castRepo := mock_service.NewMockCastRepository(ctrl)
+	gomock.InOrder(
+		castRepo.EXPECT().RemoveFilmActor(gomock.Any(), 2, 1).Return(nil),
+		actorRepo.EXPECT().FindActorByID(gomock.Any(), 1).Return(mockActor, nil),
+	)
+
+	service := NewActorService(actorRepo, castRepo, nil)
+	actor, err := service.RemoveActorFilm(context.Background(), 1, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockActor, actor)
+}
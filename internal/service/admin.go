@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/domain"
+	"go.uber.org/zap"
+)
+
+type AdminRepository interface {
+	GetUsers(ctx context.Context, filter domain.UserFilter, page domain.PageRequest) ([]*domain.User, *domain.PageInfo, error)
+	GetUserByID(ctx context.Context, id int) (*domain.User, error)
+	UpdateRole(ctx context.Context, id, role int) error
+	SetDisabled(ctx context.Context, id int, disabled bool) error
+	DeleteUser(ctx context.Context, id int) error
+}
+
+// AdminService manages the accounts of users on behalf of admins. Every
+// change is logged with the admin who made it.
+type AdminService struct {
+	log       *zap.Logger
+	userRepo  AdminRepository
+	storeRepo StoreRepository
+}
+
+func NewAdminService(userRepo AdminRepository, storeRepo StoreRepository, log *zap.Logger) *AdminService {
+	return &AdminService{log: log, userRepo: userRepo, storeRepo: storeRepo}
+}
+
+// GetUsers returns a page of the users matching the filter without passwords.
+func (s *AdminService) GetUsers(ctx context.Context, filter domain.UserFilter, page domain.PageRequest) ([]*domain.User, *domain.PageInfo, error) {
+	users, info, err := s.userRepo.GetUsers(ctx, filter, page)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, user := range users {
+		user.Sanitize()
+	}
+	return users, info, nil
+}
+
+// GetUserAccount returns the user without the password.
+func (s *AdminService) GetUserAccount(ctx context.Context, id int) (*domain.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	user.Sanitize()
+	return user, nil
+}
+
+// ChangeUserRole gives the user the role. Sessions take the role of the user
+// when they are checked, so the change applies to signed in users at once.
+// Admins can not change their own role, so the last admin is never demoted.
+func (s *AdminService) ChangeUserRole(ctx context.Context, admin *domain.Session, id, role int) (*domain.User, error) {
+	if !domain.ValidRole(role) {
+		return nil, fmt.Errorf("%w: %d", domain.ErrInvalidRole, role)
+	}
+	if admin.UserID == id {
+		return nil, fmt.Errorf("%w: admins can not change their own role", domain.ErrForbidden)
+	}
+	if err := s.userRepo.UpdateRole(ctx, id, role); err != nil {
+		return nil, err
+	}
+	s.audit("user_role_changed", admin, id, zap.Int("role", role))
+	return s.GetUserAccount(ctx, id)
+}
+
+// DisableUser keeps the user from signing in and ends every session of the
+// user. Sessions left behind when they fail to be deleted are refused anyway,
+// as the user is disabled.
+func (s *AdminService) DisableUser(ctx context.Context, admin *domain.Session, id int) (*domain.User, error) {
+	if admin.UserID == id {
+		return nil, fmt.Errorf("%w: admins can not disable their own account", domain.ErrForbidden)
+	}
+	if err := s.userRepo.SetDisabled(ctx, id, true); err != nil {
+		return nil, err
+	}
+	s.audit("user_disabled", admin, id)
+	if err := s.storeRepo.DeleteUserSessions(ctx, id); err != nil {
+		s.log.Error("Failed to delete sessions of disabled user", zap.Int("user", id), zap.Error(err))
+	}
+	return s.GetUserAccount(ctx, id)
+}
+
+// EnableUser lets a disabled user sign in again.
+func (s *AdminService) EnableUser(ctx context.Context, admin *domain.Session, id int) (*domain.User, error) {
+	if err := s.userRepo.SetDisabled(ctx, id, false); err != nil {
+		return nil, err
+	}
+	s.audit("user_enabled", admin, id)
+	return s.GetUserAccount(ctx, id)
+}
+
+// LogoutUser ends every session of the user.
+func (s *AdminService) LogoutUser(ctx context.Context, admin *domain.Session, id int) error {
+	if _, err := s.userRepo.GetUserByID(ctx, id); err != nil {
+		return err
+	}
+	if err := s.storeRepo.DeleteUserSessions(ctx, id); err != nil {
+		return err
+	}
+	s.audit("user_logged_out", admin, id)
+	return nil
+}
+
+// DeleteUser removes the user along with the reviews and the lists of the
+// user and ends every session of the user. Admins can not delete themselves.
+func (s *AdminService) DeleteUser(ctx context.Context, admin *domain.Session, id int) error {
+	if admin.UserID == id {
+		return fmt.Errorf("%w: admins can not delete their own account", domain.ErrForbidden)
+	}
+	if err := s.userRepo.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	s.audit("user_deleted", admin, id)
+	if err := s.storeRepo.DeleteUserSessions(ctx, id); err != nil {
+		s.log.Error("Failed to delete sessions of deleted user", zap.Int("user", id), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *AdminService) audit(event string, admin *domain.Session, id int, fields ...zap.Field) {
+	s.log.Info("User changed by admin", append([]zap.Field{
+		zap.String("event", event),
+		zap.Int("admin", admin.UserID),
+		zap.Int("user", id),
+	}, fields...)...)
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
+	mock_service "github.com/Max425/film-library.git/mocks/db"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestAdminService_GetUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := mock_service.NewMockAdminRepository(ctrl)
+	user, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "salt", constants.UserRole)
+	filter := domain.UserFilter{Search: "bob"}
+	page := domain.PageRequest{Limit: 20}
+	userRepo.EXPECT().GetUsers(gomock.Any(), filter, page).Return([]*domain.User{user}, &domain.PageInfo{Total: 1, Limit: 20}, nil)
+
+	adminService := NewAdminService(userRepo, nil, zap.NewNop())
+	users, info, err := adminService.GetUsers(context.Background(), filter, page)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, info.Total)
+	assert.Equal(t, "", users[0].Password())
+	assert.Equal(t, "", users[0].Salt())
+}
+
+func TestAdminService_ChangeUserRole(t *testing.T) {
+	admin := &domain.Session{UserID: 1, Role: constants.AdminRole}
+	editor, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.EditorRole)
+
+	tests := []struct {
+		name          string
+		mockBehavior  func(r *mock_service.MockAdminRepository)
+		id            int
+		role          int
+		expectedUser  *domain.User
+		expectedError error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r *mock_service.MockAdminRepository) {
+				r.EXPECT().UpdateRole(gomock.Any(), 7, constants.EditorRole).Return(nil)
+				r.EXPECT().GetUserByID(gomock.Any(), 7).Return(editor, nil)
+			},
+			id:           7,
+			role:         constants.EditorRole,
+			expectedUser: editor,
+		},
+		{
+			name:          "Unknown role",
+			mockBehavior:  func(r *mock_service.MockAdminRepository) {},
+			id:            7,
+			role:          9,
+			expectedError: domain.ErrInvalidRole,
+		},
+		{
+			name:          "Own role",
+			mockBehavior:  func(r *mock_service.MockAdminRepository) {},
+			id:            1,
+			role:          constants.UserRole,
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name: "No such user",
+			mockBehavior: func(r *mock_service.MockAdminRepository) {
+				r.EXPECT().UpdateRole(gomock.Any(), 8, constants.EditorRole).Return(domain.ErrNotFound)
+			},
+			id:            8,
+			role:          constants.EditorRole,
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockAdminRepository(ctrl)
+			test.mockBehavior(userRepo)
+
+			adminService := NewAdminService(userRepo, nil, zap.NewNop())
+			user, err := adminService.ChangeUserRole(context.Background(), admin, test.id, test.role)
+
+			assert.ErrorIs(t, err, test.expectedError)
+			assert.Equal(t, test.expectedUser, user)
+		})
+	}
+}
+
+func TestAdminService_DisableUser(t *testing.T) {
+	admin := &domain.Session{UserID: 1, Role: constants.AdminRole}
+	disabled, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.UserRole)
+	disabled.SetDisabledAt(time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name          string
+		mockBehavior  func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository)
+		id            int
+		expectedError error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {
+				u.EXPECT().SetDisabled(gomock.Any(), 7, true).Return(nil)
+				s.EXPECT().DeleteUserSessions(gomock.Any(), 7).Return(nil)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(disabled, nil)
+			},
+			id: 7,
+		},
+		{
+			name:          "Own account",
+			mockBehavior:  func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {},
+			id:            1,
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name: "No such user",
+			mockBehavior: func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {
+				u.EXPECT().SetDisabled(gomock.Any(), 8, true).Return(domain.ErrNotFound)
+			},
+			id:            8,
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name: "Sessions not deleted",
+			mockBehavior: func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {
+				u.EXPECT().SetDisabled(gomock.Any(), 7, true).Return(nil)
+				s.EXPECT().DeleteUserSessions(gomock.Any(), 7).Return(errors.New("redis down"))
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(disabled, nil)
+			},
+			id: 7,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockAdminRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			test.mockBehavior(userRepo, storeRepo)
+
+			adminService := NewAdminService(userRepo, storeRepo, zap.NewNop())
+			user, err := adminService.DisableUser(context.Background(), admin, test.id)
+
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+				assert.True(t, user.Disabled())
+			} else {
+				assert.ErrorContains(t, err, test.expectedError.Error())
+			}
+		})
+	}
+}
+
+func TestAdminService_LogoutUser(t *testing.T) {
+	admin := &domain.Session{UserID: 1, Role: constants.AdminRole}
+	user, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.UserRole)
+
+	tests := []struct {
+		name          string
+		mockBehavior  func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository)
+		expectedError error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(user, nil)
+				s.EXPECT().DeleteUserSessions(gomock.Any(), 7).Return(nil)
+			},
+		},
+		{
+			name: "No such user",
+			mockBehavior: func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(nil, domain.ErrNotFound)
+			},
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockAdminRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			test.mockBehavior(userRepo, storeRepo)
+
+			adminService := NewAdminService(userRepo, storeRepo, zap.NewNop())
+			assert.Equal(t, test.expectedError, adminService.LogoutUser(context.Background(), admin, 7))
+		})
+	}
+}
+
+func TestAdminService_DeleteUser(t *testing.T) {
+	admin := &domain.Session{UserID: 1, Role: constants.AdminRole}
+
+	tests := []struct {
+		name          string
+		mockBehavior  func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository)
+		id            int
+		expectedError error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {
+				u.EXPECT().DeleteUser(gomock.Any(), 7).Return(nil)
+				s.EXPECT().DeleteUserSessions(gomock.Any(), 7).Return(nil)
+			},
+			id: 7,
+		},
+		{
+			name: "Sessions not deleted",
+			mockBehavior: func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {
+				u.EXPECT().DeleteUser(gomock.Any(), 7).Return(nil)
+				s.EXPECT().DeleteUserSessions(gomock.Any(), 7).Return(errors.New("redis down"))
+			},
+			id: 7,
+		},
+		{
+			name:          "Own account",
+			mockBehavior:  func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {},
+			id:            1,
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name: "No such user",
+			mockBehavior: func(u *mock_service.MockAdminRepository, s *mock_service.MockStoreRepository) {
+				u.EXPECT().DeleteUser(gomock.Any(), 8).Return(domain.ErrNotFound)
+			},
+			id:            8,
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockAdminRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			test.mockBehavior(userRepo, storeRepo)
+
+			adminService := NewAdminService(userRepo, storeRepo, zap.NewNop())
+			assert.ErrorIs(t, adminService.DeleteUser(context.Background(), admin, test.id), test.expectedError)
+		})
+	}
+}
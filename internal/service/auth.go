@@ -2,58 +2,253 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/Max425/film-library.git/internal/common/constants"
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"strings"
+	"sync"
 	"time"
 )
 
 type UserRepository interface {
 	CreateUser(ctx context.Context, user *domain.User) (int, error)
 	GetUser(ctx context.Context, mail string) (*domain.User, error)
+	GetUserByID(ctx context.Context, id int) (*domain.User, error)
+	UpdatePassword(ctx context.Context, user *domain.User) error
+	SetVerified(ctx context.Context, id int) error
 }
 
 type StoreRepository interface {
-	SetSession(ctx context.Context, session string, role int, expire time.Duration) error
+	SetSession(ctx context.Context, session string, value *domain.Session, expire time.Duration) error
 	DeleteSession(ctx context.Context, session string) error
-	GetSession(ctx context.Context, session string) (int, error)
+	GetSession(ctx context.Context, session string) (*domain.Session, error)
+	TakeSession(ctx context.Context, session string) (*domain.Session, error)
+	SetSpentSession(ctx context.Context, session string, value *domain.Session, expire time.Duration) error
+	GetSpentSession(ctx context.Context, session string) (*domain.Session, error)
+	DeleteSessionFamily(ctx context.Context, userID int, family string) error
+	GetUserSessions(ctx context.Context, userID int) ([]*domain.Session, error)
+	GetUserSession(ctx context.Context, userID int, id string) (*domain.Session, error)
+	DeleteUserSession(ctx context.Context, userID int, id string) error
+	DeleteUserSessions(ctx context.Context, userID int) error
+	SetToken(ctx context.Context, purpose domain.TokenPurpose, token string, userID int, lifetime time.Duration) error
+	TakeToken(ctx context.Context, purpose domain.TokenPurpose, token string) (int, error)
+	AddLoginFailure(ctx context.Context, key string, window time.Duration) (int, error)
+	SetLoginLock(ctx context.Context, key string, lifetime time.Duration) error
+	GetLoginLock(ctx context.Context, key string) (time.Duration, error)
+	ResetLoginFailures(ctx context.Context, key string) error
+}
+
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
 }
 
 type AuthService struct {
 	log       *zap.Logger
 	userRepo  UserRepository
 	storeRepo StoreRepository
+	hasher    PasswordHasher
+	policy    PasswordPolicy
+	mailer    Mailer
+	throttle  LoginThrottle
+	// requireVerified keeps users whose mail is not verified from signing in.
+	requireVerified bool
+	tokens          *AccessTokens
+	// mails counts the mails still being sent in the background.
+	mails *sync.WaitGroup
 }
 
-func NewAuthService(log *zap.Logger, userRepo UserRepository, storeRepo StoreRepository) *AuthService {
-	return &AuthService{log: log, userRepo: userRepo, storeRepo: storeRepo}
+func NewAuthService(log *zap.Logger, userRepo UserRepository, storeRepo StoreRepository, hasher PasswordHasher,
+	policy PasswordPolicy, mailer Mailer, throttle LoginThrottle, requireVerified bool, tokens *AccessTokens) *AuthService {
+	return &AuthService{log: log, userRepo: userRepo, storeRepo: storeRepo, hasher: hasher, policy: policy,
+		mailer: mailer, throttle: throttle, requireVerified: requireVerified, tokens: tokens, mails: &sync.WaitGroup{}}
 }
 
+// CreateUser checks the mail and the password and stores the user with the
+// password hashed, the hash carries its own salt, so the salt of the user
+// stays empty. The user is sent a link to verify the mail, the user is
+// created even when the mail fails to go out, a new link can be asked for.
 func (s *AuthService) CreateUser(ctx context.Context, user *domain.User) (int, error) {
-	user.SetSalt(GenerateUuid())
-	user.SetPassword(GeneratePasswordHash(user.Password(), user.Salt()))
+	if err := domain.ValidateMail(user.Mail()); err != nil {
+		return 0, err
+	}
+	if err := s.policy.Validate(user.Password()); err != nil {
+		return 0, err
+	}
+
+	hash, err := s.hasher.Hash(user.Password())
+	if err != nil {
+		return 0, err
+	}
+	user.SetSalt("")
+	user.SetPassword(hash)
 	id, err := s.userRepo.CreateUser(ctx, user)
-	return id, err
+	if err != nil {
+		return 0, err
+	}
+
+	if err = s.sendVerification(ctx, id, user.Name(), user.Mail()); err != nil {
+		s.log.Error("Failed to send verification mail", zap.Int("user", id), zap.Error(err))
+	}
+	return id, nil
 }
 
+// SignIn returns the user once the password is checked, unless too many
+// attempts failed lately for the account or from the address. A failed
+// attempt is counted for both, one that succeeds forgets the failures of
+// the account.
+func (s *AuthService) SignIn(ctx context.Context, mail, password, ip string) (*domain.User, error) {
+	for _, key := range []string{mailLoginKey(mail), ipLoginKey(ip)} {
+		retryAfter, err := s.storeRepo.GetLoginLock(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if retryAfter > 0 {
+			return nil, &domain.LockedError{RetryAfter: retryAfter}
+		}
+	}
+
+	user, err := s.GetUser(ctx, mail, password)
+	if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrInvalidPassword) {
+		s.loginFailed(ctx, mail, ip)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.storeRepo.ResetLoginFailures(ctx, mailLoginKey(mail)); err != nil {
+		s.log.Error("Failed to reset login failures", zap.String("mail", mail), zap.Error(err))
+	}
+	return user, nil
+}
+
+// loginFailed counts the failed attempt and locks the account or the address
+// as the throttle says. The attempt has failed already, so errors are only
+// logged.
+func (s *AuthService) loginFailed(ctx context.Context, mail, ip string) {
+	failures, err := s.storeRepo.AddLoginFailure(ctx, mailLoginKey(mail), s.throttle.Window)
+	if err == nil {
+		if delay := s.throttle.delay(failures); delay > 0 {
+			err = s.storeRepo.SetLoginLock(ctx, mailLoginKey(mail), delay)
+			if err == nil && failures >= s.throttle.MaxAttempts {
+				s.log.Warn("Login locked",
+					zap.String("event", "login_lockout"),
+					zap.String("scope", "account"),
+					zap.String("mail", mail),
+					zap.String("ip", ip),
+					zap.Int("failures", failures),
+					zap.Duration("lockout", delay),
+				)
+			}
+		}
+	}
+	if err != nil {
+		s.log.Error("Failed to count login failure", zap.String("mail", mail), zap.Error(err))
+	}
+
+	failures, err = s.storeRepo.AddLoginFailure(ctx, ipLoginKey(ip), s.throttle.Window)
+	if err == nil && failures >= s.throttle.MaxIPAttempts {
+		err = s.storeRepo.SetLoginLock(ctx, ipLoginKey(ip), s.throttle.Lockout)
+		if err == nil {
+			s.log.Warn("Login locked",
+				zap.String("event", "login_lockout"),
+				zap.String("scope", "ip"),
+				zap.String("mail", mail),
+				zap.String("ip", ip),
+				zap.Int("failures", failures),
+				zap.Duration("lockout", s.throttle.Lockout),
+			)
+		}
+	}
+	if err != nil {
+		s.log.Error("Failed to count login failure", zap.String("ip", ip), zap.Error(err))
+	}
+}
+
+// UnlockUser lifts the sign in lock of the user and forgets the failed attempts.
+func (s *AuthService) UnlockUser(ctx context.Context, userID int) error {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err = s.storeRepo.ResetLoginFailures(ctx, mailLoginKey(user.Mail())); err != nil {
+		return err
+	}
+	s.log.Info("Login unlocked",
+		zap.String("event", "login_unlock"),
+		zap.Int("user", userID),
+		zap.String("mail", user.Mail()),
+	)
+	return nil
+}
+
+// GetUser returns the user once the password is checked. A password hash made
+// by an outdated hasher is replaced while the password is at hand. When
+// verification is required, a user with the mail not verified is refused,
+// a disabled user is refused always.
 func (s *AuthService) GetUser(ctx context.Context, mail, password string) (*domain.User, error) {
 	user, err := s.userRepo.GetUser(ctx, mail)
 	if err != nil {
 		return user, err
 	}
-	if GeneratePasswordHash(password, user.Salt()) != user.Password() {
+	ok, rehash, err := VerifyPassword(s.hasher, password, user.Password())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
 		return user, domain.ErrInvalidPassword
 	}
+	if user.Disabled() {
+		return nil, domain.ErrDisabled
+	}
+	if s.requireVerified && !user.Verified() {
+		return nil, domain.ErrUnverified
+	}
+	if rehash {
+		s.rehashPassword(ctx, user, password)
+	}
 	user.Sanitize()
 	return user, nil
 }
 
-func (s *AuthService) GenerateCookie(ctx context.Context, role int) (string, error) {
+// rehashPassword stores a fresh hash of the password, the user stays signed
+// in with the old hash when it fails.
+func (s *AuthService) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	hash, err := s.hasher.Hash(password)
+	if err == nil {
+		user.SetSalt("")
+		user.SetPassword(hash)
+		err = s.userRepo.UpdatePassword(ctx, user)
+	}
+	if err != nil {
+		s.log.Error("Failed to rehash password", zap.Int("user", user.ID()), zap.Error(err))
+	}
+}
+
+// GetUserByID returns the profile of the user without the password.
+func (s *AuthService) GetUserByID(ctx context.Context, id int) (*domain.User, error) {
+	user, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	user.Sanitize()
+	return user, nil
+}
+
+// GenerateCookie starts a new session and returns its id, the session is
+// stamped with the time it starts.
+func (s *AuthService) GenerateCookie(ctx context.Context, session *domain.Session) (string, error) {
 	SID := GenerateUuid()
-	if err := s.storeRepo.SetSession(ctx, SID, role, constants.CookieExpire); err != nil {
+	session.CreatedAt = time.Now().UTC()
+	if err := s.storeRepo.SetSession(ctx, SID, session, constants.CookieExpire); err != nil {
 		return "", err
 	}
 
@@ -61,18 +256,370 @@ func (s *AuthService) GenerateCookie(ctx context.Context, role int) (string, err
 }
 
 func (s *AuthService) DeleteCookie(ctx context.Context, session string) error {
+	if isRefreshSessionKey(session) {
+		return nil
+	}
 	return s.storeRepo.DeleteSession(ctx, session)
 }
 
-func (s *AuthService) GetSessionValue(ctx context.Context, session string) (int, error) {
-	id, err := s.storeRepo.GetSession(ctx, session)
+// GetSessionValue returns the session once its user is checked. A session of
+// a deleted or disabled user is refused, a session of a user whose role has
+// changed gets the new role. A refresh session is never a cookie session.
+func (s *AuthService) GetSessionValue(ctx context.Context, session string) (*domain.Session, error) {
+	if isRefreshSessionKey(session) {
+		return nil, domain.ErrNotFound
+	}
+	value, err := s.storeRepo.GetSession(ctx, session)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	if err = s.checkSessionUser(ctx, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
 
-	return id, nil
+// checkSessionUser refuses the session when its user is deleted or
+// disabled and gives the session the role the user has now.
+func (s *AuthService) checkSessionUser(ctx context.Context, session *domain.Session) error {
+	user, err := s.userRepo.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		return err
+	}
+	if user.Disabled() {
+		return domain.ErrDisabled
+	}
+	session.Role = user.Role()
+	return nil
+}
+
+// IssueTokens starts a refresh session for the signed in user and returns
+// an access token along with the refresh token of the session. Refresh
+// sessions are listed and revoked like cookie sessions.
+func (s *AuthService) IssueTokens(ctx context.Context, session *domain.Session) (*domain.TokenPair, error) {
+	session.CreatedAt = time.Now().UTC()
+	session.Family = GenerateUuid()
+	return s.startRefreshSession(ctx, session)
 }
 
+// RefreshTokens swaps the refresh token for a new pair, the refresh token
+// works once. The session keeps the time it started and takes the client
+// it is refreshed from. A refresh token used already has leaked, presenting
+// it again ends every session refreshed from the same sign in.
+func (s *AuthService) RefreshTokens(ctx context.Context, refreshToken, userAgent, ip string) (*domain.TokenPair, error) {
+	key := refreshSessionKey(refreshToken)
+	session, err := s.storeRepo.TakeSession(ctx, key)
+	if errors.Is(err, domain.ErrNotFound) {
+		if err = s.revokeReusedRefresh(ctx, key); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if session.Family == "" {
+		session.Family = GenerateUuid()
+	}
+	if err = s.storeRepo.SetSpentSession(ctx, key, session, s.tokens.RefreshTTL()); err != nil {
+		s.log.Error("Failed to remember spent refresh token", zap.Int("user", session.UserID), zap.Error(err))
+	}
+	if err = s.checkSessionUser(ctx, session); err != nil {
+		return nil, err
+	}
+	session.UserAgent, session.IP = userAgent, ip
+	return s.startRefreshSession(ctx, session)
+}
+
+// RevokeRefreshToken ends the refresh session of the token along with the
+// access tokens issued for it. An unknown token is not an error.
+func (s *AuthService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	return s.storeRepo.DeleteSession(ctx, refreshSessionKey(refreshToken))
+}
+
+// revokeReusedRefresh ends the sessions of the family when the refresh
+// session of the key was taken before.
+func (s *AuthService) revokeReusedRefresh(ctx context.Context, key string) error {
+	spent, err := s.storeRepo.GetSpentSession(ctx, key)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.log.Warn("Spent refresh token reused",
+		zap.String("event", "refresh_token_reuse"),
+		zap.Int("user", spent.UserID),
+	)
+	return s.storeRepo.DeleteSessionFamily(ctx, spent.UserID, spent.Family)
+}
+
+// ParseAccessToken returns the session of the access token. The token only
+// works while the refresh session it was issued for lives, so revoking the
+// session, signing out or changing the password ends it too. Its user is
+// checked like the user of a cookie session, so disabling the user takes
+// effect at once.
+func (s *AuthService) ParseAccessToken(ctx context.Context, token string) (*domain.Session, error) {
+	session, err := s.tokens.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.storeRepo.GetUserSession(ctx, session.UserID, session.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if err = s.checkSessionUser(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *AuthService) startRefreshSession(ctx context.Context, session *domain.Session) (*domain.TokenPair, error) {
+	refreshToken, err := GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+	if err = s.storeRepo.SetSession(ctx, refreshSessionKey(refreshToken), session, s.tokens.RefreshTTL()); err != nil {
+		return nil, err
+	}
+	accessToken, expiresAt, err := s.tokens.Issue(session, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TokenPair{AccessToken: accessToken, ExpiresAt: expiresAt, RefreshToken: refreshToken}, nil
+}
+
+// refreshSessionPrefix marks the session ids of refresh tokens, it keeps
+// refresh sessions from working as session cookies.
+const refreshSessionPrefix = "refresh:"
+
+// refreshSessionKey is the session id of a refresh token. Only a hash of the
+// token is kept, it works as a credential.
+func refreshSessionKey(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return refreshSessionPrefix + hex.EncodeToString(sum[:])
+}
+
+func isRefreshSessionKey(session string) bool {
+	return strings.HasPrefix(session, refreshSessionPrefix)
+}
+
+// ChangePassword sets a new password of the user once the old one is
+// confirmed and ends every session of the user.
+func (s *AuthService) ChangePassword(ctx context.Context, userID int, oldPassword, newPassword string) error {
+	if newPassword == "" {
+		return fmt.Errorf("%w: new password is required", domain.ErrRequired)
+	}
+	if err := s.policy.Validate(newPassword); err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	ok, _, err := VerifyPassword(s.hasher, oldPassword, user.Password())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrInvalidPassword
+	}
+
+	hash, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	user.SetSalt("")
+	user.SetPassword(hash)
+	if err = s.userRepo.UpdatePassword(ctx, user); err != nil {
+		return err
+	}
+	return s.storeRepo.DeleteUserSessions(ctx, userID)
+}
+
+func (s *AuthService) GetSessions(ctx context.Context, userID int) ([]*domain.Session, error) {
+	return s.storeRepo.GetUserSessions(ctx, userID)
+}
+
+// RevokeSession ends the session of the user with the public id.
+func (s *AuthService) RevokeSession(ctx context.Context, userID int, id string) error {
+	return s.storeRepo.DeleteUserSession(ctx, userID, id)
+}
+
+// RevokeSessions ends every session of the user, the current one too.
+func (s *AuthService) RevokeSessions(ctx context.Context, userID int) error {
+	return s.storeRepo.DeleteUserSessions(ctx, userID)
+}
+
+// MailVerificationRequired reports whether users have to verify the mail
+// before they may sign in.
+func (s *AuthService) MailVerificationRequired() bool {
+	return s.requireVerified
+}
+
+// RequestVerification sends a new verification link to the mail, the links
+// sent before stop working. Nothing is sent when there is no such user or
+// the mail is verified already, the caller is not told which, so mails of
+// users cannot be probed.
+func (s *AuthService) RequestVerification(ctx context.Context, mail string) error {
+	user, err := s.userRepo.GetUser(ctx, mail)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.Verified() {
+		return nil
+	}
+	s.sendInBackground(ctx, user.ID(), func(ctx context.Context) error {
+		return s.sendVerification(ctx, user.ID(), user.Name(), user.Mail())
+	})
+	return nil
+}
+
+// VerifyMail marks the mail of the user the token was sent to as verified.
+func (s *AuthService) VerifyMail(ctx context.Context, token string) error {
+	userID, err := s.storeRepo.TakeToken(ctx, domain.TokenMailVerification, token)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+	return s.userRepo.SetVerified(ctx, userID)
+}
+
+// RequestPasswordReset sends a token to set a new password to the mail, the
+// tokens sent before stop working. Nothing is sent when there is no such
+// user, the caller is not told.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, mail string) error {
+	user, err := s.userRepo.GetUser(ctx, mail)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.sendInBackground(ctx, user.ID(), func(ctx context.Context) error {
+		return s.sendPasswordReset(ctx, user.ID(), user.Name(), user.Mail())
+	})
+	return nil
+}
+
+func (s *AuthService) sendPasswordReset(ctx context.Context, userID int, name, mail string) error {
+	token, err := s.issueToken(ctx, domain.TokenPasswordReset, userID, constants.ResetTokenExpire)
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("Hello, %s!\n\n"+
+		"Someone asked to reset the password of your Film Library account. "+
+		"Send this token along with a new password to %s/api/auth/password/reset/confirm:\n\n%s\n\n"+
+		"The token works once within %d minutes. If it was not you, ignore this mail, your password stays the same.\n",
+		name, constants.Host, token, int(constants.ResetTokenExpire.Minutes()))
+	return s.mailer.Send(ctx, mail, "Reset your password", body)
+}
+
+// ResetPassword sets a new password of the user the token was sent to and
+// ends every session of the user. Getting the token proves the mail is the
+// user's, so the mail becomes verified too.
+func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if newPassword == "" {
+		return fmt.Errorf("%w: new password is required", domain.ErrRequired)
+	}
+	if err := s.policy.Validate(newPassword); err != nil {
+		return err
+	}
+	userID, err := s.storeRepo.TakeToken(ctx, domain.TokenPasswordReset, token)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	hash, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	user.SetSalt("")
+	user.SetPassword(hash)
+	if err = s.userRepo.UpdatePassword(ctx, user); err != nil {
+		return err
+	}
+	if !user.Verified() {
+		if err = s.userRepo.SetVerified(ctx, userID); err != nil {
+			return err
+		}
+	}
+	return s.storeRepo.DeleteUserSessions(ctx, userID)
+}
+
+// sendVerification mails the user a link to verify the mail.
+func (s *AuthService) sendVerification(ctx context.Context, userID int, name, mail string) error {
+	token, err := s.issueToken(ctx, domain.TokenMailVerification, userID, constants.VerifyTokenExpire)
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("Hello, %s!\n\n"+
+		"Follow the link to confirm this is the mail of your Film Library account:\n\n%s/api/auth/verify?token=%s\n\n"+
+		"The link works once within %d hours.\n",
+		name, constants.Host, token, int(constants.VerifyTokenExpire.Hours()))
+	return s.mailer.Send(ctx, mail, "Confirm your mail", body)
+}
+
+// sendInBackground sends the mail after the request is answered, so neither
+// the answer nor the time it takes tells an existing account from an
+// unknown one. A failure is only logged, the user may ask again.
+func (s *AuthService) sendInBackground(ctx context.Context, userID int, send func(ctx context.Context) error) {
+	ctx = context.WithoutCancel(ctx)
+	s.mails.Add(1)
+	go func() {
+		defer s.mails.Done()
+		if err := send(ctx); err != nil {
+			s.log.Error("Failed to send mail", zap.Int("user", userID), zap.Error(err))
+		}
+	}()
+}
+
+// Wait returns once the mails sent in the background are gone, or with the
+// error of the context when it is done first. Call it on shutdown, before
+// the stores the mails need are closed.
+func (s *AuthService) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.mails.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// issueToken stores a new random token of the purpose for the user, the
+// store drops the token of the purpose issued to the user before.
+func (s *AuthService) issueToken(ctx context.Context, purpose domain.TokenPurpose, userID int, lifetime time.Duration) (string, error) {
+	token, err := GenerateToken()
+	if err != nil {
+		return "", err
+	}
+	if err = s.storeRepo.SetToken(ctx, purpose, token, userID, lifetime); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// GeneratePasswordHash makes the sha1 hashes used before argon2id, they are
+// only checked now.
 func GeneratePasswordHash(password, salt string) string {
 	hash := sha1.New()
 	hash.Write([]byte(password))
@@ -83,3 +630,13 @@ func GeneratePasswordHash(password, salt string) string {
 func GenerateUuid() string {
 	return uuid.NewString()
 }
+
+// GenerateToken returns 32 random bytes in URL safe base64, unguessable
+// enough to stand for a user in a link.
+func GenerateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
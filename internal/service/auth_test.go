@@ -6,40 +6,78 @@ import (
 	mock_service "github.com/Max425/film-library.git/mocks/db"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 	"testing"
+	"time"
 
 	"github.com/Max425/film-library.git/internal/common/constants"
 	"github.com/Max425/film-library.git/internal/domain"
 )
 
 func TestAuthService_CreateUser(t *testing.T) {
-	mockUser, _ := domain.NewUser(1, "bob", "test@example.com", "password", "", 0)
+	newUser := func(mail, password string) *domain.User {
+		user, _ := domain.NewUser(0, "bob", mail, password, "", 0)
+		return user
+	}
 
 	tests := []struct {
 		name          string
-		mockBehavior  func(r *mock_service.MockUserRepository)
+		mockBehavior  func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer)
 		user          *domain.User
 		expectedID    int
 		expectedError error
 	}{
 		{
 			name: "Success",
-			mockBehavior: func(r *mock_service.MockUserRepository) {
-				r.EXPECT().CreateUser(gomock.Any(), mockUser).Return(1, nil)
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				s.EXPECT().SetToken(gomock.Any(), domain.TokenMailVerification, gomock.Any(), 1, constants.VerifyTokenExpire).Return(nil)
+				m.EXPECT().Send(gomock.Any(), "test@example.com", "Confirm your mail", gomock.Any()).Return(nil)
 			},
-			user:          mockUser,
-			expectedID:    1,
-			expectedError: nil,
+			user:       newUser("test@example.com", "Popcorn-2024"),
+			expectedID: 1,
+		},
+		{
+			name: "Verification mail fails",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(1, nil)
+				s.EXPECT().SetToken(gomock.Any(), domain.TokenMailVerification, gomock.Any(), 1, constants.VerifyTokenExpire).Return(nil)
+				m.EXPECT().Send(gomock.Any(), "test@example.com", "Confirm your mail", gomock.Any()).Return(errors.New("smtp down"))
+			},
+			user:       newUser("test@example.com", "Popcorn-2024"),
+			expectedID: 1,
 		},
 		{
 			name: "Error Creating User",
-			mockBehavior: func(r *mock_service.MockUserRepository) {
-				r.EXPECT().CreateUser(gomock.Any(), mockUser).Return(0, errors.New("create user error"))
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(0, errors.New("create user error"))
 			},
-			user:          mockUser,
-			expectedID:    0,
+			user:          newUser("test@example.com", "Popcorn-2024"),
 			expectedError: errors.New("create user error"),
 		},
+		{
+			name: "Mail taken",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(0, domain.ErrAlreadyExists)
+			},
+			user:          newUser("test@example.com", "Popcorn-2024"),
+			expectedError: domain.ErrAlreadyExists,
+		},
+		{
+			name: "Invalid mail",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+			},
+			user:          newUser("test@example", "Popcorn-2024"),
+			expectedError: domain.ErrInvalidMail,
+		},
+		{
+			name: "Weak password",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+			},
+			user:          newUser("test@example.com", "password"),
+			expectedError: domain.ErrWeakPassword,
+		},
 	}
 
 	for _, test := range tests {
@@ -48,13 +86,20 @@ func TestAuthService_CreateUser(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := mock_service.NewMockUserRepository(ctrl)
-			test.mockBehavior(repo)
+			store := mock_service.NewMockStoreRepository(ctrl)
+			mailer := mock_service.NewMockMailer(ctrl)
+			test.mockBehavior(repo, store, mailer)
 
-			authService := NewAuthService(nil, repo, nil)
+			authService := NewAuthService(zap.NewNop(), repo, store, NewArgon2idHasher(), NewPasswordPolicy(0, 0), mailer, LoginThrottle{}, false, nil)
 			id, err := authService.CreateUser(context.Background(), test.user)
 
 			assert.Equal(t, test.expectedID, id)
-			assert.Equal(t, test.expectedError, err)
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+				assert.True(t, NewArgon2idHasher().Recognizes(test.user.Password()))
+			} else {
+				assert.ErrorContains(t, err, test.expectedError.Error())
+			}
 		})
 	}
 }
@@ -100,7 +145,7 @@ func TestAuthService_GetUser(t *testing.T) {
 			repo := mock_service.NewMockUserRepository(ctrl)
 			test.mockBehavior(repo)
 
-			authService := NewAuthService(nil, repo, nil)
+			authService := NewAuthService(nil, repo, nil, NewArgon2idHasher(), NewPasswordPolicy(0, 0), nil, LoginThrottle{}, false, nil)
 			user, err := authService.GetUser(context.Background(), test.mail, test.password)
 
 			assert.Equal(t, test.expectedUser, user)
@@ -109,21 +154,73 @@ func TestAuthService_GetUser(t *testing.T) {
 	}
 }
 
+func TestAuthService_GetUser_Rehash(t *testing.T) {
+	argon2id := NewArgon2idHasher()
+	current, _ := argon2id.Hash("password")
+
+	tests := []struct {
+		name       string
+		storedHash string
+		rehashed   bool
+	}{
+		{name: "Legacy sha1 hash", storedHash: GeneratePasswordHash("password", "salt"), rehashed: true},
+		{name: "Bcrypt hash", storedHash: mustBcrypt(t, "password"), rehashed: true},
+		{name: "Current hash", storedHash: current},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stored, _ := domain.NewUser(1, "bob", "test@example.com", test.storedHash, "salt", 0)
+			repo := mock_service.NewMockUserRepository(ctrl)
+			repo.EXPECT().GetUser(gomock.Any(), "test@example.com").Return(stored, nil)
+			if test.rehashed {
+				repo.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user *domain.User) error {
+					assert.True(t, argon2id.Recognizes(user.Password()))
+					assert.Equal(t, "", user.Salt())
+					return nil
+				})
+			}
+
+			authService := NewAuthService(zap.NewNop(), repo, nil, argon2id, NewPasswordPolicy(0, 0), nil, LoginThrottle{}, false, nil)
+			user, err := authService.GetUser(context.Background(), "test@example.com", "password")
+
+			assert.NoError(t, err)
+			assert.Equal(t, "", user.Password())
+		})
+	}
+}
+
+func mustBcrypt(t *testing.T, password string) string {
+	hash, err := NewBcryptHasher(bcrypt.MinCost).Hash(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
 func TestAuthService_GenerateCookie(t *testing.T) {
 	tests := []struct {
 		name          string
 		mockBehavior  func(r *mock_service.MockStoreRepository)
-		role          int
-		expectedSID   string
+		session       *domain.Session
 		expectedError error
 	}{
+		{
+			name: "Success",
+			mockBehavior: func(r *mock_service.MockStoreRepository) {
+				r.EXPECT().SetSession(gomock.Any(), gomock.Any(), gomock.Any(), constants.CookieExpire).Return(nil)
+			},
+			session: &domain.Session{UserID: 7, Role: 1, UserAgent: "curl/8.0", IP: "10.0.0.1"},
+		},
 		{
 			name: "Error Generating Cookie",
 			mockBehavior: func(r *mock_service.MockStoreRepository) {
-				r.EXPECT().SetSession(gomock.Any(), gomock.Any(), 1, constants.CookieExpire).Return(errors.New("generate cookie error"))
+				r.EXPECT().SetSession(gomock.Any(), gomock.Any(), gomock.Any(), constants.CookieExpire).Return(errors.New("generate cookie error"))
 			},
-			role:          1,
-			expectedSID:   "",
+			session:       &domain.Session{UserID: 7, Role: 1},
 			expectedError: errors.New("generate cookie error"),
 		},
 	}
@@ -136,15 +233,39 @@ func TestAuthService_GenerateCookie(t *testing.T) {
 			repo := mock_service.NewMockStoreRepository(ctrl)
 			test.mockBehavior(repo)
 
-			authService := NewAuthService(nil, nil, repo)
-			sid, err := authService.GenerateCookie(context.Background(), test.role)
+			authService := NewAuthService(nil, nil, repo, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, nil)
+			sid, err := authService.GenerateCookie(context.Background(), test.session)
 
-			assert.Equal(t, test.expectedSID, sid)
-			assert.Equal(t, test.expectedError, err)
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+				assert.Len(t, sid, 36)
+				assert.False(t, test.session.CreatedAt.IsZero())
+			} else {
+				assert.Equal(t, "", sid)
+				assert.Equal(t, test.expectedError, err)
+			}
 		})
 	}
 }
 
+func TestAuthService_GetUserByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "salt", 0)
+	sanitized, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "salt", 0)
+	sanitized.Sanitize()
+
+	repo := mock_service.NewMockUserRepository(ctrl)
+	repo.EXPECT().GetUserByID(gomock.Any(), 7).Return(stored, nil)
+
+	authService := NewAuthService(nil, repo, nil, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, nil)
+	user, err := authService.GetUserByID(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, sanitized, user)
+}
+
 func TestAuthService_DeleteCookie(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -178,7 +299,7 @@ func TestAuthService_DeleteCookie(t *testing.T) {
 			repo := mock_service.NewMockStoreRepository(ctrl)
 			test.mockBehavior(repo)
 
-			authService := NewAuthService(nil, nil, repo)
+			authService := NewAuthService(nil, nil, repo, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, nil)
 			err := authService.DeleteCookie(context.Background(), test.session)
 
 			assert.Equal(t, test.expectedError, err)
@@ -187,31 +308,63 @@ func TestAuthService_DeleteCookie(t *testing.T) {
 }
 
 func TestAuthService_GetSessionValue(t *testing.T) {
+	editor, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.EditorRole)
+	disabled, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.UserRole)
+	disabled.SetDisabledAt(time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC))
+
 	tests := []struct {
 		name          string
-		mockBehavior  func(r *mock_service.MockStoreRepository)
+		mockBehavior  func(u *mock_service.MockUserRepository, r *mock_service.MockStoreRepository)
 		session       string
-		expectedID    int
+		expected      *domain.Session
 		expectedError error
 	}{
 		{
 			name: "Success",
-			mockBehavior: func(r *mock_service.MockStoreRepository) {
-				r.EXPECT().GetSession(gomock.Any(), "dummySession").Return(1, nil)
+			mockBehavior: func(u *mock_service.MockUserRepository, r *mock_service.MockStoreRepository) {
+				r.EXPECT().GetSession(gomock.Any(), "dummySession").Return(&domain.Session{UserID: 7, Role: 1}, nil)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(editor, nil)
 			},
 			session:       "dummySession",
-			expectedID:    1,
+			expected:      &domain.Session{UserID: 7, Role: constants.EditorRole},
 			expectedError: nil,
 		},
+		{
+			name:          "Refresh session as cookie",
+			mockBehavior:  func(u *mock_service.MockUserRepository, r *mock_service.MockStoreRepository) {},
+			session:       refreshSessionKey("token"),
+			expected:      nil,
+			expectedError: domain.ErrNotFound,
+		},
 		{
 			name: "Error Getting Session",
-			mockBehavior: func(r *mock_service.MockStoreRepository) {
-				r.EXPECT().GetSession(gomock.Any(), "dummySession").Return(0, errors.New("get session error"))
+			mockBehavior: func(u *mock_service.MockUserRepository, r *mock_service.MockStoreRepository) {
+				r.EXPECT().GetSession(gomock.Any(), "dummySession").Return(nil, errors.New("get session error"))
 			},
 			session:       "dummySession",
-			expectedID:    0,
+			expected:      nil,
 			expectedError: errors.New("get session error"),
 		},
+		{
+			name: "User Deleted",
+			mockBehavior: func(u *mock_service.MockUserRepository, r *mock_service.MockStoreRepository) {
+				r.EXPECT().GetSession(gomock.Any(), "dummySession").Return(&domain.Session{UserID: 7, Role: 1}, nil)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(nil, domain.ErrNotFound)
+			},
+			session:       "dummySession",
+			expected:      nil,
+			expectedError: domain.ErrNotFound,
+		},
+		{
+			name: "User Disabled",
+			mockBehavior: func(u *mock_service.MockUserRepository, r *mock_service.MockStoreRepository) {
+				r.EXPECT().GetSession(gomock.Any(), "dummySession").Return(&domain.Session{UserID: 7, Role: 0}, nil)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(disabled, nil)
+			},
+			session:       "dummySession",
+			expected:      nil,
+			expectedError: domain.ErrDisabled,
+		},
 	}
 
 	for _, test := range tests {
@@ -219,13 +372,14 @@ func TestAuthService_GetSessionValue(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			userRepo := mock_service.NewMockUserRepository(ctrl)
 			repo := mock_service.NewMockStoreRepository(ctrl)
-			test.mockBehavior(repo)
+			test.mockBehavior(userRepo, repo)
 
-			authService := NewAuthService(nil, nil, repo)
-			id, err := authService.GetSessionValue(context.Background(), test.session)
+			authService := NewAuthService(nil, userRepo, repo, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, nil)
+			session, err := authService.GetSessionValue(context.Background(), test.session)
 
-			assert.Equal(t, test.expectedID, id)
+			assert.Equal(t, test.expected, session)
 			assert.Equal(t, test.expectedError, err)
 		})
 	}
@@ -239,3 +393,758 @@ func TestGeneratePasswordHash(t *testing.T) {
 
 	assert.Equal(t, hash, GeneratePasswordHash(password, salt))
 }
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	tests := []struct {
+		name          string
+		oldPassword   string
+		newPassword   string
+		mockBehavior  func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository)
+		expectedError error
+	}{
+		{
+			name:        "Success",
+			oldPassword: "old",
+			newPassword: "Popcorn-2024",
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				user, _ := domain.NewUser(7, "bob", "bob@example.com", GeneratePasswordHash("old", "salt"), "salt", 0)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(user, nil)
+				u.EXPECT().UpdatePassword(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user *domain.User) error {
+					ok, err := NewArgon2idHasher().Verify("Popcorn-2024", user.Password())
+					assert.NoError(t, err)
+					assert.True(t, ok)
+					return nil
+				})
+				s.EXPECT().DeleteUserSessions(gomock.Any(), 7).Return(nil)
+			},
+		},
+		{
+			name:        "Wrong old password",
+			oldPassword: "wrong",
+			newPassword: "Popcorn-2024",
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				user, _ := domain.NewUser(7, "bob", "bob@example.com", GeneratePasswordHash("old", "salt"), "salt", 0)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(user, nil)
+			},
+			expectedError: domain.ErrInvalidPassword,
+		},
+		{
+			name:          "Empty new password",
+			oldPassword:   "old",
+			mockBehavior:  func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {},
+			expectedError: domain.ErrRequired,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockUserRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			test.mockBehavior(userRepo, storeRepo)
+
+			authService := NewAuthService(nil, userRepo, storeRepo, NewArgon2idHasher(), NewPasswordPolicy(0, 0), nil, LoginThrottle{}, false, nil)
+			err := authService.ChangePassword(context.Background(), 7, test.oldPassword, test.newPassword)
+
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetUser_Unverified(t *testing.T) {
+	hasher := NewArgon2idHasher()
+	hash, err := hasher.Hash("Popcorn-2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		verified        bool
+		requireVerified bool
+		expectedError   error
+	}{
+		{name: "Verification required, not verified", requireVerified: true, expectedError: domain.ErrUnverified},
+		{name: "Verification required, verified", verified: true, requireVerified: true},
+		{name: "Verification not required", requireVerified: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user, _ := domain.NewUser(1, "bob", "test@example.com", hash, "", 0)
+			if test.verified {
+				user.SetVerifiedAt(time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC))
+			}
+			repo := mock_service.NewMockUserRepository(ctrl)
+			repo.EXPECT().GetUser(gomock.Any(), "test@example.com").Return(user, nil)
+
+			authService := NewAuthService(zap.NewNop(), repo, nil, hasher, NewPasswordPolicy(0, 0), nil, LoginThrottle{}, test.requireVerified, nil)
+			_, err := authService.GetUser(context.Background(), "test@example.com", "Popcorn-2024")
+
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestAuthService_GetUser_Disabled(t *testing.T) {
+	hasher := NewArgon2idHasher()
+	hash, err := hasher.Hash("Popcorn-2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user, _ := domain.NewUser(1, "bob", "test@example.com", hash, "", 0)
+	user.SetVerifiedAt(time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC))
+	user.SetDisabledAt(time.Date(2024, time.March, 19, 12, 0, 0, 0, time.UTC))
+	repo := mock_service.NewMockUserRepository(ctrl)
+	repo.EXPECT().GetUser(gomock.Any(), "test@example.com").Return(user, nil)
+
+	authService := NewAuthService(zap.NewNop(), repo, nil, hasher, NewPasswordPolicy(0, 0), nil, LoginThrottle{}, false, nil)
+	_, err = authService.GetUser(context.Background(), "test@example.com", "Popcorn-2024")
+
+	assert.Equal(t, domain.ErrDisabled, err)
+}
+
+func TestAuthService_RequestVerification(t *testing.T) {
+	unverified, _ := domain.NewUser(1, "bob", "test@example.com", "hash", "", 0)
+	verified, _ := domain.NewUser(2, "alice", "alice@example.com", "hash", "", 0)
+	verified.SetVerifiedAt(time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name          string
+		mail          string
+		mockBehavior  func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer)
+		expectedError error
+	}{
+		{
+			name: "Sent",
+			mail: "test@example.com",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().GetUser(gomock.Any(), "test@example.com").Return(unverified, nil)
+				s.EXPECT().SetToken(gomock.Any(), domain.TokenMailVerification, gomock.Any(), 1, constants.VerifyTokenExpire).Return(nil)
+				m.EXPECT().Send(gomock.Any(), "test@example.com", "Confirm your mail", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "Unknown mail",
+			mail: "nobody@example.com",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().GetUser(gomock.Any(), "nobody@example.com").Return(nil, domain.ErrNotFound)
+			},
+		},
+		{
+			name: "Already verified",
+			mail: "alice@example.com",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().GetUser(gomock.Any(), "alice@example.com").Return(verified, nil)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockUserRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			mailer := mock_service.NewMockMailer(ctrl)
+			test.mockBehavior(userRepo, storeRepo, mailer)
+
+			authService := NewAuthService(zap.NewNop(), userRepo, storeRepo, nil, PasswordPolicy{}, mailer, LoginThrottle{}, false, nil)
+			err := authService.RequestVerification(context.Background(), test.mail)
+			assert.NoError(t, authService.Wait(context.Background()))
+
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestAuthService_VerifyMail(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockBehavior  func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository)
+		expectedError error
+	}{
+		{
+			name: "Verified",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().TakeToken(gomock.Any(), domain.TokenMailVerification, "token").Return(7, nil)
+				r.EXPECT().SetVerified(gomock.Any(), 7).Return(nil)
+			},
+		},
+		{
+			name: "Unknown token",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().TakeToken(gomock.Any(), domain.TokenMailVerification, "token").Return(0, domain.ErrNotFound)
+			},
+			expectedError: domain.ErrInvalidToken,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockUserRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			test.mockBehavior(userRepo, storeRepo)
+
+			authService := NewAuthService(nil, userRepo, storeRepo, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, nil)
+			err := authService.VerifyMail(context.Background(), "token")
+
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestAuthService_RequestPasswordReset(t *testing.T) {
+	user, _ := domain.NewUser(1, "bob", "test@example.com", "hash", "", 0)
+
+	tests := []struct {
+		name          string
+		mail          string
+		mockBehavior  func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer)
+		expectedError error
+	}{
+		{
+			name: "Sent",
+			mail: "test@example.com",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().GetUser(gomock.Any(), "test@example.com").Return(user, nil)
+				s.EXPECT().SetToken(gomock.Any(), domain.TokenPasswordReset, gomock.Any(), 1, constants.ResetTokenExpire).Return(nil)
+				m.EXPECT().Send(gomock.Any(), "test@example.com", "Reset your password", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "Unknown mail",
+			mail: "nobody@example.com",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().GetUser(gomock.Any(), "nobody@example.com").Return(nil, domain.ErrNotFound)
+			},
+		},
+		{
+			name: "Mail fails",
+			mail: "test@example.com",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().GetUser(gomock.Any(), "test@example.com").Return(user, nil)
+				s.EXPECT().SetToken(gomock.Any(), domain.TokenPasswordReset, gomock.Any(), 1, constants.ResetTokenExpire).Return(nil)
+				m.EXPECT().Send(gomock.Any(), "test@example.com", "Reset your password", gomock.Any()).Return(errors.New("smtp down"))
+			},
+		},
+		{
+			name: "Store fails",
+			mail: "test@example.com",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, m *mock_service.MockMailer) {
+				r.EXPECT().GetUser(gomock.Any(), "test@example.com").Return(user, nil)
+				s.EXPECT().SetToken(gomock.Any(), domain.TokenPasswordReset, gomock.Any(), 1, constants.ResetTokenExpire).Return(errors.New("redis down"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockUserRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			mailer := mock_service.NewMockMailer(ctrl)
+			test.mockBehavior(userRepo, storeRepo, mailer)
+
+			authService := NewAuthService(zap.NewNop(), userRepo, storeRepo, nil, PasswordPolicy{}, mailer, LoginThrottle{}, false, nil)
+			err := authService.RequestPasswordReset(context.Background(), test.mail)
+			assert.NoError(t, authService.Wait(context.Background()))
+
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name          string
+		newPassword   string
+		verified      bool
+		mockBehavior  func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User)
+		expectedError error
+	}{
+		{
+			name:        "Reset and verified",
+			newPassword: "Popcorn-2024",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().TakeToken(gomock.Any(), domain.TokenPasswordReset, "token").Return(7, nil)
+				r.EXPECT().GetUserByID(gomock.Any(), 7).Return(user, nil)
+				r.EXPECT().UpdatePassword(gomock.Any(), user).Return(nil)
+				r.EXPECT().SetVerified(gomock.Any(), 7).Return(nil)
+				s.EXPECT().DeleteUserSessions(gomock.Any(), 7).Return(nil)
+			},
+		},
+		{
+			name:        "Reset of verified user",
+			newPassword: "Popcorn-2024",
+			verified:    true,
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().TakeToken(gomock.Any(), domain.TokenPasswordReset, "token").Return(7, nil)
+				r.EXPECT().GetUserByID(gomock.Any(), 7).Return(user, nil)
+				r.EXPECT().UpdatePassword(gomock.Any(), user).Return(nil)
+				s.EXPECT().DeleteUserSessions(gomock.Any(), 7).Return(nil)
+			},
+		},
+		{
+			name:        "Weak password keeps the token",
+			newPassword: "qwerty",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+			},
+			expectedError: domain.ErrWeakPassword,
+		},
+		{
+			name:        "Unknown token",
+			newPassword: "Popcorn-2024",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().TakeToken(gomock.Any(), domain.TokenPasswordReset, "token").Return(0, domain.ErrNotFound)
+			},
+			expectedError: domain.ErrInvalidToken,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user, _ := domain.NewUser(7, "bob", "test@example.com", GeneratePasswordHash("old", "salt"), "salt", 0)
+			if test.verified {
+				user.SetVerifiedAt(time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC))
+			}
+			userRepo := mock_service.NewMockUserRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			test.mockBehavior(userRepo, storeRepo, user)
+
+			authService := NewAuthService(nil, userRepo, storeRepo, NewArgon2idHasher(), NewPasswordPolicy(0, 0), nil, LoginThrottle{}, false, nil)
+			err := authService.ResetPassword(context.Background(), "token", test.newPassword)
+
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+				ok, _ := NewArgon2idHasher().Verify(test.newPassword, user.Password())
+				assert.True(t, ok)
+			} else {
+				assert.ErrorIs(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestAuthService_SignIn(t *testing.T) {
+	hasher := NewArgon2idHasher()
+	hash, err := hasher.Hash("Popcorn-2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+	throttle := NewLoginThrottle(3, 10, time.Minute, time.Hour)
+
+	tests := []struct {
+		name          string
+		password      string
+		mockBehavior  func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User)
+		expectedError error
+	}{
+		{
+			name:     "Ok",
+			password: "Popcorn-2024",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().GetLoginLock(gomock.Any(), "mail:bob@example.com").Return(time.Duration(0), nil)
+				s.EXPECT().GetLoginLock(gomock.Any(), "ip:10.0.0.1").Return(time.Duration(0), nil)
+				r.EXPECT().GetUser(gomock.Any(), "bob@example.com").Return(user, nil)
+				s.EXPECT().ResetLoginFailures(gomock.Any(), "mail:bob@example.com").Return(nil)
+			},
+		},
+		{
+			name:     "Account locked",
+			password: "Popcorn-2024",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().GetLoginLock(gomock.Any(), "mail:bob@example.com").Return(time.Minute, nil)
+			},
+			expectedError: &domain.LockedError{RetryAfter: time.Minute},
+		},
+		{
+			name:     "Address locked",
+			password: "Popcorn-2024",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().GetLoginLock(gomock.Any(), "mail:bob@example.com").Return(time.Duration(0), nil)
+				s.EXPECT().GetLoginLock(gomock.Any(), "ip:10.0.0.1").Return(time.Hour, nil)
+			},
+			expectedError: &domain.LockedError{RetryAfter: time.Hour},
+		},
+		{
+			name:     "First failure",
+			password: "wrong",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().GetLoginLock(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				r.EXPECT().GetUser(gomock.Any(), "bob@example.com").Return(user, nil)
+				s.EXPECT().AddLoginFailure(gomock.Any(), "mail:bob@example.com", time.Minute).Return(1, nil)
+				s.EXPECT().AddLoginFailure(gomock.Any(), "ip:10.0.0.1", time.Minute).Return(1, nil)
+			},
+			expectedError: domain.ErrInvalidPassword,
+		},
+		{
+			name:     "Second failure delays",
+			password: "wrong",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().GetLoginLock(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				r.EXPECT().GetUser(gomock.Any(), "bob@example.com").Return(user, nil)
+				s.EXPECT().AddLoginFailure(gomock.Any(), "mail:bob@example.com", time.Minute).Return(2, nil)
+				s.EXPECT().SetLoginLock(gomock.Any(), "mail:bob@example.com", time.Second).Return(nil)
+				s.EXPECT().AddLoginFailure(gomock.Any(), "ip:10.0.0.1", time.Minute).Return(2, nil)
+			},
+			expectedError: domain.ErrInvalidPassword,
+		},
+		{
+			name:     "Last failure locks the account",
+			password: "wrong",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().GetLoginLock(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				r.EXPECT().GetUser(gomock.Any(), "bob@example.com").Return(user, nil)
+				s.EXPECT().AddLoginFailure(gomock.Any(), "mail:bob@example.com", time.Minute).Return(3, nil)
+				s.EXPECT().SetLoginLock(gomock.Any(), "mail:bob@example.com", time.Hour).Return(nil)
+				s.EXPECT().AddLoginFailure(gomock.Any(), "ip:10.0.0.1", time.Minute).Return(3, nil)
+			},
+			expectedError: domain.ErrInvalidPassword,
+		},
+		{
+			name:     "Unknown mail locks the address",
+			password: "wrong",
+			mockBehavior: func(r *mock_service.MockUserRepository, s *mock_service.MockStoreRepository, user *domain.User) {
+				s.EXPECT().GetLoginLock(gomock.Any(), gomock.Any()).Return(time.Duration(0), nil).Times(2)
+				r.EXPECT().GetUser(gomock.Any(), "bob@example.com").Return(nil, domain.ErrNotFound)
+				s.EXPECT().AddLoginFailure(gomock.Any(), "mail:bob@example.com", time.Minute).Return(1, nil)
+				s.EXPECT().AddLoginFailure(gomock.Any(), "ip:10.0.0.1", time.Minute).Return(10, nil)
+				s.EXPECT().SetLoginLock(gomock.Any(), "ip:10.0.0.1", time.Hour).Return(nil)
+			},
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user, _ := domain.NewUser(1, "bob", "bob@example.com", hash, "", 0)
+			userRepo := mock_service.NewMockUserRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			test.mockBehavior(userRepo, storeRepo, user)
+
+			authService := NewAuthService(zap.NewNop(), userRepo, storeRepo, hasher, PasswordPolicy{}, nil, throttle, false, nil)
+			signedIn, err := authService.SignIn(context.Background(), "bob@example.com", test.password, "10.0.0.1")
+
+			assert.Equal(t, test.expectedError, err)
+			if test.expectedError == nil {
+				assert.Equal(t, 1, signedIn.ID())
+			} else {
+				assert.Nil(t, signedIn)
+			}
+		})
+	}
+}
+
+func TestAuthService_UnlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", 0)
+	userRepo := mock_service.NewMockUserRepository(ctrl)
+	storeRepo := mock_service.NewMockStoreRepository(ctrl)
+	userRepo.EXPECT().GetUserByID(gomock.Any(), 7).Return(user, nil)
+	storeRepo.EXPECT().ResetLoginFailures(gomock.Any(), "mail:bob@example.com").Return(nil)
+
+	authService := NewAuthService(zap.NewNop(), userRepo, storeRepo, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, nil)
+	assert.NoError(t, authService.UnlockUser(context.Background(), 7))
+}
+
+func TestAuthService_SignIn_MailCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hasher := NewArgon2idHasher()
+	hash, err := hasher.Hash("Popcorn-2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, _ := domain.NewUser(7, "bob", "bob@example.com", hash, "", 0)
+
+	failures := map[string]int{}
+	locks := map[string]time.Duration{}
+	userRepo := mock_service.NewMockUserRepository(ctrl)
+	storeRepo := mock_service.NewMockStoreRepository(ctrl)
+	userRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(user, nil).AnyTimes()
+	userRepo.EXPECT().GetUserByID(gomock.Any(), 7).Return(user, nil)
+	storeRepo.EXPECT().GetLoginLock(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, key string) (time.Duration, error) {
+			// the short delays between attempts are taken as passed already
+			if locks[key] < time.Hour {
+				return 0, nil
+			}
+			return locks[key], nil
+		})
+	storeRepo.EXPECT().AddLoginFailure(gomock.Any(), gomock.Any(), time.Minute).AnyTimes().
+		DoAndReturn(func(ctx context.Context, key string, window time.Duration) (int, error) {
+			failures[key]++
+			return failures[key], nil
+		})
+	storeRepo.EXPECT().SetLoginLock(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, key string, lifetime time.Duration) error {
+			locks[key] = lifetime
+			return nil
+		})
+	storeRepo.EXPECT().ResetLoginFailures(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, key string) error {
+			delete(failures, key)
+			delete(locks, key)
+			return nil
+		})
+
+	authService := NewAuthService(zap.NewNop(), userRepo, storeRepo, hasher, PasswordPolicy{}, nil,
+		NewLoginThrottle(3, 10, time.Minute, time.Hour), false, nil)
+	for _, mail := range []string{"Bob@example.com", "BOB@EXAMPLE.COM", " bob@Example.com"} {
+		_, err = authService.SignIn(context.Background(), mail, "wrong", "10.0.0.1")
+		assert.ErrorIs(t, err, domain.ErrInvalidPassword)
+	}
+	assert.Equal(t, 3, failures["mail:bob@example.com"])
+
+	_, err = authService.SignIn(context.Background(), "bob@EXAMPLE.com", "Popcorn-2024", "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrTooManyAttempts)
+
+	assert.NoError(t, authService.UnlockUser(context.Background(), 7))
+	signedIn, err := authService.SignIn(context.Background(), "BOB@example.com", "Popcorn-2024", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, signedIn.ID())
+}
+
+func testAccessTokens(t *testing.T) *AccessTokens {
+	key, err := ParseAccessKey("test", "HS256", testSecret, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := NewAccessTokens("film-library", time.Minute, time.Hour, "test", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokens
+}
+
+func TestAuthService_IssueTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := testAccessTokens(t)
+	storeRepo := mock_service.NewMockStoreRepository(ctrl)
+	storeRepo.EXPECT().SetSession(gomock.Any(), gomock.Any(), gomock.Any(), time.Hour).
+		DoAndReturn(func(ctx context.Context, SID string, session *domain.Session, ttl time.Duration) error {
+			assert.Regexp(t, "^refresh:[0-9a-f]{64}$", SID)
+			assert.NotEmpty(t, session.Family)
+			session.ID = "public-id"
+			return nil
+		})
+
+	authService := NewAuthService(nil, nil, storeRepo, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, tokens)
+	pair, err := authService.IssueTokens(context.Background(), &domain.Session{UserID: 7, Role: constants.EditorRole})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pair.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), pair.ExpiresAt, 2*time.Second)
+	session, err := tokens.Parse(pair.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "public-id", session.ID)
+	assert.Equal(t, 7, session.UserID)
+	assert.Equal(t, constants.EditorRole, session.Role)
+}
+
+func TestAuthService_RefreshTokens(t *testing.T) {
+	user, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.ModeratorRole)
+	disabled, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.UserRole)
+	disabled.SetDisabledAt(time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC))
+	createdAt := time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC)
+	oldKey := refreshSessionKey("old")
+
+	tests := []struct {
+		name          string
+		mockBehavior  func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository)
+		expectedError error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				spent := &domain.Session{ID: "a", UserID: 7, CreatedAt: createdAt, UserAgent: "app/1.0", Family: "f"}
+				s.EXPECT().TakeSession(gomock.Any(), oldKey).Return(spent, nil)
+				s.EXPECT().SetSpentSession(gomock.Any(), oldKey, spent, time.Hour).Return(nil)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(user, nil)
+				s.EXPECT().SetSession(gomock.Any(), gomock.Not(oldKey), &domain.Session{
+					ID: "a", UserID: 7, Role: constants.ModeratorRole, CreatedAt: createdAt, UserAgent: "app/1.1", IP: "10.0.0.1", Family: "f",
+				}, time.Hour).Return(nil)
+			},
+		},
+		{
+			name: "Unknown token",
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().TakeSession(gomock.Any(), oldKey).Return(nil, domain.ErrNotFound)
+				s.EXPECT().GetSpentSession(gomock.Any(), oldKey).Return(nil, domain.ErrNotFound)
+			},
+			expectedError: domain.ErrInvalidToken,
+		},
+		{
+			name: "Used token revokes the family",
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().TakeSession(gomock.Any(), oldKey).Return(nil, domain.ErrNotFound)
+				s.EXPECT().GetSpentSession(gomock.Any(), oldKey).Return(&domain.Session{UserID: 7, Family: "f"}, nil)
+				s.EXPECT().DeleteSessionFamily(gomock.Any(), 7, "f").Return(nil)
+			},
+			expectedError: domain.ErrInvalidToken,
+		},
+		{
+			name: "Spent token not remembered",
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().TakeSession(gomock.Any(), oldKey).Return(&domain.Session{UserID: 7}, nil)
+				s.EXPECT().SetSpentSession(gomock.Any(), oldKey, gomock.Any(), time.Hour).Return(errors.New("redis down"))
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(user, nil)
+				s.EXPECT().SetSession(gomock.Any(), gomock.Not(oldKey), gomock.Any(), time.Hour).
+					DoAndReturn(func(ctx context.Context, SID string, session *domain.Session, ttl time.Duration) error {
+						assert.NotEmpty(t, session.Family)
+						return nil
+					})
+			},
+		},
+		{
+			name: "User disabled",
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().TakeSession(gomock.Any(), oldKey).Return(&domain.Session{UserID: 7}, nil)
+				s.EXPECT().SetSpentSession(gomock.Any(), oldKey, gomock.Any(), time.Hour).Return(nil)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(disabled, nil)
+			},
+			expectedError: domain.ErrDisabled,
+		},
+		{
+			name: "User deleted",
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().TakeSession(gomock.Any(), oldKey).Return(&domain.Session{UserID: 7}, nil)
+				s.EXPECT().SetSpentSession(gomock.Any(), oldKey, gomock.Any(), time.Hour).Return(nil)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(nil, domain.ErrNotFound)
+			},
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockUserRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			test.mockBehavior(userRepo, storeRepo)
+
+			authService := NewAuthService(zap.NewNop(), userRepo, storeRepo, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, testAccessTokens(t))
+			pair, err := authService.RefreshTokens(context.Background(), "old", "app/1.1", "10.0.0.1")
+
+			assert.ErrorIs(t, err, test.expectedError)
+			if test.expectedError == nil {
+				assert.NotEqual(t, "old", pair.RefreshToken)
+			} else {
+				assert.Nil(t, pair)
+			}
+		})
+	}
+}
+
+func TestAuthService_RevokeRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeRepo := mock_service.NewMockStoreRepository(ctrl)
+	storeRepo.EXPECT().DeleteSession(gomock.Any(), refreshSessionKey("token")).Return(nil)
+
+	authService := NewAuthService(nil, nil, storeRepo, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, nil)
+	assert.NoError(t, authService.RevokeRefreshToken(context.Background(), "token"))
+}
+
+func TestAuthService_ParseAccessToken(t *testing.T) {
+	tokens := testAccessTokens(t)
+	token, _, _ := tokens.Issue(&domain.Session{ID: "a", UserID: 7, Role: constants.AdminRole}, time.Now())
+	demoted, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.UserRole)
+	disabled, _ := domain.NewUser(7, "bob", "bob@example.com", "hash", "", constants.UserRole)
+	disabled.SetDisabledAt(time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name          string
+		token         string
+		mockBehavior  func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository)
+		expectedRole  int
+		expectedError error
+	}{
+		{
+			name:  "Role taken from the user",
+			token: token,
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().GetUserSession(gomock.Any(), 7, "a").Return(&domain.Session{ID: "a", UserID: 7}, nil)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(demoted, nil)
+			},
+			expectedRole: constants.UserRole,
+		},
+		{
+			name:  "Session revoked",
+			token: token,
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().GetUserSession(gomock.Any(), 7, "a").Return(nil, domain.ErrNotFound)
+			},
+			expectedError: domain.ErrInvalidToken,
+		},
+		{
+			name:          "Invalid token",
+			token:         token + "x",
+			mockBehavior:  func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {},
+			expectedError: domain.ErrInvalidToken,
+		},
+		{
+			name:  "User disabled",
+			token: token,
+			mockBehavior: func(u *mock_service.MockUserRepository, s *mock_service.MockStoreRepository) {
+				s.EXPECT().GetUserSession(gomock.Any(), 7, "a").Return(&domain.Session{ID: "a", UserID: 7}, nil)
+				u.EXPECT().GetUserByID(gomock.Any(), 7).Return(disabled, nil)
+			},
+			expectedError: domain.ErrDisabled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock_service.NewMockUserRepository(ctrl)
+			storeRepo := mock_service.NewMockStoreRepository(ctrl)
+			test.mockBehavior(userRepo, storeRepo)
+
+			authService := NewAuthService(nil, userRepo, storeRepo, nil, PasswordPolicy{}, nil, LoginThrottle{}, false, tokens)
+			session, err := authService.ParseAccessToken(context.Background(), test.token)
+
+			assert.ErrorIs(t, err, test.expectedError)
+			if test.expectedError == nil {
+				assert.Equal(t, 7, session.UserID)
+				assert.Equal(t, test.expectedRole, session.Role)
+			}
+		})
+	}
+}
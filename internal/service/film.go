@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/common/constants"
 	"github.com/Max425/film-library.git/internal/domain"
 	"go.uber.org/zap"
 )
@@ -11,9 +13,20 @@ type FilmRepository interface {
 	FindFilmByID(ctx context.Context, id int) (*domain.Film, error)
 	UpdateFilm(ctx context.Context, film *domain.Film) (*domain.Film, error)
 	UpdateFilmActors(ctx context.Context, id int, actorsId []int) (*domain.Film, error)
+	AddFilmActor(ctx context.Context, casting domain.Casting) error
+	RemoveFilmActor(ctx context.Context, filmID, actorID int) error
+	AddFilmCrew(ctx context.Context, credit domain.Credit) error
+	RemoveFilmCrew(ctx context.Context, credit domain.Credit) error
+	UpdateFilmGenres(ctx context.Context, id int, genreIDs []int) (*domain.Film, error)
+	AddFilmGenre(ctx context.Context, filmID, genreID int) error
+	RemoveFilmGenre(ctx context.Context, filmID, genreID int) error
 	DeleteFilm(ctx context.Context, id int) error
-	GetAllFilms(ctx context.Context, sortBy, order string) ([]*domain.Film, error)
-	SearchFilms(ctx context.Context, fragment string) ([]*domain.Film, error)
+	GetAllFilms(ctx context.Context, sort []domain.FilmSort, filter domain.FilmFilter, page domain.PageRequest) ([]*domain.Film, *domain.PageInfo, error)
+	GetFilmGenreFacets(ctx context.Context, filter domain.FilmFilter) ([]*domain.GenreCount, error)
+	AddFilmUserStates(ctx context.Context, userID int, films []*domain.Film) error
+	SearchFilms(ctx context.Context, query string, page domain.PageRequest) ([]*domain.FilmMatch, *domain.PageInfo, error)
+	FuzzySearchFilms(ctx context.Context, query string, threshold float64, page domain.PageRequest) ([]*domain.FilmMatch, *domain.PageInfo, error)
+	SuggestSearchTerms(ctx context.Context, query string, threshold float64, limit int) ([]string, error)
 }
 
 type FilmService struct {
@@ -48,7 +61,86 @@ func (s *FilmService) UpdateFilmActors(ctx context.Context, id int, actorsId []i
 		return nil, err
 	}
 
-	return s.filmRepo.UpdateFilmActors(ctx, id, actorsId)
+	return s.filmRepo.UpdateFilmActors(ctx, id, uniqueIDs(actorsId))
+}
+
+func (s *FilmService) AddFilmActor(ctx context.Context, casting domain.Casting) (*domain.Film, error) {
+	if err := casting.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.filmRepo.AddFilmActor(ctx, casting); err != nil {
+		return nil, err
+	}
+
+	return s.filmRepo.FindFilmByID(ctx, casting.FilmID)
+}
+
+func (s *FilmService) RemoveFilmActor(ctx context.Context, filmID, actorID int) (*domain.Film, error) {
+	if err := s.filmRepo.RemoveFilmActor(ctx, filmID, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.filmRepo.FindFilmByID(ctx, filmID)
+}
+
+func (s *FilmService) AddFilmCrew(ctx context.Context, credit domain.Credit) (*domain.Film, error) {
+	if err := credit.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.filmRepo.AddFilmCrew(ctx, credit); err != nil {
+		return nil, err
+	}
+
+	return s.filmRepo.FindFilmByID(ctx, credit.FilmID)
+}
+
+// RemoveFilmCrew removes the credit of a person on a film. Without a job every
+// credit of the person on the film is removed.
+func (s *FilmService) RemoveFilmCrew(ctx context.Context, credit domain.Credit) (*domain.Film, error) {
+	if credit.Job != "" {
+		if err := credit.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.filmRepo.RemoveFilmCrew(ctx, credit); err != nil {
+		return nil, err
+	}
+
+	return s.filmRepo.FindFilmByID(ctx, credit.FilmID)
+}
+
+// UpdateFilmGenres replaces the genres of a film. Repeated ids are ignored.
+func (s *FilmService) UpdateFilmGenres(ctx context.Context, id int, genreIDs []int) (*domain.Film, error) {
+	return s.filmRepo.UpdateFilmGenres(ctx, id, uniqueIDs(genreIDs))
+}
+
+func (s *FilmService) AddFilmGenre(ctx context.Context, filmID, genreID int) (*domain.Film, error) {
+	if err := s.filmRepo.AddFilmGenre(ctx, filmID, genreID); err != nil {
+		return nil, err
+	}
+
+	return s.filmRepo.FindFilmByID(ctx, filmID)
+}
+
+func (s *FilmService) RemoveFilmGenre(ctx context.Context, filmID, genreID int) (*domain.Film, error) {
+	if err := s.filmRepo.RemoveFilmGenre(ctx, filmID, genreID); err != nil {
+		return nil, err
+	}
+
+	return s.filmRepo.FindFilmByID(ctx, filmID)
+}
+
+// uniqueIDs drops repeated ids keeping the order of their first occurrence.
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
 }
 
 func (s *FilmService) DeleteFilm(ctx context.Context, id int) error {
@@ -60,10 +152,57 @@ func (s *FilmService) DeleteFilm(ctx context.Context, id int) error {
 	return s.filmRepo.DeleteFilm(ctx, id)
 }
 
-func (s *FilmService) GetAllFilms(ctx context.Context, sortBy, order string) ([]*domain.Film, error) {
-	return s.filmRepo.GetAllFilms(ctx, sortBy, order)
+func (s *FilmService) GetAllFilms(ctx context.Context, sort []domain.FilmSort, filter domain.FilmFilter, page domain.PageRequest) ([]*domain.Film, *domain.PageInfo, error) {
+	if len(sort) == 0 {
+		sort = domain.DefaultFilmSort
+	}
+	if err := domain.ValidateFilmSort(sort); err != nil {
+		return nil, nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return s.filmRepo.GetAllFilms(ctx, sort, filter, page)
 }
 
-func (s *FilmService) SearchFilms(ctx context.Context, fragment string) ([]*domain.Film, error) {
-	return s.filmRepo.SearchFilms(ctx, fragment)
+// GetFilmGenreFacets counts the films matching the filter in every genre.
+func (s *FilmService) GetFilmGenreFacets(ctx context.Context, filter domain.FilmFilter) ([]*domain.GenreCount, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.filmRepo.GetFilmGenreFacets(ctx, filter)
+}
+
+// AddFilmUserStates tells for each film whether the user keeps it in the
+// watchlist, the watched log or own lists.
+func (s *FilmService) AddFilmUserStates(ctx context.Context, userID int, films []*domain.Film) error {
+	return s.filmRepo.AddFilmUserStates(ctx, userID, films)
+}
+
+// SearchFilms runs a full-text search. When nothing matches the query exactly,
+// it falls back to films with a title, an actor or a crew name at least
+// similarity similar to the query and suggests similar titles and names.
+func (s *FilmService) SearchFilms(ctx context.Context, query string, similarity float64, page domain.PageRequest) (*domain.FilmSearchResult, error) {
+	if similarity <= 0 || similarity > 1 {
+		return nil, fmt.Errorf("%w: similarity should be between 0 and 1", domain.ErrInvalidQuery)
+	}
+
+	matches, info, err := s.filmRepo.SearchFilms(ctx, query, page)
+	if err != nil {
+		return nil, err
+	}
+	if info.Total > 0 {
+		return &domain.FilmSearchResult{Matches: matches, Page: info}, nil
+	}
+
+	if matches, info, err = s.filmRepo.FuzzySearchFilms(ctx, query, similarity, page); err != nil {
+		return nil, err
+	}
+	suggestions, err := s.filmRepo.SuggestSearchTerms(ctx, query, similarity, constants.SuggestionsLimit)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.FilmSearchResult{Matches: matches, Page: info, Fuzzy: true, Suggestions: suggestions}, nil
 }
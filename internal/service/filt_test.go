@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/Max425/film-library.git/internal/domain"
 	"github.com/Max425/film-library.git/mocks/db"
 	"github.com/golang/mock/gomock"
@@ -171,6 +172,7 @@ func TestFilmService_UpdateFilmActors(t *testing.T) {
 		name          string
 		mockBehavior  func(r *mock_service.MockFilmRepository)
 		filmID        int
+		actorIDs      []int
 		expectedFilm  *domain.Film
 		expectedError error
 	}{
@@ -184,6 +186,17 @@ func TestFilmService_UpdateFilmActors(t *testing.T) {
 			expectedFilm:  mockFilm,
 			expectedError: nil,
 		},
+		{
+			name: "Repeated Actor IDs",
+			mockBehavior: func(r *mock_service.MockFilmRepository) {
+				r.EXPECT().FindFilmByID(gomock.Any(), 2).Return(mockFilm, nil)
+				r.EXPECT().UpdateFilmActors(gomock.Any(), 2, []int{3, 1}).Return(mockFilm, nil)
+			},
+			filmID:        2,
+			actorIDs:      []int{3, 1, 3, 1},
+			expectedFilm:  mockFilm,
+			expectedError: nil,
+		},
 		{
 			name: "Error, Finding Film",
 			mockBehavior: func(r *mock_service.MockFilmRepository) {
@@ -214,7 +227,10 @@ func TestFilmService_UpdateFilmActors(t *testing.T) {
 			test.mockBehavior(repo)
 
 			service := NewFilmService(repo, nil)
-			film, err := service.UpdateFilmActors(context.Background(), test.filmID, actorIDs)
+			if test.actorIDs == nil {
+				test.actorIDs = actorIDs
+			}
+			film, err := service.UpdateFilmActors(context.Background(), test.filmID, test.actorIDs)
 
 			assert.Equal(t, test.expectedFilm, film)
 			assert.Equal(t, test.expectedError, err)
@@ -280,9 +296,14 @@ func TestFilmService_GetAllFilms(t *testing.T) {
 	mockFilm2, _ := domain.NewFilm(2, "title", "desc", time.Unix(0, 0), 2.2, nil)
 	mockFilm3, _ := domain.NewFilm(3, "title", "desc", time.Unix(0, 0), 2.2, nil)
 	mockFilms := []*domain.Film{mockFilm1, mockFilm2, mockFilm3}
+	page := domain.PageRequest{Limit: 20}
+
+	minRating, maxRating := 8.0, 5.0
 
 	tests := []struct {
 		name          string
+		sort          []domain.FilmSort
+		filter        domain.FilmFilter
 		mockBehavior  func(r *mock_service.MockFilmRepository)
 		expectedFilms []*domain.Film
 		expectedError error
@@ -290,7 +311,7 @@ func TestFilmService_GetAllFilms(t *testing.T) {
 		{
 			name: "Success",
 			mockBehavior: func(r *mock_service.MockFilmRepository) {
-				r.EXPECT().GetAllFilms(gomock.Any(), "", "").Return(mockFilms, nil)
+				r.EXPECT().GetAllFilms(gomock.Any(), domain.DefaultFilmSort, domain.FilmFilter{}, page).Return(mockFilms, &domain.PageInfo{Total: 3, Limit: 20}, nil)
 			},
 			expectedFilms: mockFilms,
 			expectedError: nil,
@@ -298,11 +319,42 @@ func TestFilmService_GetAllFilms(t *testing.T) {
 		{
 			name: "Error Getting Films",
 			mockBehavior: func(r *mock_service.MockFilmRepository) {
-				r.EXPECT().GetAllFilms(gomock.Any(), "", "").Return(nil, errors.New("get films error"))
+				r.EXPECT().GetAllFilms(gomock.Any(), domain.DefaultFilmSort, domain.FilmFilter{}, page).Return(nil, nil, errors.New("get films error"))
 			},
 			expectedFilms: nil,
 			expectedError: errors.New("get films error"),
 		},
+		{
+			name: "Multi Column Sort",
+			sort: []domain.FilmSort{{Field: domain.FilmSortReleaseDate, Desc: true}, {Field: domain.FilmSortTitle}},
+			mockBehavior: func(r *mock_service.MockFilmRepository) {
+				sort := []domain.FilmSort{{Field: domain.FilmSortReleaseDate, Desc: true}, {Field: domain.FilmSortTitle}}
+				r.EXPECT().GetAllFilms(gomock.Any(), sort, domain.FilmFilter{}, page).Return(mockFilms, &domain.PageInfo{Total: 3, Limit: 20}, nil)
+			},
+			expectedFilms: mockFilms,
+			expectedError: nil,
+		},
+		{
+			name:          "Repeated Sort Field",
+			sort:          []domain.FilmSort{{Field: domain.FilmSortTitle}, {Field: domain.FilmSortTitle, Desc: true}},
+			mockBehavior:  func(r *mock_service.MockFilmRepository) {},
+			expectedFilms: nil,
+			expectedError: fmt.Errorf("%w: field %q is repeated", domain.ErrInvalidSort, domain.FilmSortTitle),
+		},
+		{
+			name:          "Invalid Filter",
+			filter:        domain.FilmFilter{MinRating: &minRating, MaxRating: &maxRating},
+			mockBehavior:  func(r *mock_service.MockFilmRepository) {},
+			expectedFilms: nil,
+			expectedError: fmt.Errorf("%w: rating range is empty", domain.ErrInvalidFilter),
+		},
+		{
+			name:          "Invalid User Rating Filter",
+			filter:        domain.FilmFilter{MinUserRating: &minRating, MaxUserRating: &maxRating},
+			mockBehavior:  func(r *mock_service.MockFilmRepository) {},
+			expectedFilms: nil,
+			expectedError: fmt.Errorf("%w: user rating range is empty", domain.ErrInvalidFilter),
+		},
 	}
 
 	for _, test := range tests {
@@ -314,7 +366,7 @@ func TestFilmService_GetAllFilms(t *testing.T) {
 			test.mockBehavior(repo)
 
 			service := NewFilmService(repo, nil)
-			films, err := service.GetAllFilms(context.Background(), "", "")
+			films, _, err := service.GetAllFilms(context.Background(), test.sort, test.filter, page)
 
 			assert.Equal(t, test.expectedFilms, films)
 			assert.Equal(t, test.expectedError, err)
@@ -326,32 +378,73 @@ func TestFilmService_SearchFilms(t *testing.T) {
 	mockFilm1, _ := domain.NewFilm(1, "title", "desc", time.Unix(0, 0), 2.2, nil)
 	mockFilm2, _ := domain.NewFilm(2, "title", "desc", time.Unix(0, 0), 2.2, nil)
 	mockFilm3, _ := domain.NewFilm(3, "title", "desc", time.Unix(0, 0), 2.2, nil)
-	mockFilms := []*domain.Film{mockFilm1, mockFilm2, mockFilm3}
+	mockMatches := []*domain.FilmMatch{{Film: mockFilm1, Rank: 0.3}, {Film: mockFilm2, Rank: 0.2}, {Film: mockFilm3, Rank: 0.1}}
+	page := domain.PageRequest{Limit: 20}
 
 	tests := []struct {
-		name          string
-		mockBehavior  func(r *mock_service.MockFilmRepository)
-		fragment      string
-		expectedFilms []*domain.Film
-		expectedError error
+		name           string
+		mockBehavior   func(r *mock_service.MockFilmRepository)
+		fragment       string
+		similarity     float64
+		expectedResult *domain.FilmSearchResult
+		expectedError  error
 	}{
 		{
 			name: "Success",
 			mockBehavior: func(r *mock_service.MockFilmRepository) {
-				r.EXPECT().SearchFilms(gomock.Any(), "test").Return(mockFilms, nil)
+				r.EXPECT().SearchFilms(gomock.Any(), "test", page).Return(mockMatches, &domain.PageInfo{Total: 3, Limit: 20}, nil)
+			},
+			fragment:       "test",
+			similarity:     0.3,
+			expectedResult: &domain.FilmSearchResult{Matches: mockMatches, Page: &domain.PageInfo{Total: 3, Limit: 20}},
+			expectedError:  nil,
+		},
+		{
+			name: "Fuzzy Fallback",
+			mockBehavior: func(r *mock_service.MockFilmRepository) {
+				r.EXPECT().SearchFilms(gomock.Any(), "tset", page).Return([]*domain.FilmMatch{}, &domain.PageInfo{Limit: 20}, nil)
+				r.EXPECT().FuzzySearchFilms(gomock.Any(), "tset", 0.4, page).Return(mockMatches, &domain.PageInfo{Total: 3, Limit: 20}, nil)
+				r.EXPECT().SuggestSearchTerms(gomock.Any(), "tset", 0.4, 5).Return([]string{"test", "title"}, nil)
+			},
+			fragment:   "tset",
+			similarity: 0.4,
+			expectedResult: &domain.FilmSearchResult{
+				Matches:     mockMatches,
+				Page:        &domain.PageInfo{Total: 3, Limit: 20},
+				Fuzzy:       true,
+				Suggestions: []string{"test", "title"},
 			},
-			fragment:      "test",
-			expectedFilms: mockFilms,
 			expectedError: nil,
 		},
+		{
+			name:           "Invalid Similarity",
+			mockBehavior:   func(r *mock_service.MockFilmRepository) {},
+			fragment:       "test",
+			similarity:     1.5,
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("%w: similarity should be between 0 and 1", domain.ErrInvalidQuery),
+		},
 		{
 			name: "Error Searching Films",
 			mockBehavior: func(r *mock_service.MockFilmRepository) {
-				r.EXPECT().SearchFilms(gomock.Any(), "test").Return(nil, errors.New("search films error"))
+				r.EXPECT().SearchFilms(gomock.Any(), "test", page).Return(nil, nil, errors.New("search films error"))
 			},
-			fragment:      "test",
-			expectedFilms: nil,
-			expectedError: errors.New("search films error"),
+			fragment:       "test",
+			similarity:     0.3,
+			expectedResult: nil,
+			expectedError:  errors.New("search films error"),
+		},
+		{
+			name: "Error Suggesting Terms",
+			mockBehavior: func(r *mock_service.MockFilmRepository) {
+				r.EXPECT().SearchFilms(gomock.Any(), "tset", page).Return([]*domain.FilmMatch{}, &domain.PageInfo{Limit: 20}, nil)
+				r.EXPECT().FuzzySearchFilms(gomock.Any(), "tset", 0.3, page).Return([]*domain.FilmMatch{}, &domain.PageInfo{Limit: 20}, nil)
+				r.EXPECT().SuggestSearchTerms(gomock.Any(), "tset", 0.3, 5).Return(nil, errors.New("suggest error"))
+			},
+			fragment:       "tset",
+			similarity:     0.3,
+			expectedResult: nil,
+			expectedError:  errors.New("suggest error"),
 		},
 	}
 
@@ -364,10 +457,119 @@ func TestFilmService_SearchFilms(t *testing.T) {
 			test.mockBehavior(repo)
 
 			service := NewFilmService(repo, nil)
-			films, err := service.SearchFilms(context.Background(), test.fragment)
+			result, err := service.SearchFilms(context.Background(), test.fragment, test.similarity, page)
 
-			assert.Equal(t, test.expectedFilms, films)
+			assert.Equal(t, test.expectedResult, result)
 			assert.Equal(t, test.expectedError, err)
 		})
 	}
 }
+
+func TestFilmService_AddFilmCrew(t *testing.T) {
+	mockFilm, _ := domain.NewFilm(1, "Inception", "Dreams", time.Unix(0, 0), 8.8, nil)
+
+	tests := []struct {
+		name          string
+		credit        domain.Credit
+		mockBehavior  func(r *mock_service.MockFilmRepository)
+		expectedFilm  *domain.Film
+		expectedError string
+	}{
+		{
+			name:   "Success",
+			credit: domain.Credit{FilmID: 1, PersonID: 2, Job: domain.JobDirector},
+			mockBehavior: func(r *mock_service.MockFilmRepository) {
+				r.EXPECT().AddFilmCrew(gomock.Any(), domain.Credit{FilmID: 1, PersonID: 2, Job: domain.JobDirector}).Return(nil)
+				r.EXPECT().FindFilmByID(gomock.Any(), 1).Return(mockFilm, nil)
+			},
+			expectedFilm: mockFilm,
+		},
+		{
+			name:   "Person Not Found",
+			credit: domain.Credit{FilmID: 1, PersonID: 2, Job: domain.JobDirector},
+			mockBehavior: func(r *mock_service.MockFilmRepository) {
+				r.EXPECT().AddFilmCrew(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: person 2", domain.ErrNotFound))
+			},
+			expectedError: "not found: person 2",
+		},
+		{
+			name:          "Missing Job",
+			credit:        domain.Credit{FilmID: 1, PersonID: 2},
+			mockBehavior:  func(r *mock_service.MockFilmRepository) {},
+			expectedError: "invalid credit: job is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockFilmRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewFilmService(repo, nil)
+			film, err := service.AddFilmCrew(context.Background(), test.credit)
+
+			assert.Equal(t, test.expectedFilm, film)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestFilmService_RemoveFilmCrew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFilm, _ := domain.NewFilm(1, "Inception", "Dreams", time.Unix(0, 0), 8.8, nil)
+	repo := mock_service.NewMockFilmRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().RemoveFilmCrew(gomock.Any(), domain.Credit{FilmID: 1, PersonID: 2}).Return(nil),
+		repo.EXPECT().FindFilmByID(gomock.Any(), 1).Return(mockFilm, nil),
+	)
+
+	service := NewFilmService(repo, nil)
+	film, err := service.RemoveFilmCrew(context.Background(), domain.Credit{FilmID: 1, PersonID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, mockFilm, film)
+
+	_, err = service.RemoveFilmCrew(context.Background(), domain.Credit{FilmID: 1, PersonID: 2, Job: "gaffer"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCredit)
+}
+
+func TestFilmService_UpdateFilmGenres(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFilm, _ := domain.NewFilm(1, "Inception", "Dreams", time.Unix(0, 0), 8.8, nil)
+	repo := mock_service.NewMockFilmRepository(ctrl)
+	repo.EXPECT().UpdateFilmGenres(gomock.Any(), 1, []int{2, 3}).Return(mockFilm, nil)
+
+	service := NewFilmService(repo, nil)
+	film, err := service.UpdateFilmGenres(context.Background(), 1, []int{2, 3, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, mockFilm, film)
+}
+
+func TestFilmService_GetFilmGenreFacets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	drama, _ := domain.NewGenre(1, "Drama")
+	counts := []*domain.GenreCount{{Genre: drama, Count: 4}}
+	filter := domain.FilmFilter{GenreIDs: []int{1}}
+	repo := mock_service.NewMockFilmRepository(ctrl)
+	repo.EXPECT().GetFilmGenreFacets(gomock.Any(), filter).Return(counts, nil)
+
+	service := NewFilmService(repo, nil)
+	result, err := service.GetFilmGenreFacets(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, counts, result)
+
+	_, err = service.GetFilmGenreFacets(context.Background(), domain.FilmFilter{GenreIDs: []int{1}, GenreMatch: "some"})
+	assert.EqualError(t, err, "invalid filter: genre match must be any/all")
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"github.com/Max425/film-library.git/internal/domain"
+	"go.uber.org/zap"
+)
+
+type GenreRepository interface {
+	CreateGenre(ctx context.Context, genre *domain.Genre) (*domain.Genre, error)
+	FindGenreByID(ctx context.Context, id int) (*domain.Genre, error)
+	UpdateGenre(ctx context.Context, genre *domain.Genre) (*domain.Genre, error)
+	DeleteGenre(ctx context.Context, id int) error
+	GetAllGenres(ctx context.Context) ([]*domain.Genre, error)
+}
+
+type GenreService struct {
+	log       *zap.Logger
+	genreRepo GenreRepository
+}
+
+func NewGenreService(genreRepo GenreRepository, log *zap.Logger) *GenreService {
+	return &GenreService{genreRepo: genreRepo, log: log}
+}
+
+func (s *GenreService) CreateGenre(ctx context.Context, genre *domain.Genre) (*domain.Genre, error) {
+	return s.genreRepo.CreateGenre(ctx, genre)
+}
+
+func (s *GenreService) GetGenreByID(ctx context.Context, id int) (*domain.Genre, error) {
+	return s.genreRepo.FindGenreByID(ctx, id)
+}
+
+func (s *GenreService) UpdateGenre(ctx context.Context, genre *domain.Genre) (*domain.Genre, error) {
+	_, err := s.genreRepo.FindGenreByID(ctx, genre.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.genreRepo.UpdateGenre(ctx, genre)
+}
+
+func (s *GenreService) DeleteGenre(ctx context.Context, id int) error {
+	_, err := s.genreRepo.FindGenreByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.genreRepo.DeleteGenre(ctx, id)
+}
+
+func (s *GenreService) GetAllGenres(ctx context.Context) ([]*domain.Genre, error) {
+	return s.genreRepo.GetAllGenres(ctx)
+}
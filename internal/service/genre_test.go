@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/mocks/db"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGenreService_UpdateGenre(t *testing.T) {
+	mockGenre, _ := domain.NewGenre(1, "Drama")
+
+	tests := []struct {
+		name          string
+		mockBehavior  func(r *mock_service.MockGenreRepository)
+		expectedGenre *domain.Genre
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockBehavior: func(r *mock_service.MockGenreRepository) {
+				r.EXPECT().FindGenreByID(gomock.Any(), 1).Return(mockGenre, nil)
+				r.EXPECT().UpdateGenre(gomock.Any(), mockGenre).Return(mockGenre, nil)
+			},
+			expectedGenre: mockGenre,
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func(r *mock_service.MockGenreRepository) {
+				r.EXPECT().FindGenreByID(gomock.Any(), 1).Return(nil, domain.ErrNotFound)
+			},
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockGenreRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewGenreService(repo, nil)
+			genre, err := service.UpdateGenre(context.Background(), mockGenre)
+
+			assert.Equal(t, test.expectedGenre, genre)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestGenreService_DeleteGenre(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockBehavior  func(r *mock_service.MockGenreRepository)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockBehavior: func(r *mock_service.MockGenreRepository) {
+				r.EXPECT().FindGenreByID(gomock.Any(), 1).Return(&domain.Genre{}, nil)
+				r.EXPECT().DeleteGenre(gomock.Any(), 1).Return(nil)
+			},
+		},
+		{
+			name: "Error Deleting Genre",
+			mockBehavior: func(r *mock_service.MockGenreRepository) {
+				r.EXPECT().FindGenreByID(gomock.Any(), 1).Return(&domain.Genre{}, nil)
+				r.EXPECT().DeleteGenre(gomock.Any(), 1).Return(errors.New("delete genre error"))
+			},
+			expectedError: errors.New("delete genre error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockGenreRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewGenreService(repo, nil)
+			err := service.DeleteGenre(context.Background(), 1)
+
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+type ListRepository interface {
+	AddToWatchlist(ctx context.Context, userID, filmID int) error
+	RemoveFromWatchlist(ctx context.Context, userID, filmID int) error
+	GetWatchlist(ctx context.Context, userID int) ([]*domain.Film, error)
+	AddWatched(ctx context.Context, entry domain.WatchEntry) (*domain.WatchEntry, error)
+	DeleteWatched(ctx context.Context, userID, id int) error
+	GetWatched(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.WatchEntry, *domain.PageInfo, error)
+	CreateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error)
+	FindFilmListByID(ctx context.Context, userID, id int) (*domain.FilmList, error)
+	FindSharedFilmList(ctx context.Context, token string) (*domain.FilmList, error)
+	UpdateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error)
+	DeleteFilmList(ctx context.Context, userID, id int) error
+	GetFilmLists(ctx context.Context, userID int) ([]*domain.FilmList, error)
+	AddFilmListItem(ctx context.Context, userID, listID, filmID int) error
+	RemoveFilmListItem(ctx context.Context, userID, listID, filmID int) error
+	SetFilmListShareToken(ctx context.Context, userID, id int, token string) error
+}
+
+type ListService struct {
+	log      *zap.Logger
+	listRepo ListRepository
+}
+
+func NewListService(listRepo ListRepository, log *zap.Logger) *ListService {
+	return &ListService{listRepo: listRepo, log: log}
+}
+
+func (s *ListService) AddToWatchlist(ctx context.Context, userID, filmID int) ([]*domain.Film, error) {
+	if err := s.listRepo.AddToWatchlist(ctx, userID, filmID); err != nil {
+		return nil, err
+	}
+	return s.listRepo.GetWatchlist(ctx, userID)
+}
+
+func (s *ListService) RemoveFromWatchlist(ctx context.Context, userID, filmID int) ([]*domain.Film, error) {
+	if err := s.listRepo.RemoveFromWatchlist(ctx, userID, filmID); err != nil {
+		return nil, err
+	}
+	return s.listRepo.GetWatchlist(ctx, userID)
+}
+
+func (s *ListService) GetWatchlist(ctx context.Context, userID int) ([]*domain.Film, error) {
+	return s.listRepo.GetWatchlist(ctx, userID)
+}
+
+// AddWatched logs a watched film, the film is taken as watched today when
+// the entry has no date.
+func (s *ListService) AddWatched(ctx context.Context, entry domain.WatchEntry) (*domain.WatchEntry, error) {
+	if entry.WatchedAt.IsZero() {
+		year, month, day := time.Now().Date()
+		entry.WatchedAt = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	return s.listRepo.AddWatched(ctx, entry)
+}
+
+func (s *ListService) DeleteWatched(ctx context.Context, userID, id int) error {
+	return s.listRepo.DeleteWatched(ctx, userID, id)
+}
+
+func (s *ListService) GetWatched(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.WatchEntry, *domain.PageInfo, error) {
+	return s.listRepo.GetWatched(ctx, userID, page)
+}
+
+func (s *ListService) CreateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error) {
+	return s.listRepo.CreateFilmList(ctx, list)
+}
+
+func (s *ListService) GetFilmList(ctx context.Context, userID, id int) (*domain.FilmList, error) {
+	return s.listRepo.FindFilmListByID(ctx, userID, id)
+}
+
+// GetSharedFilmList returns the list shared by the token, malformed tokens share nothing.
+func (s *ListService) GetSharedFilmList(ctx context.Context, token string) (*domain.FilmList, error) {
+	if _, err := uuid.Parse(token); err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return s.listRepo.FindSharedFilmList(ctx, token)
+}
+
+func (s *ListService) UpdateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error) {
+	return s.listRepo.UpdateFilmList(ctx, list)
+}
+
+func (s *ListService) DeleteFilmList(ctx context.Context, userID, id int) error {
+	return s.listRepo.DeleteFilmList(ctx, userID, id)
+}
+
+func (s *ListService) GetFilmLists(ctx context.Context, userID int) ([]*domain.FilmList, error) {
+	return s.listRepo.GetFilmLists(ctx, userID)
+}
+
+func (s *ListService) AddFilmListItem(ctx context.Context, userID, listID, filmID int) (*domain.FilmList, error) {
+	if err := s.listRepo.AddFilmListItem(ctx, userID, listID, filmID); err != nil {
+		return nil, err
+	}
+	return s.listRepo.FindFilmListByID(ctx, userID, listID)
+}
+
+func (s *ListService) RemoveFilmListItem(ctx context.Context, userID, listID, filmID int) (*domain.FilmList, error) {
+	if err := s.listRepo.RemoveFilmListItem(ctx, userID, listID, filmID); err != nil {
+		return nil, err
+	}
+	return s.listRepo.FindFilmListByID(ctx, userID, listID)
+}
+
+// ShareFilmList shares the list by a new token, links with an earlier token stop working.
+func (s *ListService) ShareFilmList(ctx context.Context, userID, id int) (*domain.FilmList, error) {
+	if err := s.listRepo.SetFilmListShareToken(ctx, userID, id, GenerateUuid()); err != nil {
+		return nil, err
+	}
+	return s.listRepo.FindFilmListByID(ctx, userID, id)
+}
+
+func (s *ListService) UnshareFilmList(ctx context.Context, userID, id int) error {
+	return s.listRepo.SetFilmListShareToken(ctx, userID, id, "")
+}
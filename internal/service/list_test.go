@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/mocks/db"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestListService_AddWatched(t *testing.T) {
+	year, month, day := time.Now().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	watchedAt := time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		entry         domain.WatchEntry
+		mockBehavior  func(r *mock_service.MockListRepository)
+		expectedError error
+	}{
+		{
+			name:  "With date",
+			entry: domain.WatchEntry{UserID: 7, FilmID: 5, WatchedAt: watchedAt},
+			mockBehavior: func(r *mock_service.MockListRepository) {
+				r.EXPECT().AddWatched(gomock.Any(), domain.WatchEntry{UserID: 7, FilmID: 5, WatchedAt: watchedAt}).
+					Return(&domain.WatchEntry{ID: 11}, nil)
+			},
+		},
+		{
+			name:  "Today by default",
+			entry: domain.WatchEntry{UserID: 7, FilmID: 5},
+			mockBehavior: func(r *mock_service.MockListRepository) {
+				r.EXPECT().AddWatched(gomock.Any(), domain.WatchEntry{UserID: 7, FilmID: 5, WatchedAt: today}).
+					Return(&domain.WatchEntry{ID: 11}, nil)
+			},
+		},
+		{
+			name:          "Future date",
+			entry:         domain.WatchEntry{UserID: 7, FilmID: 5, WatchedAt: time.Now().AddDate(0, 0, 2)},
+			mockBehavior:  func(r *mock_service.MockListRepository) {},
+			expectedError: domain.ErrInvalidWatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockListRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewListService(repo, nil)
+			entry, err := service.AddWatched(context.Background(), test.entry)
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, 11, entry.ID)
+			} else {
+				assert.ErrorIs(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestListService_GetSharedFilmList(t *testing.T) {
+	sharedList, _ := domain.NewFilmList(4, 7, "Noir")
+	tests := []struct {
+		name          string
+		token         string
+		mockBehavior  func(r *mock_service.MockListRepository)
+		expectedError error
+	}{
+		{
+			name:  "Ok",
+			token: "0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7",
+			mockBehavior: func(r *mock_service.MockListRepository) {
+				r.EXPECT().FindSharedFilmList(gomock.Any(), "0b8e9d52-2bd4-4a43-9c4e-59a1f1d1e1a7").Return(sharedList, nil)
+			},
+		},
+		{
+			name:          "Malformed token",
+			token:         "not-a-uuid",
+			mockBehavior:  func(r *mock_service.MockListRepository) {},
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockListRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewListService(repo, nil)
+			list, err := service.GetSharedFilmList(context.Background(), test.token)
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, sharedList, list)
+			} else {
+				assert.ErrorIs(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestListService_ShareFilmList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sharedList, _ := domain.NewFilmList(4, 7, "Noir")
+
+	repo := mock_service.NewMockListRepository(ctrl)
+	var token string
+	repo.EXPECT().SetFilmListShareToken(gomock.Any(), 7, 4, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ int, newToken string) error {
+			token = newToken
+			return nil
+		})
+	repo.EXPECT().FindFilmListByID(gomock.Any(), 7, 4).Return(sharedList, nil)
+
+	service := NewListService(repo, nil)
+	list, err := service.ShareFilmList(context.Background(), 7, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, sharedList, list)
+	assert.Len(t, token, 36)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"github.com/Max425/film-library.git/internal/domain"
+	"net/netip"
+	"time"
+)
+
+// LoginThrottle slows down guessing of passwords. Each failed attempt on an
+// account makes the next one wait twice as long as the one before, the
+// account is locked once the failures reach MaxAttempts within Window. An
+// address is only locked, after MaxIPAttempts failures, so users behind one
+// address do not slow each other down.
+type LoginThrottle struct {
+	MaxAttempts   int
+	MaxIPAttempts int
+	Window        time.Duration
+	Lockout       time.Duration
+}
+
+// NewLoginThrottle returns the throttle, 5 failures per account and 50 per
+// address within 15 minutes lock for 15 minutes where the settings are left
+// at zero.
+func NewLoginThrottle(maxAttempts, maxIPAttempts int, window, lockout time.Duration) LoginThrottle {
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+	if maxIPAttempts <= 0 {
+		maxIPAttempts = 50
+	}
+	if window <= 0 {
+		window = 15 * time.Minute
+	}
+	if lockout <= 0 {
+		lockout = 15 * time.Minute
+	}
+	return LoginThrottle{MaxAttempts: maxAttempts, MaxIPAttempts: maxIPAttempts, Window: window, Lockout: lockout}
+}
+
+// delay returns how long an account waits after the failures: nothing after
+// the first one, then a second doubling with each failure, and the lockout
+// once the failures reach the limit.
+func (t LoginThrottle) delay(failures int) time.Duration {
+	if failures >= t.MaxAttempts {
+		return t.Lockout
+	}
+	if failures <= 1 {
+		return 0
+	}
+	delay := time.Second << (failures - 2)
+	if delay <= 0 || delay > t.Lockout {
+		return t.Lockout
+	}
+	return delay
+}
+
+// mailLoginKey counts the account by its normalized mail, as it is looked up,
+// so spelling the mail in another case does not start a new count.
+func mailLoginKey(mail string) string {
+	return "mail:" + domain.NormalizeMail(mail)
+}
+
+// ipLoginKey counts an IPv6 client by its /64 network, a single host usually
+// owns the whole network and could take a new address for every attempt.
+func ipLoginKey(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "ip:" + ip
+	}
+	addr = addr.Unmap()
+	if addr.Is6() {
+		prefix, _ := addr.Prefix(64)
+		return "ip:" + prefix.String()
+	}
+	return "ip:" + addr.String()
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottle_Delay(t *testing.T) {
+	throttle := NewLoginThrottle(0, 0, 0, 0)
+
+	assert.Equal(t, time.Duration(0), throttle.delay(1))
+	assert.Equal(t, time.Second, throttle.delay(2))
+	assert.Equal(t, 2*time.Second, throttle.delay(3))
+	assert.Equal(t, 4*time.Second, throttle.delay(4))
+	assert.Equal(t, 15*time.Minute, throttle.delay(5))
+	assert.Equal(t, 15*time.Minute, throttle.delay(100))
+}
+
+func TestLoginThrottle_DelayCappedByLockout(t *testing.T) {
+	throttle := NewLoginThrottle(100, 0, time.Hour, 10*time.Second)
+
+	assert.Equal(t, 8*time.Second, throttle.delay(5))
+	assert.Equal(t, 10*time.Second, throttle.delay(6))
+	assert.Equal(t, 10*time.Second, throttle.delay(80))
+}
+
+func TestIPLoginKey(t *testing.T) {
+	assert.Equal(t, "ip:192.0.2.1", ipLoginKey("192.0.2.1"))
+	assert.Equal(t, "ip:192.0.2.1", ipLoginKey("::ffff:192.0.2.1"))
+	assert.Equal(t, "ip:2001:db8:1:2::/64", ipLoginKey("2001:db8:1:2::1"))
+	assert.Equal(t, ipLoginKey("2001:db8:1:2::1"), ipLoginKey("2001:db8:1:2:ffff::7"))
+}
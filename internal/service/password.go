@@ -0,0 +1,190 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHasher makes password hashes in a self-describing format, so a
+// hash can be checked later whatever settings are in use by then.
+type PasswordHasher interface {
+	// Hash returns the hash of the password.
+	Hash(password string) (string, error)
+	// Verify checks the password against a hash this hasher recognizes.
+	Verify(password, hash string) (bool, error)
+	// Recognizes reports whether the hash is in the format of the hasher.
+	Recognizes(hash string) bool
+	// NeedsRehash reports whether a recognized hash was made with other
+	// settings than the hasher uses now.
+	NeedsRehash(hash string) bool
+}
+
+// NewPasswordHasher returns the hasher by its name, argon2id when the name is empty.
+func NewPasswordHasher(name string) (PasswordHasher, error) {
+	switch name {
+	case "", "argon2id":
+		return NewArgon2idHasher(), nil
+	case "bcrypt":
+		return NewBcryptHasher(bcrypt.DefaultCost), nil
+	default:
+		return nil, fmt.Errorf("unknown password hasher %q", name)
+	}
+}
+
+// VerifyPassword checks the password against a hash made by any known hasher.
+// rehash is set when the password is right and the hash should be replaced
+// by one made with the preferred hasher. Unknown hashes match no password.
+func VerifyPassword(preferred PasswordHasher, password, hash string) (ok, rehash bool, err error) {
+	hashers := []PasswordHasher{preferred, NewArgon2idHasher(), NewBcryptHasher(bcrypt.DefaultCost), legacySHA1Hasher{}}
+	for i, hasher := range hashers {
+		if !hasher.Recognizes(hash) {
+			continue
+		}
+		if ok, err = hasher.Verify(password, hash); err != nil || !ok {
+			return false, false, err
+		}
+		return true, i > 0 || hasher.NeedsRehash(hash), nil
+	}
+	return false, false, nil
+}
+
+// Argon2idHasher makes argon2id hashes in the PHC string format,
+// $argon2id$v=19$m=19456,t=2,p=1$<salt>$<key>.
+type Argon2idHasher struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	keyLen  uint32
+	saltLen int
+}
+
+// NewArgon2idHasher returns the hasher with the OWASP recommended settings.
+func NewArgon2idHasher() *Argon2idHasher {
+	return &Argon2idHasher{memory: 19 * 1024, time: 2, threads: 1, keyLen: 32, saltLen: 16}
+}
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.time, h.memory, h.threads, h.keyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.memory, h.time, h.threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *Argon2idHasher) Verify(password, hash string) (bool, error) {
+	params, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return false, err
+	}
+	other := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+func (h *Argon2idHasher) Recognizes(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+func (h *Argon2idHasher) NeedsRehash(hash string) bool {
+	params, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return params.memory != h.memory || params.time != h.time || params.threads != h.threads ||
+		len(salt) != h.saltLen || uint32(len(key)) != h.keyLen
+}
+
+func parseArgon2id(hash string) (*Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, errors.New("malformed argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, errors.New("unsupported argon2id version")
+	}
+	params := &Argon2idHasher{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return nil, nil, nil, errors.New("malformed argon2id parameters")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, errors.New("malformed argon2id salt")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, errors.New("malformed argon2id key")
+	}
+	return params, salt, key, nil
+}
+
+// BcryptHasher makes bcrypt hashes in the modular crypt format, $2a$10$<salt and key>.
+type BcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{cost: cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptHasher) Verify(password, hash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (h *BcryptHasher) Recognizes(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func (h *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
+}
+
+// legacySHA1Hasher checks the hashes made by GeneratePasswordHash before the
+// move to argon2id. The salt in them is only put in front of the digest, so
+// each one carries its salt and is always rehashed once the password matches.
+type legacySHA1Hasher struct{}
+
+func (legacySHA1Hasher) Hash(string) (string, error) {
+	return "", errors.New("sha1 password hashes are no longer made")
+}
+
+func (legacySHA1Hasher) Verify(password, hash string) (bool, error) {
+	raw, err := hex.DecodeString(hash)
+	if err != nil || len(raw) < sha1.Size {
+		return false, nil
+	}
+	salt := string(raw[:len(raw)-sha1.Size])
+	return subtle.ConstantTimeCompare([]byte(GeneratePasswordHash(password, salt)), []byte(hash)) == 1, nil
+}
+
+func (legacySHA1Hasher) Recognizes(hash string) bool {
+	raw, err := hex.DecodeString(hash)
+	return err == nil && len(raw) >= sha1.Size
+}
+
+func (legacySHA1Hasher) NeedsRehash(string) bool {
+	return true
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Max425/film-library.git/internal/domain"
+)
+
+// maxPasswordLength is the most bytes bcrypt takes into account.
+const maxPasswordLength = 72
+
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+// commonPasswords are passwords found first in every leaked password list.
+var commonPasswords = func() map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, password := range strings.Fields(commonPasswordsList) {
+		passwords[password] = struct{}{}
+	}
+	return passwords
+}()
+
+// PasswordPolicy is what a new password has to satisfy.
+type PasswordPolicy struct {
+	MinLength int
+	// MinClasses is how many of lower case letters, upper case letters,
+	// digits and other characters the password has to mix.
+	MinClasses int
+}
+
+// NewPasswordPolicy returns the policy, at least 8 characters of 2 classes
+// where the settings are left at zero.
+func NewPasswordPolicy(minLength, minClasses int) PasswordPolicy {
+	if minLength <= 0 {
+		minLength = 8
+	}
+	if minClasses <= 0 {
+		minClasses = 2
+	}
+	return PasswordPolicy{MinLength: minLength, MinClasses: minClasses}
+}
+
+// Validate checks the password against the policy and the common passwords.
+func (p PasswordPolicy) Validate(password string) error {
+	if length := len([]rune(password)); length < p.MinLength {
+		return fmt.Errorf("%w: password should be at least %d characters long", domain.ErrWeakPassword, p.MinLength)
+	}
+	if len(password) > maxPasswordLength {
+		return fmt.Errorf("%w: password should not exceed %d bytes", domain.ErrWeakPassword, maxPasswordLength)
+	}
+
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	if lower+upper+digit+other < p.MinClasses {
+		return fmt.Errorf("%w: password should mix at least %d of lower case letters, upper case letters, digits and symbols",
+			domain.ErrWeakPassword, p.MinClasses)
+	}
+
+	if _, ok := commonPasswords[strings.ToLower(password)]; ok {
+		return fmt.Errorf("%w: password is too common", domain.ErrWeakPassword)
+	}
+	return nil
+}
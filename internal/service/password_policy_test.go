@@ -0,0 +1,38 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        PasswordPolicy
+		password      string
+		expectedError error
+	}{
+		{name: "Strong", policy: NewPasswordPolicy(0, 0), password: "Popcorn-2024"},
+		{name: "Too short", policy: NewPasswordPolicy(0, 0), password: "Pop-24", expectedError: domain.ErrWeakPassword},
+		{name: "Too long", policy: NewPasswordPolicy(0, 0), password: strings.Repeat("Ab1", 25), expectedError: domain.ErrWeakPassword},
+		{name: "One class", policy: NewPasswordPolicy(0, 0), password: "popcornpopcorn", expectedError: domain.ErrWeakPassword},
+		{name: "Common", policy: NewPasswordPolicy(0, 0), password: "Password1", expectedError: domain.ErrWeakPassword},
+		{name: "Custom length", policy: NewPasswordPolicy(12, 0), password: "Popcorn-24", expectedError: domain.ErrWeakPassword},
+		{name: "Custom classes", policy: NewPasswordPolicy(0, 4), password: "Popcorn2024", expectedError: domain.ErrWeakPassword},
+		{name: "Multibyte length", policy: NewPasswordPolicy(0, 0), password: "Попкорн-1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy.Validate(test.password)
+			if test.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+func TestArgon2idHasher(t *testing.T) {
+	hasher := NewArgon2idHasher()
+
+	hash, err := hasher.Hash("password")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=19456,t=2,p=1\$[A-Za-z0-9+/]{22}\$[A-Za-z0-9+/]{43}$`, hash)
+	assert.True(t, hasher.Recognizes(hash))
+	assert.False(t, hasher.NeedsRehash(hash))
+
+	ok, err := hasher.Verify("password", hash)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify("wrongpassword", hash)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	stronger := &Argon2idHasher{memory: 64 * 1024, time: 3, threads: 4, keyLen: 32, saltLen: 16}
+	assert.True(t, stronger.NeedsRehash(hash))
+	ok, err = stronger.Verify("password", hash)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = hasher.Verify("password", "$argon2id$v=19$m=19456$broken")
+	assert.Error(t, err)
+}
+
+func TestBcryptHasher(t *testing.T) {
+	hasher := NewBcryptHasher(bcrypt.MinCost)
+
+	hash, err := hasher.Hash("password")
+	assert.NoError(t, err)
+	assert.True(t, hasher.Recognizes(hash))
+	assert.False(t, hasher.NeedsRehash(hash))
+	assert.True(t, NewBcryptHasher(bcrypt.MinCost+1).NeedsRehash(hash))
+
+	ok, err := hasher.Verify("password", hash)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify("wrongpassword", hash)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestVerifyPassword(t *testing.T) {
+	argon2id := NewArgon2idHasher()
+	argon2idHash, _ := argon2id.Hash("password")
+	bcryptHash, _ := NewBcryptHasher(bcrypt.DefaultCost).Hash("password")
+	// the hash of the seeded admin user
+	legacyHash := "32393837663431632d646231662d346264332d613139302d336466653830326239383232d033e22ae348aeb5660fc2140aec35850c4da997"
+
+	tests := []struct {
+		name           string
+		preferred      PasswordHasher
+		password       string
+		hash           string
+		expectedOk     bool
+		expectedRehash bool
+	}{
+		{name: "Argon2id preferred", preferred: argon2id, password: "password", hash: argon2idHash, expectedOk: true},
+		{name: "Bcrypt under argon2id", preferred: argon2id, password: "password", hash: bcryptHash, expectedOk: true, expectedRehash: true},
+		{name: "Argon2id under bcrypt", preferred: NewBcryptHasher(bcrypt.DefaultCost), password: "password", hash: argon2idHash, expectedOk: true, expectedRehash: true},
+		{name: "Legacy sha1", preferred: argon2id, password: "admin", hash: legacyHash, expectedOk: true, expectedRehash: true},
+		{name: "Legacy sha1 wrong password", preferred: argon2id, password: "password", hash: legacyHash},
+		{name: "Wrong password", preferred: argon2id, password: "wrongpassword", hash: argon2idHash},
+		{name: "Unknown hash", preferred: argon2id, password: "password", hash: "password"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ok, rehash, err := VerifyPassword(test.preferred, test.password, test.hash)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedOk, ok)
+			assert.Equal(t, test.expectedRehash, rehash)
+		})
+	}
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	hasher, err := NewPasswordHasher("")
+	assert.NoError(t, err)
+	assert.IsType(t, &Argon2idHasher{}, hasher)
+
+	hasher, err = NewPasswordHasher("bcrypt")
+	assert.NoError(t, err)
+	assert.IsType(t, &BcryptHasher{}, hasher)
+
+	_, err = NewPasswordHasher("md5")
+	assert.EqualError(t, err, `unknown password hasher "md5"`)
+}
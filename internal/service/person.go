@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"github.com/Max425/film-library.git/internal/domain"
+	"go.uber.org/zap"
+)
+
+type PersonRepository interface {
+	CreatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error)
+	FindPersonByID(ctx context.Context, id int) (*domain.Person, error)
+	UpdatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error)
+	DeletePerson(ctx context.Context, id int) error
+	GetAllPersons(ctx context.Context, filter domain.PersonFilter, page domain.PageRequest) ([]*domain.Person, *domain.PageInfo, error)
+}
+
+// CrewRepository changes single film_person credits.
+type CrewRepository interface {
+	AddFilmCrew(ctx context.Context, credit domain.Credit) error
+	RemoveFilmCrew(ctx context.Context, credit domain.Credit) error
+}
+
+type PersonService struct {
+	log        *zap.Logger
+	personRepo PersonRepository
+	crewRepo   CrewRepository
+}
+
+func NewPersonService(personRepo PersonRepository, crewRepo CrewRepository, log *zap.Logger) *PersonService {
+	return &PersonService{personRepo: personRepo, crewRepo: crewRepo, log: log}
+}
+
+func (s *PersonService) CreatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error) {
+	return s.personRepo.CreatePerson(ctx, person)
+}
+
+func (s *PersonService) GetPersonByID(ctx context.Context, id int) (*domain.Person, error) {
+	return s.personRepo.FindPersonByID(ctx, id)
+}
+
+func (s *PersonService) UpdatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error) {
+	_, err := s.personRepo.FindPersonByID(ctx, person.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.personRepo.UpdatePerson(ctx, person)
+}
+
+func (s *PersonService) DeletePerson(ctx context.Context, id int) error {
+	_, err := s.personRepo.FindPersonByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.personRepo.DeletePerson(ctx, id)
+}
+
+func (s *PersonService) GetAllPersons(ctx context.Context, filter domain.PersonFilter, page domain.PageRequest) ([]*domain.Person, *domain.PageInfo, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return s.personRepo.GetAllPersons(ctx, filter, page)
+}
+
+// AddPersonFilm credits the person with the job on the film.
+func (s *PersonService) AddPersonFilm(ctx context.Context, credit domain.Credit) (*domain.Person, error) {
+	if err := credit.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.crewRepo.AddFilmCrew(ctx, credit); err != nil {
+		return nil, err
+	}
+
+	return s.personRepo.FindPersonByID(ctx, credit.PersonID)
+}
+
+// RemovePersonFilm removes the credit of the person on the film. Without a job
+// every credit of the person on the film is removed.
+func (s *PersonService) RemovePersonFilm(ctx context.Context, credit domain.Credit) (*domain.Person, error) {
+	if credit.Job != "" {
+		if err := credit.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.crewRepo.RemoveFilmCrew(ctx, credit); err != nil {
+		return nil, err
+	}
+
+	return s.personRepo.FindPersonByID(ctx, credit.PersonID)
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/mocks/db"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPersonService_UpdatePerson(t *testing.T) {
+	mockPerson, _ := domain.NewPerson(1, "Hans Zimmer", nil)
+
+	tests := []struct {
+		name           string
+		mockBehavior   func(r *mock_service.MockPersonRepository)
+		expectedPerson *domain.Person
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockBehavior: func(r *mock_service.MockPersonRepository) {
+				r.EXPECT().FindPersonByID(gomock.Any(), 1).Return(mockPerson, nil)
+				r.EXPECT().UpdatePerson(gomock.Any(), mockPerson).Return(mockPerson, nil)
+			},
+			expectedPerson: mockPerson,
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func(r *mock_service.MockPersonRepository) {
+				r.EXPECT().FindPersonByID(gomock.Any(), 1).Return(nil, domain.ErrNotFound)
+			},
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockPersonRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewPersonService(repo, nil, nil)
+			person, err := service.UpdatePerson(context.Background(), mockPerson)
+
+			assert.Equal(t, test.expectedPerson, person)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestPersonService_DeletePerson(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockBehavior  func(r *mock_service.MockPersonRepository)
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockBehavior: func(r *mock_service.MockPersonRepository) {
+				r.EXPECT().FindPersonByID(gomock.Any(), 1).Return(&domain.Person{}, nil)
+				r.EXPECT().DeletePerson(gomock.Any(), 1).Return(nil)
+			},
+		},
+		{
+			name: "Error Deleting Person",
+			mockBehavior: func(r *mock_service.MockPersonRepository) {
+				r.EXPECT().FindPersonByID(gomock.Any(), 1).Return(&domain.Person{}, nil)
+				r.EXPECT().DeletePerson(gomock.Any(), 1).Return(errors.New("delete person error"))
+			},
+			expectedError: errors.New("delete person error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockPersonRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewPersonService(repo, nil, nil)
+			err := service.DeletePerson(context.Background(), 1)
+
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestPersonService_GetAllPersons(t *testing.T) {
+	mockPerson, _ := domain.NewPerson(1, "Hans Zimmer", nil)
+	page := domain.PageRequest{Limit: 20}
+
+	tests := []struct {
+		name            string
+		filter          domain.PersonFilter
+		mockBehavior    func(r *mock_service.MockPersonRepository)
+		expectedPersons []*domain.Person
+		expectedError   error
+	}{
+		{
+			name:   "Success",
+			filter: domain.PersonFilter{Job: domain.JobComposer},
+			mockBehavior: func(r *mock_service.MockPersonRepository) {
+				r.EXPECT().GetAllPersons(gomock.Any(), domain.PersonFilter{Job: domain.JobComposer}, page).
+					Return([]*domain.Person{mockPerson}, &domain.PageInfo{Total: 1, Limit: 20}, nil)
+			},
+			expectedPersons: []*domain.Person{mockPerson},
+		},
+		{
+			name:          "Invalid Job",
+			filter:        domain.PersonFilter{Job: "gaffer"},
+			mockBehavior:  func(r *mock_service.MockPersonRepository) {},
+			expectedError: fmt.Errorf("%w: job must be director/writer/composer/producer", domain.ErrInvalidFilter),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockPersonRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewPersonService(repo, nil, nil)
+			persons, _, err := service.GetAllPersons(context.Background(), test.filter, page)
+
+			assert.Equal(t, test.expectedPersons, persons)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestPersonService_AddPersonFilm(t *testing.T) {
+	mockPerson, _ := domain.NewPerson(1, "Hans Zimmer", nil)
+
+	tests := []struct {
+		name           string
+		credit         domain.Credit
+		mockBehavior   func(p *mock_service.MockPersonRepository, c *mock_service.MockCrewRepository)
+		expectedPerson *domain.Person
+		expectedError  string
+	}{
+		{
+			name:   "Success",
+			credit: domain.Credit{FilmID: 2, PersonID: 1, Job: domain.JobComposer},
+			mockBehavior: func(p *mock_service.MockPersonRepository, c *mock_service.MockCrewRepository) {
+				c.EXPECT().AddFilmCrew(gomock.Any(), domain.Credit{FilmID: 2, PersonID: 1, Job: domain.JobComposer}).Return(nil)
+				p.EXPECT().FindPersonByID(gomock.Any(), 1).Return(mockPerson, nil)
+			},
+			expectedPerson: mockPerson,
+		},
+		{
+			name:   "Film Not Found",
+			credit: domain.Credit{FilmID: 2, PersonID: 1, Job: domain.JobComposer},
+			mockBehavior: func(p *mock_service.MockPersonRepository, c *mock_service.MockCrewRepository) {
+				c.EXPECT().AddFilmCrew(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedError: "not found",
+		},
+		{
+			name:          "No Job",
+			credit:        domain.Credit{FilmID: 2, PersonID: 1},
+			mockBehavior:  func(p *mock_service.MockPersonRepository, c *mock_service.MockCrewRepository) {},
+			expectedError: "invalid credit: job is required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			personRepo := mock_service.NewMockPersonRepository(ctrl)
+			crewRepo := mock_service.NewMockCrewRepository(ctrl)
+			test.mockBehavior(personRepo, crewRepo)
+
+			service := NewPersonService(personRepo, crewRepo, nil)
+			person, err := service.AddPersonFilm(context.Background(), test.credit)
+
+			assert.Equal(t, test.expectedPerson, person)
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestPersonService_RemovePersonFilm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPerson, _ := domain.NewPerson(1, "Hans Zimmer", nil)
+	personRepo := mock_service.NewMockPersonRepository(ctrl)
+	crewRepo := mock_service.NewMockCrewRepository(ctrl)
+	gomock.InOrder(
+		crewRepo.EXPECT().RemoveFilmCrew(gomock.Any(), domain.Credit{FilmID: 2, PersonID: 1}).Return(nil),
+		personRepo.EXPECT().FindPersonByID(gomock.Any(), 1).Return(mockPerson, nil),
+	)
+
+	service := NewPersonService(personRepo, crewRepo, nil)
+	person, err := service.RemovePersonFilm(context.Background(), domain.Credit{FilmID: 2, PersonID: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, mockPerson, person)
+}
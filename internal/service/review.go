@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"github.com/Max425/film-library.git/internal/domain"
+	"go.uber.org/zap"
+)
+
+type ReviewRepository interface {
+	CreateReview(ctx context.Context, review *domain.Review) (*domain.Review, error)
+	FindReviewByID(ctx context.Context, id int) (*domain.Review, error)
+	UpdateReview(ctx context.Context, review *domain.Review) (*domain.Review, error)
+	DeleteReview(ctx context.Context, review *domain.Review) error
+	GetFilmReviews(ctx context.Context, filmID int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error)
+	GetUserReviews(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error)
+}
+
+type ReviewService struct {
+	log        *zap.Logger
+	reviewRepo ReviewRepository
+}
+
+func NewReviewService(reviewRepo ReviewRepository, log *zap.Logger) *ReviewService {
+	return &ReviewService{reviewRepo: reviewRepo, log: log}
+}
+
+func (s *ReviewService) CreateReview(ctx context.Context, review *domain.Review) (*domain.Review, error) {
+	return s.reviewRepo.CreateReview(ctx, review)
+}
+
+func (s *ReviewService) GetReviewByID(ctx context.Context, id int) (*domain.Review, error) {
+	return s.reviewRepo.FindReviewByID(ctx, id)
+}
+
+// UpdateReview changes the rating and the text of a review, only its author may do it.
+func (s *ReviewService) UpdateReview(ctx context.Context, session *domain.Session, review *domain.Review) (*domain.Review, error) {
+	existing, err := s.reviewRepo.FindReviewByID(ctx, review.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if existing.GetUserID() != session.UserID {
+		return nil, domain.ErrForbidden
+	}
+
+	updated, err := domain.NewReview(existing.GetId(), existing.GetFilmID(), existing.GetUserID(), review.GetRating(), review.GetText())
+	if err != nil {
+		return nil, err
+	}
+	return s.reviewRepo.UpdateReview(ctx, updated)
+}
+
+// DeleteReview deletes a review on behalf of its author or of a moderator.
+func (s *ReviewService) DeleteReview(ctx context.Context, session *domain.Session, id int) error {
+	review, err := s.reviewRepo.FindReviewByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if review.GetUserID() != session.UserID && !domain.RoleHas(session.Role, domain.PermReviewModerate) {
+		return domain.ErrForbidden
+	}
+
+	return s.reviewRepo.DeleteReview(ctx, review)
+}
+
+func (s *ReviewService) GetFilmReviews(ctx context.Context, filmID int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error) {
+	return s.reviewRepo.GetFilmReviews(ctx, filmID, page)
+}
+
+func (s *ReviewService) GetUserReviews(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.Review, *domain.PageInfo, error) {
+	return s.reviewRepo.GetUserReviews(ctx, userID, page)
+}
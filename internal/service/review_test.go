@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"github.com/Max425/film-library.git/internal/common/constants"
+	"github.com/Max425/film-library.git/internal/domain"
+	"github.com/Max425/film-library.git/mocks/db"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReviewService_UpdateReview(t *testing.T) {
+	existing, _ := domain.NewReview(3, 1, 7, 8, "Great")
+	edit, _ := domain.NewReview(3, 0, 7, 6, "Worse on rewatch")
+	updated, _ := domain.NewReview(3, 1, 7, 6, "Worse on rewatch")
+
+	tests := []struct {
+		name           string
+		session        *domain.Session
+		mockBehavior   func(r *mock_service.MockReviewRepository)
+		expectedReview *domain.Review
+		expectedError  error
+	}{
+		{
+			name:    "Author",
+			session: &domain.Session{UserID: 7, Role: constants.UserRole},
+			mockBehavior: func(r *mock_service.MockReviewRepository) {
+				r.EXPECT().FindReviewByID(gomock.Any(), 3).Return(existing, nil)
+				r.EXPECT().UpdateReview(gomock.Any(), updated).Return(updated, nil)
+			},
+			expectedReview: updated,
+		},
+		{
+			name:    "Admin is not the author",
+			session: &domain.Session{UserID: 1, Role: constants.AdminRole},
+			mockBehavior: func(r *mock_service.MockReviewRepository) {
+				r.EXPECT().FindReviewByID(gomock.Any(), 3).Return(existing, nil)
+			},
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:    "Not Found",
+			session: &domain.Session{UserID: 7, Role: constants.UserRole},
+			mockBehavior: func(r *mock_service.MockReviewRepository) {
+				r.EXPECT().FindReviewByID(gomock.Any(), 3).Return(nil, domain.ErrNotFound)
+			},
+			expectedError: domain.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockReviewRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewReviewService(repo, nil)
+			review, err := service.UpdateReview(context.Background(), test.session, edit)
+
+			assert.Equal(t, test.expectedReview, review)
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestReviewService_DeleteReview(t *testing.T) {
+	existing, _ := domain.NewReview(3, 1, 7, 8, "Great")
+
+	tests := []struct {
+		name          string
+		session       *domain.Session
+		mockBehavior  func(r *mock_service.MockReviewRepository)
+		expectedError error
+	}{
+		{
+			name:    "Author",
+			session: &domain.Session{UserID: 7, Role: constants.UserRole},
+			mockBehavior: func(r *mock_service.MockReviewRepository) {
+				r.EXPECT().FindReviewByID(gomock.Any(), 3).Return(existing, nil)
+				r.EXPECT().DeleteReview(gomock.Any(), existing).Return(nil)
+			},
+		},
+		{
+			name:    "Admin moderation",
+			session: &domain.Session{UserID: 1, Role: constants.AdminRole},
+			mockBehavior: func(r *mock_service.MockReviewRepository) {
+				r.EXPECT().FindReviewByID(gomock.Any(), 3).Return(existing, nil)
+				r.EXPECT().DeleteReview(gomock.Any(), existing).Return(nil)
+			},
+		},
+		{
+			name:    "Moderator moderation",
+			session: &domain.Session{UserID: 4, Role: constants.ModeratorRole},
+			mockBehavior: func(r *mock_service.MockReviewRepository) {
+				r.EXPECT().FindReviewByID(gomock.Any(), 3).Return(existing, nil)
+				r.EXPECT().DeleteReview(gomock.Any(), existing).Return(nil)
+			},
+		},
+		{
+			name:    "Another user",
+			session: &domain.Session{UserID: 2, Role: constants.UserRole},
+			mockBehavior: func(r *mock_service.MockReviewRepository) {
+				r.EXPECT().FindReviewByID(gomock.Any(), 3).Return(existing, nil)
+			},
+			expectedError: domain.ErrForbidden,
+		},
+		{
+			name:    "Editor",
+			session: &domain.Session{UserID: 5, Role: constants.EditorRole},
+			mockBehavior: func(r *mock_service.MockReviewRepository) {
+				r.EXPECT().FindReviewByID(gomock.Any(), 3).Return(existing, nil)
+			},
+			expectedError: domain.ErrForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_service.NewMockReviewRepository(ctrl)
+			test.mockBehavior(repo)
+
+			service := NewReviewService(repo, nil)
+			err := service.DeleteReview(context.Background(), test.session, 3)
+
+			assert.Equal(t, test.expectedError, err)
+		})
+	}
+}
@@ -7,20 +7,36 @@ import (
 type Repository interface {
 	ActorRepository
 	FilmRepository
+	PersonRepository
+	GenreRepository
+	ReviewRepository
+	ListRepository
 	UserRepository
 	StoreRepository
+	AdminRepository
 }
 
 type Service struct {
 	ActorService
 	FilmService
+	PersonService
+	GenreService
+	ReviewService
+	ListService
 	AuthService
+	AdminService
 }
 
-func NewService(repo Repository, log *zap.Logger) *Service {
+func NewService(repo Repository, log *zap.Logger, hasher PasswordHasher, policy PasswordPolicy, mailer Mailer,
+	throttle LoginThrottle, requireVerified bool, tokens *AccessTokens) *Service {
 	return &Service{
-		*NewActorService(repo, log),
+		*NewActorService(repo, repo, log),
 		*NewFilmService(repo, log),
-		*NewAuthService(log, repo, repo),
+		*NewPersonService(repo, repo, log),
+		*NewGenreService(repo, log),
+		*NewReviewService(repo, log),
+		*NewListService(repo, log),
+		*NewAuthService(log, repo, repo, hasher, policy, mailer, throttle, requireVerified, tokens),
+		*NewAdminService(repo, repo, log),
 	}
 }
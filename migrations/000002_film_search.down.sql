@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS actor_search_vector_update ON actor;
+DROP TRIGGER IF EXISTS film_actor_search_vector_update ON film_actor;
+DROP TRIGGER IF EXISTS film_search_vector_update ON film;
+DROP FUNCTION IF EXISTS actor_search_vector_update();
+DROP FUNCTION IF EXISTS film_actor_search_vector_update();
+DROP FUNCTION IF EXISTS film_search_vector_update();
+DROP FUNCTION IF EXISTS film_search_vector(text, text, int);
+DROP INDEX IF EXISTS idx_film_search_vector;
+ALTER TABLE film DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,73 @@
+alter table film
+    add column search_vector tsvector not null default ''::tsvector;
+
+-- title weighs most, then description, then the names of the cast
+create or replace function film_search_vector(film_title text, film_description text, film_id int) returns tsvector
+    language sql
+    stable
+as
+$$
+select setweight(to_tsvector('simple', coalesce(film_title, '')), 'A') ||
+       setweight(to_tsvector('simple', coalesce(film_description, '')), 'B') ||
+       setweight(to_tsvector('simple', coalesce((select string_agg(a.name, ' ')
+                                                 from actor as a
+                                                          join film_actor as fa on a.id = fa.actor_id
+                                                 where fa.film_id = film_search_vector.film_id), '')), 'C')
+$$;
+
+create or replace function film_search_vector_update() returns trigger
+    language plpgsql
+as
+$$
+begin
+    new.search_vector := film_search_vector(new.title, new.description, new.id);
+    return new;
+end
+$$;
+
+create trigger film_search_vector_update
+    before insert or update of title, description
+    on film
+    for each row
+execute function film_search_vector_update();
+
+create or replace function film_actor_search_vector_update() returns trigger
+    language plpgsql
+as
+$$
+begin
+    update film
+    set search_vector = film_search_vector(title, description, id)
+    where id = coalesce(new.film_id, old.film_id);
+    return null;
+end
+$$;
+
+create trigger film_actor_search_vector_update
+    after insert or delete
+    on film_actor
+    for each row
+execute function film_actor_search_vector_update();
+
+create or replace function actor_search_vector_update() returns trigger
+    language plpgsql
+as
+$$
+begin
+    update film
+    set search_vector = film_search_vector(title, description, id)
+    where id in (select fa.film_id from film_actor as fa where fa.actor_id = new.id);
+    return null;
+end
+$$;
+
+create trigger actor_search_vector_update
+    after update of name
+    on actor
+    for each row
+execute function actor_search_vector_update();
+
+update film
+set search_vector = film_search_vector(title, description, id);
+
+create index idx_film_search_vector on film using gin (search_vector);
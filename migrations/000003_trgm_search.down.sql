@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_actor_name_trgm;
+DROP INDEX IF EXISTS idx_film_title_trgm;
+DROP EXTENSION IF EXISTS pg_trgm;
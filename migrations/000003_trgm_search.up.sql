@@ -0,0 +1,4 @@
+create extension if not exists pg_trgm;
+
+create index idx_film_title_trgm on film using gin (title gin_trgm_ops);
+create index idx_actor_name_trgm on actor using gin (name gin_trgm_ops);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_film_actor_film_id_billing;
+ALTER TABLE film_actor
+    DROP COLUMN IF EXISTS role_type,
+    DROP COLUMN IF EXISTS billing,
+    DROP COLUMN IF EXISTS role_name;
@@ -0,0 +1,6 @@
+alter table film_actor
+    add column role_name varchar(255),
+    add column billing   int check (billing > 0),
+    add column role_type varchar(10) not null default 'regular' check (role_type in ('regular', 'cameo', 'voice'));
+
+create index idx_film_actor_film_id_billing on film_actor (film_id, billing);
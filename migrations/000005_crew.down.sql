@@ -0,0 +1,21 @@
+DROP TRIGGER IF EXISTS person_search_vector_update ON person;
+DROP TRIGGER IF EXISTS film_crew_search_vector_update ON film_crew;
+DROP FUNCTION IF EXISTS person_search_vector_update();
+DROP TABLE IF EXISTS film_crew;
+DROP TABLE IF EXISTS person;
+
+CREATE OR REPLACE FUNCTION film_search_vector(film_title text, film_description text, film_id int) RETURNS tsvector
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT setweight(to_tsvector('simple', coalesce(film_title, '')), 'A') ||
+       setweight(to_tsvector('simple', coalesce(film_description, '')), 'B') ||
+       setweight(to_tsvector('simple', coalesce((SELECT string_agg(a.name, ' ')
+                                                 FROM actor AS a
+                                                          JOIN film_actor AS fa ON a.id = fa.actor_id
+                                                 WHERE fa.film_id = film_search_vector.film_id), '')), 'C')
+$$;
+
+UPDATE film
+SET search_vector = film_search_vector(title, description, id);
@@ -0,0 +1,60 @@
+create table person
+(
+    id         serial primary key,
+    name       varchar(255) not null,
+    created_at timestamptz default timezone('europe/moscow'::text, now()),
+    updated_at timestamptz default timezone('europe/moscow'::text, now())
+);
+
+create index idx_person_name_trgm on person using gin (name gin_trgm_ops);
+
+create table film_crew
+(
+    film_id   int references film (id) on delete cascade,
+    person_id int references person (id) on delete cascade,
+    job       varchar(20) not null check (job in ('director', 'writer', 'composer', 'producer')),
+    primary key (film_id, person_id, job)
+);
+create index idx_film_crew_person_id on film_crew (person_id);
+
+-- the crew is searched along with the cast
+create or replace function film_search_vector(film_title text, film_description text, film_id int) returns tsvector
+    language sql
+    stable
+as
+$$
+select setweight(to_tsvector('simple', coalesce(film_title, '')), 'A') ||
+       setweight(to_tsvector('simple', coalesce(film_description, '')), 'B') ||
+       setweight(to_tsvector('simple', coalesce((select string_agg(a.name, ' ')
+                                                 from actor as a
+                                                          join film_actor as fa on a.id = fa.actor_id
+                                                 where fa.film_id = film_search_vector.film_id), '')), 'C') ||
+       setweight(to_tsvector('simple', coalesce((select string_agg(p.name, ' ')
+                                                 from person as p
+                                                          join film_crew as fc on p.id = fc.person_id
+                                                 where fc.film_id = film_search_vector.film_id), '')), 'C')
+$$;
+
+create trigger film_crew_search_vector_update
+    after insert or delete
+    on film_crew
+    for each row
+execute function film_actor_search_vector_update();
+
+create or replace function person_search_vector_update() returns trigger
+    language plpgsql
+as
+$$
+begin
+    update film
+    set search_vector = film_search_vector(title, description, id)
+    where id in (select fc.film_id from film_crew as fc where fc.person_id = new.id);
+    return null;
+end
+$$;
+
+create trigger person_search_vector_update
+    after update of name
+    on person
+    for each row
+execute function person_search_vector_update();
@@ -0,0 +1,15 @@
+create table genre
+(
+    id         serial primary key,
+    name       varchar(100) not null unique,
+    created_at timestamptz default timezone('europe/moscow'::text, now()),
+    updated_at timestamptz default timezone('europe/moscow'::text, now())
+);
+
+create table film_genre
+(
+    film_id  int references film (id) on delete cascade,
+    genre_id int references genre (id) on delete cascade,
+    primary key (film_id, genre_id)
+);
+create index idx_film_genre_genre_id on film_genre (genre_id);
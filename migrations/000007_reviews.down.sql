@@ -0,0 +1,2 @@
+ALTER TABLE film DROP COLUMN IF EXISTS vote_count, DROP COLUMN IF EXISTS user_rating;
+DROP TABLE IF EXISTS review;
@@ -0,0 +1,17 @@
+create table review
+(
+    id         serial primary key,
+    film_id    int references film (id) on delete cascade  not null,
+    user_id    int references users (id) on delete cascade not null,
+    rating     smallint check (rating >= 1 and rating <= 10) not null,
+    text       text not null default '',
+    created_at timestamptz default timezone('europe/moscow'::text, now()),
+    updated_at timestamptz default timezone('europe/moscow'::text, now()),
+    unique (film_id, user_id)
+);
+create index idx_review_user_id on review (user_id);
+
+-- the mean of the user ratings, kept up to date by the review writes
+alter table film
+    add column user_rating decimal(4, 2) check (user_rating >= 0 and user_rating <= 10) not null default 0,
+    add column vote_count  int check (vote_count >= 0) not null default 0;
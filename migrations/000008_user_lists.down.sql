@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS film_list_item;
+DROP TABLE IF EXISTS film_list;
+DROP TABLE IF EXISTS watched;
+DROP TABLE IF EXISTS watchlist;
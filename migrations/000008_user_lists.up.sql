@@ -0,0 +1,39 @@
+create table watchlist
+(
+    user_id  int references users (id) on delete cascade,
+    film_id  int references film (id) on delete cascade,
+    added_at timestamptz default timezone('europe/moscow'::text, now()),
+    primary key (user_id, film_id)
+);
+create index idx_watchlist_film_id on watchlist (film_id);
+
+-- a film watched again gets another entry
+create table watched
+(
+    id         serial primary key,
+    user_id    int references users (id) on delete cascade not null,
+    film_id    int references film (id) on delete cascade  not null,
+    watched_at date                                        not null,
+    created_at timestamptz default timezone('europe/moscow'::text, now())
+);
+create index idx_watched_user_id_watched_at on watched (user_id, watched_at desc, id desc);
+
+create table film_list
+(
+    id          serial primary key,
+    user_id     int references users (id) on delete cascade not null,
+    name        varchar(100)                                not null,
+    share_token uuid unique,
+    created_at  timestamptz default timezone('europe/moscow'::text, now()),
+    updated_at  timestamptz default timezone('europe/moscow'::text, now()),
+    unique (user_id, name)
+);
+
+create table film_list_item
+(
+    list_id  int references film_list (id) on delete cascade,
+    film_id  int references film (id) on delete cascade,
+    added_at timestamptz default timezone('europe/moscow'::text, now()),
+    primary key (list_id, film_id)
+);
+create index idx_film_list_item_film_id on film_list_item (film_id);
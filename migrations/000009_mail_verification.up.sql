@@ -0,0 +1,7 @@
+-- null until the user follows the link sent to the mail
+alter table users
+    add column verified_at timestamptz;
+
+-- accounts made before verification existed are trusted
+update users
+set verified_at = created_at;
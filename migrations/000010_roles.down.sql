@@ -0,0 +1,2 @@
+UPDATE users SET role = 0 WHERE role > 1;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check, ADD CONSTRAINT users_role_check CHECK (role >= 0 AND role <= 1);
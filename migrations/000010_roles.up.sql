@@ -0,0 +1,4 @@
+-- 0 user, 1 admin, 2 editor, 3 moderator
+alter table users
+    drop constraint if exists users_role_check,
+    add constraint users_role_check check (role in (0, 1, 2, 3));
@@ -0,0 +1,3 @@
+-- set while an admin keeps the user from signing in
+alter table users
+    add column disabled_at timestamptz;
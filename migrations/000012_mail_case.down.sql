@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS inx_users_mail;
+CREATE INDEX inx_users_mail ON users (mail);
+ALTER TABLE users ADD CONSTRAINT users_mail_key UNIQUE (mail);
@@ -0,0 +1,23 @@
+-- mails are compared in lower case. Of the accounts whose mails differ only in
+-- case, the verified one, or else the oldest, keeps the mail. The others are
+-- disabled and their mail is marked, so an admin can sort them out.
+with duplicates as (select id,
+                           row_number() over (partition by lower(mail)
+                               order by verified_at nulls last, id) as rank
+                    from users
+                    where mail is not null)
+update users
+set mail        = lower(users.mail) || '.duplicate-' || users.id,
+    disabled_at = coalesce(users.disabled_at, timezone('europe/moscow'::text, now()))
+from duplicates
+where users.id = duplicates.id
+  and duplicates.rank > 1;
+
+update users
+set mail = lower(mail)
+where mail <> lower(mail);
+
+alter table users
+    drop constraint if exists users_mail_key;
+drop index if exists inx_users_mail;
+create unique index inx_users_mail on users (lower(mail));
@@ -80,18 +80,19 @@ func (mr *MockActorRepositoryMockRecorder) FindActorByID(ctx, id interface{}) *g
 }
 
 // GetAllActors mocks base method.
-func (m *MockActorRepository) GetAllActors(ctx context.Context) ([]*domain.Actor, error) {
+func (m *MockActorRepository) GetAllActors(ctx context.Context, sort []domain.ActorSort, filter domain.ActorFilter, page domain.PageRequest) ([]*domain.Actor, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllActors", ctx)
+	ret := m.ctrl.Call(m, "GetAllActors", ctx, sort, filter, page)
 	ret0, _ := ret[0].([]*domain.Actor)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllActors indicates an expected call of GetAllActors.
-func (mr *MockActorRepositoryMockRecorder) GetAllActors(ctx interface{}) *gomock.Call {
+func (mr *MockActorRepositoryMockRecorder) GetAllActors(ctx, sort, filter, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActors", reflect.TypeOf((*MockActorRepository)(nil).GetAllActors), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActors", reflect.TypeOf((*MockActorRepository)(nil).GetAllActors), ctx, sort, filter, page)
 }
 
 // UpdateActor mocks base method.
@@ -108,3 +109,54 @@ func (mr *MockActorRepositoryMockRecorder) UpdateActor(ctx, actor interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockActorRepository)(nil).UpdateActor), ctx, actor)
 }
+
+// MockCastRepository is a mock of CastRepository interface.
+type MockCastRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCastRepositoryMockRecorder
+}
+
+// MockCastRepositoryMockRecorder is the mock recorder for MockCastRepository.
+type MockCastRepositoryMockRecorder struct {
+	mock *MockCastRepository
+}
+
+// NewMockCastRepository creates a new mock instance.
+func NewMockCastRepository(ctrl *gomock.Controller) *MockCastRepository {
+	mock := &MockCastRepository{ctrl: ctrl}
+	mock.recorder = &MockCastRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCastRepository) EXPECT() *MockCastRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddFilmActor mocks base method.
+func (m *MockCastRepository) AddFilmActor(ctx context.Context, casting domain.Casting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmActor", ctx, casting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilmActor indicates an expected call of AddFilmActor.
+func (mr *MockCastRepositoryMockRecorder) AddFilmActor(ctx, casting interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmActor", reflect.TypeOf((*MockCastRepository)(nil).AddFilmActor), ctx, casting)
+}
+
+// RemoveFilmActor mocks base method.
+func (m *MockCastRepository) RemoveFilmActor(ctx context.Context, filmID, actorID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmActor", ctx, filmID, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFilmActor indicates an expected call of RemoveFilmActor.
+func (mr *MockCastRepositoryMockRecorder) RemoveFilmActor(ctx, filmID, actorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmActor", reflect.TypeOf((*MockCastRepository)(nil).RemoveFilmActor), ctx, filmID, actorID)
+}
@@ -66,6 +66,49 @@ func (mr *MockUserRepositoryMockRecorder) GetUser(ctx, mail interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserRepository)(nil).GetUser), ctx, mail)
 }
 
+// GetUserByID mocks base method.
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id int) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepository)(nil).GetUserByID), ctx, id)
+}
+
+// SetVerified mocks base method.
+func (m *MockUserRepository) SetVerified(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVerified", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVerified indicates an expected call of SetVerified.
+func (mr *MockUserRepositoryMockRecorder) SetVerified(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVerified", reflect.TypeOf((*MockUserRepository)(nil).SetVerified), ctx, id)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), ctx, user)
+}
+
 // MockStoreRepository is a mock of StoreRepository interface.
 type MockStoreRepository struct {
 	ctrl     *gomock.Controller
@@ -89,6 +132,21 @@ func (m *MockStoreRepository) EXPECT() *MockStoreRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddLoginFailure mocks base method.
+func (m *MockStoreRepository) AddLoginFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLoginFailure", ctx, key, window)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLoginFailure indicates an expected call of AddLoginFailure.
+func (mr *MockStoreRepositoryMockRecorder) AddLoginFailure(ctx, key, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoginFailure", reflect.TypeOf((*MockStoreRepository)(nil).AddLoginFailure), ctx, key, window)
+}
+
 // DeleteSession mocks base method.
 func (m *MockStoreRepository) DeleteSession(ctx context.Context, session string) error {
 	m.ctrl.T.Helper()
@@ -103,11 +161,68 @@ func (mr *MockStoreRepositoryMockRecorder) DeleteSession(ctx, session interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStoreRepository)(nil).DeleteSession), ctx, session)
 }
 
+// DeleteSessionFamily mocks base method.
+func (m *MockStoreRepository) DeleteSessionFamily(ctx context.Context, userID int, family string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionFamily", ctx, userID, family)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionFamily indicates an expected call of DeleteSessionFamily.
+func (mr *MockStoreRepositoryMockRecorder) DeleteSessionFamily(ctx, userID, family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionFamily", reflect.TypeOf((*MockStoreRepository)(nil).DeleteSessionFamily), ctx, userID, family)
+}
+
+// DeleteUserSession mocks base method.
+func (m *MockStoreRepository) DeleteUserSession(ctx context.Context, userID int, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSession", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSession indicates an expected call of DeleteUserSession.
+func (mr *MockStoreRepositoryMockRecorder) DeleteUserSession(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSession", reflect.TypeOf((*MockStoreRepository)(nil).DeleteUserSession), ctx, userID, id)
+}
+
+// DeleteUserSessions mocks base method.
+func (m *MockStoreRepository) DeleteUserSessions(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessions", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSessions indicates an expected call of DeleteUserSessions.
+func (mr *MockStoreRepositoryMockRecorder) DeleteUserSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessions", reflect.TypeOf((*MockStoreRepository)(nil).DeleteUserSessions), ctx, userID)
+}
+
+// GetLoginLock mocks base method.
+func (m *MockStoreRepository) GetLoginLock(ctx context.Context, key string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginLock", ctx, key)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginLock indicates an expected call of GetLoginLock.
+func (mr *MockStoreRepositoryMockRecorder) GetLoginLock(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginLock", reflect.TypeOf((*MockStoreRepository)(nil).GetLoginLock), ctx, key)
+}
+
 // GetSession mocks base method.
-func (m *MockStoreRepository) GetSession(ctx context.Context, session string) (int, error) {
+func (m *MockStoreRepository) GetSession(ctx context.Context, session string) (*domain.Session, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSession", ctx, session)
-	ret0, _ := ret[0].(int)
+	ret0, _ := ret[0].(*domain.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -118,16 +233,184 @@ func (mr *MockStoreRepositoryMockRecorder) GetSession(ctx, session interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStoreRepository)(nil).GetSession), ctx, session)
 }
 
+// GetSpentSession mocks base method.
+func (m *MockStoreRepository) GetSpentSession(ctx context.Context, session string) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpentSession", ctx, session)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpentSession indicates an expected call of GetSpentSession.
+func (mr *MockStoreRepositoryMockRecorder) GetSpentSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpentSession", reflect.TypeOf((*MockStoreRepository)(nil).GetSpentSession), ctx, session)
+}
+
+// GetUserSession mocks base method.
+func (m *MockStoreRepository) GetUserSession(ctx context.Context, userID int, id string) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSession", ctx, userID, id)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSession indicates an expected call of GetUserSession.
+func (mr *MockStoreRepositoryMockRecorder) GetUserSession(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSession", reflect.TypeOf((*MockStoreRepository)(nil).GetUserSession), ctx, userID, id)
+}
+
+// GetUserSessions mocks base method.
+func (m *MockStoreRepository) GetUserSessions(ctx context.Context, userID int) ([]*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessions", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessions indicates an expected call of GetUserSessions.
+func (mr *MockStoreRepositoryMockRecorder) GetUserSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockStoreRepository)(nil).GetUserSessions), ctx, userID)
+}
+
+// ResetLoginFailures mocks base method.
+func (m *MockStoreRepository) ResetLoginFailures(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginFailures", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginFailures indicates an expected call of ResetLoginFailures.
+func (mr *MockStoreRepositoryMockRecorder) ResetLoginFailures(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginFailures", reflect.TypeOf((*MockStoreRepository)(nil).ResetLoginFailures), ctx, key)
+}
+
+// SetLoginLock mocks base method.
+func (m *MockStoreRepository) SetLoginLock(ctx context.Context, key string, lifetime time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLoginLock", ctx, key, lifetime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLoginLock indicates an expected call of SetLoginLock.
+func (mr *MockStoreRepositoryMockRecorder) SetLoginLock(ctx, key, lifetime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLoginLock", reflect.TypeOf((*MockStoreRepository)(nil).SetLoginLock), ctx, key, lifetime)
+}
+
 // SetSession mocks base method.
-func (m *MockStoreRepository) SetSession(ctx context.Context, session string, role int, expire time.Duration) error {
+func (m *MockStoreRepository) SetSession(ctx context.Context, session string, value *domain.Session, expire time.Duration) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetSession", ctx, session, role, expire)
+	ret := m.ctrl.Call(m, "SetSession", ctx, session, value, expire)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SetSession indicates an expected call of SetSession.
-func (mr *MockStoreRepositoryMockRecorder) SetSession(ctx, session, role, expire interface{}) *gomock.Call {
+func (mr *MockStoreRepositoryMockRecorder) SetSession(ctx, session, value, expire interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSession", reflect.TypeOf((*MockStoreRepository)(nil).SetSession), ctx, session, value, expire)
+}
+
+// SetSpentSession mocks base method.
+func (m *MockStoreRepository) SetSpentSession(ctx context.Context, session string, value *domain.Session, expire time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSpentSession", ctx, session, value, expire)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSpentSession indicates an expected call of SetSpentSession.
+func (mr *MockStoreRepositoryMockRecorder) SetSpentSession(ctx, session, value, expire interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSpentSession", reflect.TypeOf((*MockStoreRepository)(nil).SetSpentSession), ctx, session, value, expire)
+}
+
+// SetToken mocks base method.
+func (m *MockStoreRepository) SetToken(ctx context.Context, purpose domain.TokenPurpose, token string, userID int, lifetime time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetToken", ctx, purpose, token, userID, lifetime)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetToken indicates an expected call of SetToken.
+func (mr *MockStoreRepositoryMockRecorder) SetToken(ctx, purpose, token, userID, lifetime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetToken", reflect.TypeOf((*MockStoreRepository)(nil).SetToken), ctx, purpose, token, userID, lifetime)
+}
+
+// TakeSession mocks base method.
+func (m *MockStoreRepository) TakeSession(ctx context.Context, session string) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSession", ctx, session)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeSession indicates an expected call of TakeSession.
+func (mr *MockStoreRepositoryMockRecorder) TakeSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSession", reflect.TypeOf((*MockStoreRepository)(nil).TakeSession), ctx, session)
+}
+
+// TakeToken mocks base method.
+func (m *MockStoreRepository) TakeToken(ctx context.Context, purpose domain.TokenPurpose, token string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeToken", ctx, purpose, token)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeToken indicates an expected call of TakeToken.
+func (mr *MockStoreRepositoryMockRecorder) TakeToken(ctx, purpose, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeToken", reflect.TypeOf((*MockStoreRepository)(nil).TakeToken), ctx, purpose, token)
+}
+
+// MockMailer is a mock of Mailer interface.
+type MockMailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerMockRecorder
+}
+
+// MockMailerMockRecorder is the mock recorder for MockMailer.
+type MockMailerMockRecorder struct {
+	mock *MockMailer
+}
+
+// NewMockMailer creates a new mock instance.
+func NewMockMailer(ctrl *gomock.Controller) *MockMailer {
+	mock := &MockMailer{ctrl: ctrl}
+	mock.recorder = &MockMailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailer) EXPECT() *MockMailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, to, subject, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailerMockRecorder) Send(ctx, to, subject, body interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSession", reflect.TypeOf((*MockStoreRepository)(nil).SetSession), ctx, session, role, expire)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailer)(nil).Send), ctx, to, subject, body)
 }
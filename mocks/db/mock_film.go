@@ -35,6 +35,62 @@ func (m *MockFilmRepository) EXPECT() *MockFilmRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddFilmActor mocks base method.
+func (m *MockFilmRepository) AddFilmActor(ctx context.Context, casting domain.Casting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmActor", ctx, casting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilmActor indicates an expected call of AddFilmActor.
+func (mr *MockFilmRepositoryMockRecorder) AddFilmActor(ctx, casting interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmActor", reflect.TypeOf((*MockFilmRepository)(nil).AddFilmActor), ctx, casting)
+}
+
+// AddFilmCrew mocks base method.
+func (m *MockFilmRepository) AddFilmCrew(ctx context.Context, credit domain.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmCrew", ctx, credit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilmCrew indicates an expected call of AddFilmCrew.
+func (mr *MockFilmRepositoryMockRecorder) AddFilmCrew(ctx, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmCrew", reflect.TypeOf((*MockFilmRepository)(nil).AddFilmCrew), ctx, credit)
+}
+
+// AddFilmGenre mocks base method.
+func (m *MockFilmRepository) AddFilmGenre(ctx context.Context, filmID, genreID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmGenre", ctx, filmID, genreID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilmGenre indicates an expected call of AddFilmGenre.
+func (mr *MockFilmRepositoryMockRecorder) AddFilmGenre(ctx, filmID, genreID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmGenre", reflect.TypeOf((*MockFilmRepository)(nil).AddFilmGenre), ctx, filmID, genreID)
+}
+
+// AddFilmUserStates mocks base method.
+func (m *MockFilmRepository) AddFilmUserStates(ctx context.Context, userID int, films []*domain.Film) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmUserStates", ctx, userID, films)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilmUserStates indicates an expected call of AddFilmUserStates.
+func (mr *MockFilmRepositoryMockRecorder) AddFilmUserStates(ctx, userID, films interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmUserStates", reflect.TypeOf((*MockFilmRepository)(nil).AddFilmUserStates), ctx, userID, films)
+}
+
 // CreateFilm mocks base method.
 func (m *MockFilmRepository) CreateFilm(ctx context.Context, film *domain.Film) (*domain.Film, error) {
 	m.ctrl.T.Helper()
@@ -79,34 +135,124 @@ func (mr *MockFilmRepositoryMockRecorder) FindFilmByID(ctx, id interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFilmByID", reflect.TypeOf((*MockFilmRepository)(nil).FindFilmByID), ctx, id)
 }
 
+// FuzzySearchFilms mocks base method.
+func (m *MockFilmRepository) FuzzySearchFilms(ctx context.Context, query string, threshold float64, page domain.PageRequest) ([]*domain.FilmMatch, *domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FuzzySearchFilms", ctx, query, threshold, page)
+	ret0, _ := ret[0].([]*domain.FilmMatch)
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FuzzySearchFilms indicates an expected call of FuzzySearchFilms.
+func (mr *MockFilmRepositoryMockRecorder) FuzzySearchFilms(ctx, query, threshold, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FuzzySearchFilms", reflect.TypeOf((*MockFilmRepository)(nil).FuzzySearchFilms), ctx, query, threshold, page)
+}
+
 // GetAllFilms mocks base method.
-func (m *MockFilmRepository) GetAllFilms(ctx context.Context, sortBy, order string) ([]*domain.Film, error) {
+func (m *MockFilmRepository) GetAllFilms(ctx context.Context, sort []domain.FilmSort, filter domain.FilmFilter, page domain.PageRequest) ([]*domain.Film, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllFilms", ctx, sortBy, order)
+	ret := m.ctrl.Call(m, "GetAllFilms", ctx, sort, filter, page)
 	ret0, _ := ret[0].([]*domain.Film)
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllFilms indicates an expected call of GetAllFilms.
+func (mr *MockFilmRepositoryMockRecorder) GetAllFilms(ctx, sort, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllFilms", reflect.TypeOf((*MockFilmRepository)(nil).GetAllFilms), ctx, sort, filter, page)
+}
+
+// GetFilmGenreFacets mocks base method.
+func (m *MockFilmRepository) GetFilmGenreFacets(ctx context.Context, filter domain.FilmFilter) ([]*domain.GenreCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmGenreFacets", ctx, filter)
+	ret0, _ := ret[0].([]*domain.GenreCount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllFilms indicates an expected call of GetAllFilms.
-func (mr *MockFilmRepositoryMockRecorder) GetAllFilms(ctx, sortBy, order interface{}) *gomock.Call {
+// GetFilmGenreFacets indicates an expected call of GetFilmGenreFacets.
+func (mr *MockFilmRepositoryMockRecorder) GetFilmGenreFacets(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmGenreFacets", reflect.TypeOf((*MockFilmRepository)(nil).GetFilmGenreFacets), ctx, filter)
+}
+
+// RemoveFilmActor mocks base method.
+func (m *MockFilmRepository) RemoveFilmActor(ctx context.Context, filmID, actorID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmActor", ctx, filmID, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFilmActor indicates an expected call of RemoveFilmActor.
+func (mr *MockFilmRepositoryMockRecorder) RemoveFilmActor(ctx, filmID, actorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmActor", reflect.TypeOf((*MockFilmRepository)(nil).RemoveFilmActor), ctx, filmID, actorID)
+}
+
+// RemoveFilmCrew mocks base method.
+func (m *MockFilmRepository) RemoveFilmCrew(ctx context.Context, credit domain.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmCrew", ctx, credit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFilmCrew indicates an expected call of RemoveFilmCrew.
+func (mr *MockFilmRepositoryMockRecorder) RemoveFilmCrew(ctx, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmCrew", reflect.TypeOf((*MockFilmRepository)(nil).RemoveFilmCrew), ctx, credit)
+}
+
+// RemoveFilmGenre mocks base method.
+func (m *MockFilmRepository) RemoveFilmGenre(ctx context.Context, filmID, genreID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmGenre", ctx, filmID, genreID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFilmGenre indicates an expected call of RemoveFilmGenre.
+func (mr *MockFilmRepositoryMockRecorder) RemoveFilmGenre(ctx, filmID, genreID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllFilms", reflect.TypeOf((*MockFilmRepository)(nil).GetAllFilms), ctx, sortBy, order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmGenre", reflect.TypeOf((*MockFilmRepository)(nil).RemoveFilmGenre), ctx, filmID, genreID)
 }
 
 // SearchFilms mocks base method.
-func (m *MockFilmRepository) SearchFilms(ctx context.Context, fragment string) ([]*domain.Film, error) {
+func (m *MockFilmRepository) SearchFilms(ctx context.Context, query string, page domain.PageRequest) ([]*domain.FilmMatch, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchFilms", ctx, fragment)
-	ret0, _ := ret[0].([]*domain.Film)
+	ret := m.ctrl.Call(m, "SearchFilms", ctx, query, page)
+	ret0, _ := ret[0].([]*domain.FilmMatch)
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchFilms indicates an expected call of SearchFilms.
+func (mr *MockFilmRepositoryMockRecorder) SearchFilms(ctx, query, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFilms", reflect.TypeOf((*MockFilmRepository)(nil).SearchFilms), ctx, query, page)
+}
+
+// SuggestSearchTerms mocks base method.
+func (m *MockFilmRepository) SuggestSearchTerms(ctx context.Context, query string, threshold float64, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestSearchTerms", ctx, query, threshold, limit)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchFilms indicates an expected call of SearchFilms.
-func (mr *MockFilmRepositoryMockRecorder) SearchFilms(ctx, fragment interface{}) *gomock.Call {
+// SuggestSearchTerms indicates an expected call of SuggestSearchTerms.
+func (mr *MockFilmRepositoryMockRecorder) SuggestSearchTerms(ctx, query, threshold, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFilms", reflect.TypeOf((*MockFilmRepository)(nil).SearchFilms), ctx, fragment)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestSearchTerms", reflect.TypeOf((*MockFilmRepository)(nil).SuggestSearchTerms), ctx, query, threshold, limit)
 }
 
 // UpdateFilm mocks base method.
@@ -138,3 +284,18 @@ func (mr *MockFilmRepositoryMockRecorder) UpdateFilmActors(ctx, id, actorsId int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmActors", reflect.TypeOf((*MockFilmRepository)(nil).UpdateFilmActors), ctx, id, actorsId)
 }
+
+// UpdateFilmGenres mocks base method.
+func (m *MockFilmRepository) UpdateFilmGenres(ctx context.Context, id int, genreIDs []int) (*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilmGenres", ctx, id, genreIDs)
+	ret0, _ := ret[0].(*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFilmGenres indicates an expected call of UpdateFilmGenres.
+func (mr *MockFilmRepositoryMockRecorder) UpdateFilmGenres(ctx, id, genreIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmGenres", reflect.TypeOf((*MockFilmRepository)(nil).UpdateFilmGenres), ctx, id, genreIDs)
+}
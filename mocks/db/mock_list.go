@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/list.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Max425/film-library.git/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockListRepository is a mock of ListRepository interface.
+type MockListRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockListRepositoryMockRecorder
+}
+
+// MockListRepositoryMockRecorder is the mock recorder for MockListRepository.
+type MockListRepositoryMockRecorder struct {
+	mock *MockListRepository
+}
+
+// NewMockListRepository creates a new mock instance.
+func NewMockListRepository(ctrl *gomock.Controller) *MockListRepository {
+	mock := &MockListRepository{ctrl: ctrl}
+	mock.recorder = &MockListRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListRepository) EXPECT() *MockListRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddFilmListItem mocks base method.
+func (m *MockListRepository) AddFilmListItem(ctx context.Context, userID, listID, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmListItem", ctx, userID, listID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilmListItem indicates an expected call of AddFilmListItem.
+func (mr *MockListRepositoryMockRecorder) AddFilmListItem(ctx, userID, listID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmListItem", reflect.TypeOf((*MockListRepository)(nil).AddFilmListItem), ctx, userID, listID, filmID)
+}
+
+// AddToWatchlist mocks base method.
+func (m *MockListRepository) AddToWatchlist(ctx context.Context, userID, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWatchlist", ctx, userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToWatchlist indicates an expected call of AddToWatchlist.
+func (mr *MockListRepositoryMockRecorder) AddToWatchlist(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWatchlist", reflect.TypeOf((*MockListRepository)(nil).AddToWatchlist), ctx, userID, filmID)
+}
+
+// AddWatched mocks base method.
+func (m *MockListRepository) AddWatched(ctx context.Context, entry domain.WatchEntry) (*domain.WatchEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWatched", ctx, entry)
+	ret0, _ := ret[0].(*domain.WatchEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWatched indicates an expected call of AddWatched.
+func (mr *MockListRepositoryMockRecorder) AddWatched(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWatched", reflect.TypeOf((*MockListRepository)(nil).AddWatched), ctx, entry)
+}
+
+// CreateFilmList mocks base method.
+func (m *MockListRepository) CreateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilmList", ctx, list)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFilmList indicates an expected call of CreateFilmList.
+func (mr *MockListRepositoryMockRecorder) CreateFilmList(ctx, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilmList", reflect.TypeOf((*MockListRepository)(nil).CreateFilmList), ctx, list)
+}
+
+// DeleteFilmList mocks base method.
+func (m *MockListRepository) DeleteFilmList(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmList", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilmList indicates an expected call of DeleteFilmList.
+func (mr *MockListRepositoryMockRecorder) DeleteFilmList(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmList", reflect.TypeOf((*MockListRepository)(nil).DeleteFilmList), ctx, userID, id)
+}
+
+// DeleteWatched mocks base method.
+func (m *MockListRepository) DeleteWatched(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWatched", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWatched indicates an expected call of DeleteWatched.
+func (mr *MockListRepositoryMockRecorder) DeleteWatched(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatched", reflect.TypeOf((*MockListRepository)(nil).DeleteWatched), ctx, userID, id)
+}
+
+// FindFilmListByID mocks base method.
+func (m *MockListRepository) FindFilmListByID(ctx context.Context, userID, id int) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFilmListByID", ctx, userID, id)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFilmListByID indicates an expected call of FindFilmListByID.
+func (mr *MockListRepositoryMockRecorder) FindFilmListByID(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFilmListByID", reflect.TypeOf((*MockListRepository)(nil).FindFilmListByID), ctx, userID, id)
+}
+
+// FindSharedFilmList mocks base method.
+func (m *MockListRepository) FindSharedFilmList(ctx context.Context, token string) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSharedFilmList", ctx, token)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSharedFilmList indicates an expected call of FindSharedFilmList.
+func (mr *MockListRepositoryMockRecorder) FindSharedFilmList(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSharedFilmList", reflect.TypeOf((*MockListRepository)(nil).FindSharedFilmList), ctx, token)
+}
+
+// GetFilmLists mocks base method.
+func (m *MockListRepository) GetFilmLists(ctx context.Context, userID int) ([]*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmLists", ctx, userID)
+	ret0, _ := ret[0].([]*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmLists indicates an expected call of GetFilmLists.
+func (mr *MockListRepositoryMockRecorder) GetFilmLists(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmLists", reflect.TypeOf((*MockListRepository)(nil).GetFilmLists), ctx, userID)
+}
+
+// GetWatched mocks base method.
+func (m *MockListRepository) GetWatched(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.WatchEntry, *domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatched", ctx, userID, page)
+	ret0, _ := ret[0].([]*domain.WatchEntry)
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWatched indicates an expected call of GetWatched.
+func (mr *MockListRepositoryMockRecorder) GetWatched(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatched", reflect.TypeOf((*MockListRepository)(nil).GetWatched), ctx, userID, page)
+}
+
+// GetWatchlist mocks base method.
+func (m *MockListRepository) GetWatchlist(ctx context.Context, userID int) ([]*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlist", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlist indicates an expected call of GetWatchlist.
+func (mr *MockListRepositoryMockRecorder) GetWatchlist(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlist", reflect.TypeOf((*MockListRepository)(nil).GetWatchlist), ctx, userID)
+}
+
+// RemoveFilmListItem mocks base method.
+func (m *MockListRepository) RemoveFilmListItem(ctx context.Context, userID, listID, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmListItem", ctx, userID, listID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFilmListItem indicates an expected call of RemoveFilmListItem.
+func (mr *MockListRepositoryMockRecorder) RemoveFilmListItem(ctx, userID, listID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmListItem", reflect.TypeOf((*MockListRepository)(nil).RemoveFilmListItem), ctx, userID, listID, filmID)
+}
+
+// RemoveFromWatchlist mocks base method.
+func (m *MockListRepository) RemoveFromWatchlist(ctx context.Context, userID, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWatchlist", ctx, userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromWatchlist indicates an expected call of RemoveFromWatchlist.
+func (mr *MockListRepositoryMockRecorder) RemoveFromWatchlist(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWatchlist", reflect.TypeOf((*MockListRepository)(nil).RemoveFromWatchlist), ctx, userID, filmID)
+}
+
+// SetFilmListShareToken mocks base method.
+func (m *MockListRepository) SetFilmListShareToken(ctx context.Context, userID, id int, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFilmListShareToken", ctx, userID, id, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFilmListShareToken indicates an expected call of SetFilmListShareToken.
+func (mr *MockListRepositoryMockRecorder) SetFilmListShareToken(ctx, userID, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFilmListShareToken", reflect.TypeOf((*MockListRepository)(nil).SetFilmListShareToken), ctx, userID, id, token)
+}
+
+// UpdateFilmList mocks base method.
+func (m *MockListRepository) UpdateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilmList", ctx, list)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFilmList indicates an expected call of UpdateFilmList.
+func (mr *MockListRepositoryMockRecorder) UpdateFilmList(ctx, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmList", reflect.TypeOf((*MockListRepository)(nil).UpdateFilmList), ctx, list)
+}
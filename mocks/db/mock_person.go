@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/person.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Max425/film-library.git/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersonRepository is a mock of PersonRepository interface.
+type MockPersonRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonRepositoryMockRecorder
+}
+
+// MockPersonRepositoryMockRecorder is the mock recorder for MockPersonRepository.
+type MockPersonRepositoryMockRecorder struct {
+	mock *MockPersonRepository
+}
+
+// NewMockPersonRepository creates a new mock instance.
+func NewMockPersonRepository(ctrl *gomock.Controller) *MockPersonRepository {
+	mock := &MockPersonRepository{ctrl: ctrl}
+	mock.recorder = &MockPersonRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonRepository) EXPECT() *MockPersonRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePerson mocks base method.
+func (m *MockPersonRepository) CreatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePerson", ctx, person)
+	ret0, _ := ret[0].(*domain.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePerson indicates an expected call of CreatePerson.
+func (mr *MockPersonRepositoryMockRecorder) CreatePerson(ctx, person interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePerson", reflect.TypeOf((*MockPersonRepository)(nil).CreatePerson), ctx, person)
+}
+
+// DeletePerson mocks base method.
+func (m *MockPersonRepository) DeletePerson(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePerson", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePerson indicates an expected call of DeletePerson.
+func (mr *MockPersonRepositoryMockRecorder) DeletePerson(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePerson", reflect.TypeOf((*MockPersonRepository)(nil).DeletePerson), ctx, id)
+}
+
+// FindPersonByID mocks base method.
+func (m *MockPersonRepository) FindPersonByID(ctx context.Context, id int) (*domain.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPersonByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPersonByID indicates an expected call of FindPersonByID.
+func (mr *MockPersonRepositoryMockRecorder) FindPersonByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPersonByID", reflect.TypeOf((*MockPersonRepository)(nil).FindPersonByID), ctx, id)
+}
+
+// GetAllPersons mocks base method.
+func (m *MockPersonRepository) GetAllPersons(ctx context.Context, filter domain.PersonFilter, page domain.PageRequest) ([]*domain.Person, *domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPersons", ctx, filter, page)
+	ret0, _ := ret[0].([]*domain.Person)
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllPersons indicates an expected call of GetAllPersons.
+func (mr *MockPersonRepositoryMockRecorder) GetAllPersons(ctx, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPersons", reflect.TypeOf((*MockPersonRepository)(nil).GetAllPersons), ctx, filter, page)
+}
+
+// UpdatePerson mocks base method.
+func (m *MockPersonRepository) UpdatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePerson", ctx, person)
+	ret0, _ := ret[0].(*domain.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePerson indicates an expected call of UpdatePerson.
+func (mr *MockPersonRepositoryMockRecorder) UpdatePerson(ctx, person interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePerson", reflect.TypeOf((*MockPersonRepository)(nil).UpdatePerson), ctx, person)
+}
+
+// MockCrewRepository is a mock of CrewRepository interface.
+type MockCrewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCrewRepositoryMockRecorder
+}
+
+// MockCrewRepositoryMockRecorder is the mock recorder for MockCrewRepository.
+type MockCrewRepositoryMockRecorder struct {
+	mock *MockCrewRepository
+}
+
+// NewMockCrewRepository creates a new mock instance.
+func NewMockCrewRepository(ctrl *gomock.Controller) *MockCrewRepository {
+	mock := &MockCrewRepository{ctrl: ctrl}
+	mock.recorder = &MockCrewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCrewRepository) EXPECT() *MockCrewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddFilmCrew mocks base method.
+func (m *MockCrewRepository) AddFilmCrew(ctx context.Context, credit domain.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmCrew", ctx, credit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilmCrew indicates an expected call of AddFilmCrew.
+func (mr *MockCrewRepositoryMockRecorder) AddFilmCrew(ctx, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmCrew", reflect.TypeOf((*MockCrewRepository)(nil).AddFilmCrew), ctx, credit)
+}
+
+// RemoveFilmCrew mocks base method.
+func (m *MockCrewRepository) RemoveFilmCrew(ctx context.Context, credit domain.Credit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmCrew", ctx, credit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFilmCrew indicates an expected call of RemoveFilmCrew.
+func (mr *MockCrewRepositoryMockRecorder) RemoveFilmCrew(ctx, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmCrew", reflect.TypeOf((*MockCrewRepository)(nil).RemoveFilmCrew), ctx, credit)
+}
@@ -35,6 +35,21 @@ func (m *MockActorService) EXPECT() *MockActorServiceMockRecorder {
 	return m.recorder
 }
 
+// AddActorFilm mocks base method.
+func (m *MockActorService) AddActorFilm(ctx context.Context, casting domain.Casting) (*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddActorFilm", ctx, casting)
+	ret0, _ := ret[0].(*domain.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddActorFilm indicates an expected call of AddActorFilm.
+func (mr *MockActorServiceMockRecorder) AddActorFilm(ctx, casting interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActorFilm", reflect.TypeOf((*MockActorService)(nil).AddActorFilm), ctx, casting)
+}
+
 // CreateActor mocks base method.
 func (m *MockActorService) CreateActor(ctx context.Context, actor *domain.Actor) (*domain.Actor, error) {
 	m.ctrl.T.Helper()
@@ -80,18 +95,34 @@ func (mr *MockActorServiceMockRecorder) GetActorByID(ctx, id interface{}) *gomoc
 }
 
 // GetAllActors mocks base method.
-func (m *MockActorService) GetAllActors(ctx context.Context) ([]*domain.Actor, error) {
+func (m *MockActorService) GetAllActors(ctx context.Context, sort []domain.ActorSort, filter domain.ActorFilter, page domain.PageRequest) ([]*domain.Actor, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllActors", ctx)
+	ret := m.ctrl.Call(m, "GetAllActors", ctx, sort, filter, page)
 	ret0, _ := ret[0].([]*domain.Actor)
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllActors indicates an expected call of GetAllActors.
+func (mr *MockActorServiceMockRecorder) GetAllActors(ctx, sort, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActors", reflect.TypeOf((*MockActorService)(nil).GetAllActors), ctx, sort, filter, page)
+}
+
+// RemoveActorFilm mocks base method.
+func (m *MockActorService) RemoveActorFilm(ctx context.Context, actorID, filmID int) (*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveActorFilm", ctx, actorID, filmID)
+	ret0, _ := ret[0].(*domain.Actor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllActors indicates an expected call of GetAllActors.
-func (mr *MockActorServiceMockRecorder) GetAllActors(ctx interface{}) *gomock.Call {
+// RemoveActorFilm indicates an expected call of RemoveActorFilm.
+func (mr *MockActorServiceMockRecorder) RemoveActorFilm(ctx, actorID, filmID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllActors", reflect.TypeOf((*MockActorService)(nil).GetAllActors), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveActorFilm", reflect.TypeOf((*MockActorService)(nil).RemoveActorFilm), ctx, actorID, filmID)
 }
 
 // UpdateActor mocks base method.
@@ -35,6 +35,20 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockAuthService) ChangePassword(ctx context.Context, userID int, oldPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, oldPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthServiceMockRecorder) ChangePassword(ctx, userID, oldPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthService)(nil).ChangePassword), ctx, userID, oldPassword, newPassword)
+}
+
 // CreateUser mocks base method.
 func (m *MockAuthService) CreateUser(ctx context.Context, user *domain.User) (int, error) {
 	m.ctrl.T.Helper()
@@ -65,25 +79,25 @@ func (mr *MockAuthServiceMockRecorder) DeleteCookie(ctx, session interface{}) *g
 }
 
 // GenerateCookie mocks base method.
-func (m *MockAuthService) GenerateCookie(ctx context.Context, role int) (string, error) {
+func (m *MockAuthService) GenerateCookie(ctx context.Context, session *domain.Session) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateCookie", ctx, role)
+	ret := m.ctrl.Call(m, "GenerateCookie", ctx, session)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateCookie indicates an expected call of GenerateCookie.
-func (mr *MockAuthServiceMockRecorder) GenerateCookie(ctx, role interface{}) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) GenerateCookie(ctx, session interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCookie", reflect.TypeOf((*MockAuthService)(nil).GenerateCookie), ctx, role)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCookie", reflect.TypeOf((*MockAuthService)(nil).GenerateCookie), ctx, session)
 }
 
 // GetSessionValue mocks base method.
-func (m *MockAuthService) GetSessionValue(ctx context.Context, session string) (int, error) {
+func (m *MockAuthService) GetSessionValue(ctx context.Context, session string) (*domain.Session, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSessionValue", ctx, session)
-	ret0, _ := ret[0].(int)
+	ret0, _ := ret[0].(*domain.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -94,17 +108,218 @@ func (mr *MockAuthServiceMockRecorder) GetSessionValue(ctx, session interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionValue", reflect.TypeOf((*MockAuthService)(nil).GetSessionValue), ctx, session)
 }
 
-// GetUser mocks base method.
-func (m *MockAuthService) GetUser(ctx context.Context, mail, password string) (*domain.User, error) {
+// GetSessions mocks base method.
+func (m *MockAuthService) GetSessions(ctx context.Context, userID int) ([]*domain.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, mail, password)
+	ret := m.ctrl.Call(m, "GetSessions", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessions indicates an expected call of GetSessions.
+func (mr *MockAuthServiceMockRecorder) GetSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessions", reflect.TypeOf((*MockAuthService)(nil).GetSessions), ctx, userID)
+}
+
+// GetUserByID mocks base method.
+func (m *MockAuthService) GetUserByID(ctx context.Context, id int) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
 	ret0, _ := ret[0].(*domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockAuthServiceMockRecorder) GetUser(ctx, mail, password interface{}) *gomock.Call {
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockAuthServiceMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockAuthService)(nil).GetUserByID), ctx, id)
+}
+
+// IssueTokens mocks base method.
+func (m *MockAuthService) IssueTokens(ctx context.Context, session *domain.Session) (*domain.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueTokens", ctx, session)
+	ret0, _ := ret[0].(*domain.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueTokens indicates an expected call of IssueTokens.
+func (mr *MockAuthServiceMockRecorder) IssueTokens(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueTokens", reflect.TypeOf((*MockAuthService)(nil).IssueTokens), ctx, session)
+}
+
+// MailVerificationRequired mocks base method.
+func (m *MockAuthService) MailVerificationRequired() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MailVerificationRequired")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// MailVerificationRequired indicates an expected call of MailVerificationRequired.
+func (mr *MockAuthServiceMockRecorder) MailVerificationRequired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MailVerificationRequired", reflect.TypeOf((*MockAuthService)(nil).MailVerificationRequired))
+}
+
+// ParseAccessToken mocks base method.
+func (m *MockAuthService) ParseAccessToken(ctx context.Context, token string) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseAccessToken", ctx, token)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseAccessToken indicates an expected call of ParseAccessToken.
+func (mr *MockAuthServiceMockRecorder) ParseAccessToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseAccessToken", reflect.TypeOf((*MockAuthService)(nil).ParseAccessToken), ctx, token)
+}
+
+// RefreshTokens mocks base method.
+func (m *MockAuthService) RefreshTokens(ctx context.Context, refreshToken, userAgent, ip string) (*domain.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshTokens", ctx, refreshToken, userAgent, ip)
+	ret0, _ := ret[0].(*domain.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshTokens indicates an expected call of RefreshTokens.
+func (mr *MockAuthServiceMockRecorder) RefreshTokens(ctx, refreshToken, userAgent, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockAuthService)(nil).RefreshTokens), ctx, refreshToken, userAgent, ip)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockAuthService) RequestPasswordReset(ctx context.Context, mail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, mail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthServiceMockRecorder) RequestPasswordReset(ctx, mail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthService)(nil).RequestPasswordReset), ctx, mail)
+}
+
+// RequestVerification mocks base method.
+func (m *MockAuthService) RequestVerification(ctx context.Context, mail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestVerification", ctx, mail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestVerification indicates an expected call of RequestVerification.
+func (mr *MockAuthServiceMockRecorder) RequestVerification(ctx, mail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestVerification", reflect.TypeOf((*MockAuthService)(nil).RequestVerification), ctx, mail)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthServiceMockRecorder) ResetPassword(ctx, token, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthService)(nil).ResetPassword), ctx, token, newPassword)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *MockAuthService) RevokeRefreshToken(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockAuthServiceMockRecorder) RevokeRefreshToken(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockAuthService)(nil).RevokeRefreshToken), ctx, refreshToken)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthService) RevokeSession(ctx context.Context, userID int, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthServiceMockRecorder) RevokeSession(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthService)(nil).RevokeSession), ctx, userID, id)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAuthService) RevokeSessions(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAuthServiceMockRecorder) RevokeSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAuthService)(nil).RevokeSessions), ctx, userID)
+}
+
+// SignIn mocks base method.
+func (m *MockAuthService) SignIn(ctx context.Context, mail, password, ip string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIn", ctx, mail, password, ip)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIn indicates an expected call of SignIn.
+func (mr *MockAuthServiceMockRecorder) SignIn(ctx, mail, password, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockAuthService)(nil).SignIn), ctx, mail, password, ip)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAuthService) UnlockUser(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAuthServiceMockRecorder) UnlockUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAuthService)(nil).UnlockUser), ctx, userID)
+}
+
+// VerifyMail mocks base method.
+func (m *MockAuthService) VerifyMail(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMail", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyMail indicates an expected call of VerifyMail.
+func (mr *MockAuthServiceMockRecorder) VerifyMail(ctx, token interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockAuthService)(nil).GetUser), ctx, mail, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMail", reflect.TypeOf((*MockAuthService)(nil).VerifyMail), ctx, token)
 }
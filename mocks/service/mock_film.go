@@ -35,6 +35,65 @@ func (m *MockFilmService) EXPECT() *MockFilmServiceMockRecorder {
 	return m.recorder
 }
 
+// AddFilmActor mocks base method.
+func (m *MockFilmService) AddFilmActor(ctx context.Context, casting domain.Casting) (*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmActor", ctx, casting)
+	ret0, _ := ret[0].(*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFilmActor indicates an expected call of AddFilmActor.
+func (mr *MockFilmServiceMockRecorder) AddFilmActor(ctx, casting interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmActor", reflect.TypeOf((*MockFilmService)(nil).AddFilmActor), ctx, casting)
+}
+
+// AddFilmCrew mocks base method.
+func (m *MockFilmService) AddFilmCrew(ctx context.Context, credit domain.Credit) (*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmCrew", ctx, credit)
+	ret0, _ := ret[0].(*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFilmCrew indicates an expected call of AddFilmCrew.
+func (mr *MockFilmServiceMockRecorder) AddFilmCrew(ctx, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmCrew", reflect.TypeOf((*MockFilmService)(nil).AddFilmCrew), ctx, credit)
+}
+
+// AddFilmGenre mocks base method.
+func (m *MockFilmService) AddFilmGenre(ctx context.Context, filmID, genreID int) (*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmGenre", ctx, filmID, genreID)
+	ret0, _ := ret[0].(*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFilmGenre indicates an expected call of AddFilmGenre.
+func (mr *MockFilmServiceMockRecorder) AddFilmGenre(ctx, filmID, genreID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmGenre", reflect.TypeOf((*MockFilmService)(nil).AddFilmGenre), ctx, filmID, genreID)
+}
+
+// AddFilmUserStates mocks base method.
+func (m *MockFilmService) AddFilmUserStates(ctx context.Context, userID int, films []*domain.Film) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmUserStates", ctx, userID, films)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilmUserStates indicates an expected call of AddFilmUserStates.
+func (mr *MockFilmServiceMockRecorder) AddFilmUserStates(ctx, userID, films interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmUserStates", reflect.TypeOf((*MockFilmService)(nil).AddFilmUserStates), ctx, userID, films)
+}
+
 // CreateFilm mocks base method.
 func (m *MockFilmService) CreateFilm(ctx context.Context, film *domain.Film) (*domain.Film, error) {
 	m.ctrl.T.Helper()
@@ -65,18 +124,19 @@ func (mr *MockFilmServiceMockRecorder) DeleteFilm(ctx, id interface{}) *gomock.C
 }
 
 // GetAllFilms mocks base method.
-func (m *MockFilmService) GetAllFilms(ctx context.Context, sortBy, order string) ([]*domain.Film, error) {
+func (m *MockFilmService) GetAllFilms(ctx context.Context, sort []domain.FilmSort, filter domain.FilmFilter, page domain.PageRequest) ([]*domain.Film, *domain.PageInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllFilms", ctx, sortBy, order)
+	ret := m.ctrl.Call(m, "GetAllFilms", ctx, sort, filter, page)
 	ret0, _ := ret[0].([]*domain.Film)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllFilms indicates an expected call of GetAllFilms.
-func (mr *MockFilmServiceMockRecorder) GetAllFilms(ctx, sortBy, order interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) GetAllFilms(ctx, sort, filter, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllFilms", reflect.TypeOf((*MockFilmService)(nil).GetAllFilms), ctx, sortBy, order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllFilms", reflect.TypeOf((*MockFilmService)(nil).GetAllFilms), ctx, sort, filter, page)
 }
 
 // GetFilmByID mocks base method.
@@ -94,19 +154,79 @@ func (mr *MockFilmServiceMockRecorder) GetFilmByID(ctx, id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmByID", reflect.TypeOf((*MockFilmService)(nil).GetFilmByID), ctx, id)
 }
 
+// GetFilmGenreFacets mocks base method.
+func (m *MockFilmService) GetFilmGenreFacets(ctx context.Context, filter domain.FilmFilter) ([]*domain.GenreCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmGenreFacets", ctx, filter)
+	ret0, _ := ret[0].([]*domain.GenreCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmGenreFacets indicates an expected call of GetFilmGenreFacets.
+func (mr *MockFilmServiceMockRecorder) GetFilmGenreFacets(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmGenreFacets", reflect.TypeOf((*MockFilmService)(nil).GetFilmGenreFacets), ctx, filter)
+}
+
+// RemoveFilmActor mocks base method.
+func (m *MockFilmService) RemoveFilmActor(ctx context.Context, filmID, actorID int) (*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmActor", ctx, filmID, actorID)
+	ret0, _ := ret[0].(*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFilmActor indicates an expected call of RemoveFilmActor.
+func (mr *MockFilmServiceMockRecorder) RemoveFilmActor(ctx, filmID, actorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmActor", reflect.TypeOf((*MockFilmService)(nil).RemoveFilmActor), ctx, filmID, actorID)
+}
+
+// RemoveFilmCrew mocks base method.
+func (m *MockFilmService) RemoveFilmCrew(ctx context.Context, credit domain.Credit) (*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmCrew", ctx, credit)
+	ret0, _ := ret[0].(*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFilmCrew indicates an expected call of RemoveFilmCrew.
+func (mr *MockFilmServiceMockRecorder) RemoveFilmCrew(ctx, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmCrew", reflect.TypeOf((*MockFilmService)(nil).RemoveFilmCrew), ctx, credit)
+}
+
+// RemoveFilmGenre mocks base method.
+func (m *MockFilmService) RemoveFilmGenre(ctx context.Context, filmID, genreID int) (*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmGenre", ctx, filmID, genreID)
+	ret0, _ := ret[0].(*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFilmGenre indicates an expected call of RemoveFilmGenre.
+func (mr *MockFilmServiceMockRecorder) RemoveFilmGenre(ctx, filmID, genreID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmGenre", reflect.TypeOf((*MockFilmService)(nil).RemoveFilmGenre), ctx, filmID, genreID)
+}
+
 // SearchFilms mocks base method.
-func (m *MockFilmService) SearchFilms(ctx context.Context, fragment string) ([]*domain.Film, error) {
+func (m *MockFilmService) SearchFilms(ctx context.Context, query string, similarity float64, page domain.PageRequest) (*domain.FilmSearchResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchFilms", ctx, fragment)
-	ret0, _ := ret[0].([]*domain.Film)
+	ret := m.ctrl.Call(m, "SearchFilms", ctx, query, similarity, page)
+	ret0, _ := ret[0].(*domain.FilmSearchResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchFilms indicates an expected call of SearchFilms.
-func (mr *MockFilmServiceMockRecorder) SearchFilms(ctx, fragment interface{}) *gomock.Call {
+func (mr *MockFilmServiceMockRecorder) SearchFilms(ctx, query, similarity, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFilms", reflect.TypeOf((*MockFilmService)(nil).SearchFilms), ctx, fragment)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFilms", reflect.TypeOf((*MockFilmService)(nil).SearchFilms), ctx, query, similarity, page)
 }
 
 // UpdateFilm mocks base method.
@@ -138,3 +258,18 @@ func (mr *MockFilmServiceMockRecorder) UpdateFilmActors(ctx, id, actorsId interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmActors", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmActors), ctx, id, actorsId)
 }
+
+// UpdateFilmGenres mocks base method.
+func (m *MockFilmService) UpdateFilmGenres(ctx context.Context, id int, genreIDs []int) (*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilmGenres", ctx, id, genreIDs)
+	ret0, _ := ret[0].(*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFilmGenres indicates an expected call of UpdateFilmGenres.
+func (mr *MockFilmServiceMockRecorder) UpdateFilmGenres(ctx, id, genreIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmGenres", reflect.TypeOf((*MockFilmService)(nil).UpdateFilmGenres), ctx, id, genreIDs)
+}
@@ -0,0 +1,274 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handler/list.go
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Max425/film-library.git/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockListService is a mock of ListService interface.
+type MockListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockListServiceMockRecorder
+}
+
+// MockListServiceMockRecorder is the mock recorder for MockListService.
+type MockListServiceMockRecorder struct {
+	mock *MockListService
+}
+
+// NewMockListService creates a new mock instance.
+func NewMockListService(ctrl *gomock.Controller) *MockListService {
+	mock := &MockListService{ctrl: ctrl}
+	mock.recorder = &MockListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListService) EXPECT() *MockListServiceMockRecorder {
+	return m.recorder
+}
+
+// AddFilmListItem mocks base method.
+func (m *MockListService) AddFilmListItem(ctx context.Context, userID, listID, filmID int) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmListItem", ctx, userID, listID, filmID)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFilmListItem indicates an expected call of AddFilmListItem.
+func (mr *MockListServiceMockRecorder) AddFilmListItem(ctx, userID, listID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmListItem", reflect.TypeOf((*MockListService)(nil).AddFilmListItem), ctx, userID, listID, filmID)
+}
+
+// AddToWatchlist mocks base method.
+func (m *MockListService) AddToWatchlist(ctx context.Context, userID, filmID int) ([]*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWatchlist", ctx, userID, filmID)
+	ret0, _ := ret[0].([]*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToWatchlist indicates an expected call of AddToWatchlist.
+func (mr *MockListServiceMockRecorder) AddToWatchlist(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWatchlist", reflect.TypeOf((*MockListService)(nil).AddToWatchlist), ctx, userID, filmID)
+}
+
+// AddWatched mocks base method.
+func (m *MockListService) AddWatched(ctx context.Context, entry domain.WatchEntry) (*domain.WatchEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWatched", ctx, entry)
+	ret0, _ := ret[0].(*domain.WatchEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWatched indicates an expected call of AddWatched.
+func (mr *MockListServiceMockRecorder) AddWatched(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWatched", reflect.TypeOf((*MockListService)(nil).AddWatched), ctx, entry)
+}
+
+// CreateFilmList mocks base method.
+func (m *MockListService) CreateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilmList", ctx, list)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFilmList indicates an expected call of CreateFilmList.
+func (mr *MockListServiceMockRecorder) CreateFilmList(ctx, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilmList", reflect.TypeOf((*MockListService)(nil).CreateFilmList), ctx, list)
+}
+
+// DeleteFilmList mocks base method.
+func (m *MockListService) DeleteFilmList(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmList", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilmList indicates an expected call of DeleteFilmList.
+func (mr *MockListServiceMockRecorder) DeleteFilmList(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmList", reflect.TypeOf((*MockListService)(nil).DeleteFilmList), ctx, userID, id)
+}
+
+// DeleteWatched mocks base method.
+func (m *MockListService) DeleteWatched(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWatched", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWatched indicates an expected call of DeleteWatched.
+func (mr *MockListServiceMockRecorder) DeleteWatched(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatched", reflect.TypeOf((*MockListService)(nil).DeleteWatched), ctx, userID, id)
+}
+
+// GetFilmList mocks base method.
+func (m *MockListService) GetFilmList(ctx context.Context, userID, id int) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmList", ctx, userID, id)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmList indicates an expected call of GetFilmList.
+func (mr *MockListServiceMockRecorder) GetFilmList(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmList", reflect.TypeOf((*MockListService)(nil).GetFilmList), ctx, userID, id)
+}
+
+// GetFilmLists mocks base method.
+func (m *MockListService) GetFilmLists(ctx context.Context, userID int) ([]*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmLists", ctx, userID)
+	ret0, _ := ret[0].([]*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmLists indicates an expected call of GetFilmLists.
+func (mr *MockListServiceMockRecorder) GetFilmLists(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmLists", reflect.TypeOf((*MockListService)(nil).GetFilmLists), ctx, userID)
+}
+
+// GetSharedFilmList mocks base method.
+func (m *MockListService) GetSharedFilmList(ctx context.Context, token string) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedFilmList", ctx, token)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedFilmList indicates an expected call of GetSharedFilmList.
+func (mr *MockListServiceMockRecorder) GetSharedFilmList(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedFilmList", reflect.TypeOf((*MockListService)(nil).GetSharedFilmList), ctx, token)
+}
+
+// GetWatched mocks base method.
+func (m *MockListService) GetWatched(ctx context.Context, userID int, page domain.PageRequest) ([]*domain.WatchEntry, *domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatched", ctx, userID, page)
+	ret0, _ := ret[0].([]*domain.WatchEntry)
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWatched indicates an expected call of GetWatched.
+func (mr *MockListServiceMockRecorder) GetWatched(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatched", reflect.TypeOf((*MockListService)(nil).GetWatched), ctx, userID, page)
+}
+
+// GetWatchlist mocks base method.
+func (m *MockListService) GetWatchlist(ctx context.Context, userID int) ([]*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatchlist", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWatchlist indicates an expected call of GetWatchlist.
+func (mr *MockListServiceMockRecorder) GetWatchlist(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatchlist", reflect.TypeOf((*MockListService)(nil).GetWatchlist), ctx, userID)
+}
+
+// RemoveFilmListItem mocks base method.
+func (m *MockListService) RemoveFilmListItem(ctx context.Context, userID, listID, filmID int) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFilmListItem", ctx, userID, listID, filmID)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFilmListItem indicates an expected call of RemoveFilmListItem.
+func (mr *MockListServiceMockRecorder) RemoveFilmListItem(ctx, userID, listID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFilmListItem", reflect.TypeOf((*MockListService)(nil).RemoveFilmListItem), ctx, userID, listID, filmID)
+}
+
+// RemoveFromWatchlist mocks base method.
+func (m *MockListService) RemoveFromWatchlist(ctx context.Context, userID, filmID int) ([]*domain.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWatchlist", ctx, userID, filmID)
+	ret0, _ := ret[0].([]*domain.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFromWatchlist indicates an expected call of RemoveFromWatchlist.
+func (mr *MockListServiceMockRecorder) RemoveFromWatchlist(ctx, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWatchlist", reflect.TypeOf((*MockListService)(nil).RemoveFromWatchlist), ctx, userID, filmID)
+}
+
+// ShareFilmList mocks base method.
+func (m *MockListService) ShareFilmList(ctx context.Context, userID, id int) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareFilmList", ctx, userID, id)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareFilmList indicates an expected call of ShareFilmList.
+func (mr *MockListServiceMockRecorder) ShareFilmList(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareFilmList", reflect.TypeOf((*MockListService)(nil).ShareFilmList), ctx, userID, id)
+}
+
+// UnshareFilmList mocks base method.
+func (m *MockListService) UnshareFilmList(ctx context.Context, userID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareFilmList", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnshareFilmList indicates an expected call of UnshareFilmList.
+func (mr *MockListServiceMockRecorder) UnshareFilmList(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareFilmList", reflect.TypeOf((*MockListService)(nil).UnshareFilmList), ctx, userID, id)
+}
+
+// UpdateFilmList mocks base method.
+func (m *MockListService) UpdateFilmList(ctx context.Context, list *domain.FilmList) (*domain.FilmList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilmList", ctx, list)
+	ret0, _ := ret[0].(*domain.FilmList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFilmList indicates an expected call of UpdateFilmList.
+func (mr *MockListServiceMockRecorder) UpdateFilmList(ctx, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilmList", reflect.TypeOf((*MockListService)(nil).UpdateFilmList), ctx, list)
+}
@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handler/person.go
+
+// Package mock_handler is a generated GoMock package.
+package mock_handler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/Max425/film-library.git/internal/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersonService is a mock of PersonService interface.
+type MockPersonService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonServiceMockRecorder
+}
+
+// MockPersonServiceMockRecorder is the mock recorder for MockPersonService.
+type MockPersonServiceMockRecorder struct {
+	mock *MockPersonService
+}
+
+// NewMockPersonService creates a new mock instance.
+func NewMockPersonService(ctrl *gomock.Controller) *MockPersonService {
+	mock := &MockPersonService{ctrl: ctrl}
+	mock.recorder = &MockPersonServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonService) EXPECT() *MockPersonServiceMockRecorder {
+	return m.recorder
+}
+
+// AddPersonFilm mocks base method.
+func (m *MockPersonService) AddPersonFilm(ctx context.Context, credit domain.Credit) (*domain.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPersonFilm", ctx, credit)
+	ret0, _ := ret[0].(*domain.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPersonFilm indicates an expected call of AddPersonFilm.
+func (mr *MockPersonServiceMockRecorder) AddPersonFilm(ctx, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPersonFilm", reflect.TypeOf((*MockPersonService)(nil).AddPersonFilm), ctx, credit)
+}
+
+// CreatePerson mocks base method.
+func (m *MockPersonService) CreatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePerson", ctx, person)
+	ret0, _ := ret[0].(*domain.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePerson indicates an expected call of CreatePerson.
+func (mr *MockPersonServiceMockRecorder) CreatePerson(ctx, person interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePerson", reflect.TypeOf((*MockPersonService)(nil).CreatePerson), ctx, person)
+}
+
+// DeletePerson mocks base method.
+func (m *MockPersonService) DeletePerson(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePerson", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePerson indicates an expected call of DeletePerson.
+func (mr *MockPersonServiceMockRecorder) DeletePerson(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePerson", reflect.TypeOf((*MockPersonService)(nil).DeletePerson), ctx, id)
+}
+
+// GetAllPersons mocks base method.
+func (m *MockPersonService) GetAllPersons(ctx context.Context, filter domain.PersonFilter, page domain.PageRequest) ([]*domain.Person, *domain.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPersons", ctx, filter, page)
+	ret0, _ := ret[0].([]*domain.Person)
+	ret1, _ := ret[1].(*domain.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllPersons indicates an expected call of GetAllPersons.
+func (mr *MockPersonServiceMockRecorder) GetAllPersons(ctx, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPersons", reflect.TypeOf((*MockPersonService)(nil).GetAllPersons), ctx, filter, page)
+}
+
+// GetPersonByID mocks base method.
+func (m *MockPersonService) GetPersonByID(ctx context.Context, id int) (*domain.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersonByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersonByID indicates an expected call of GetPersonByID.
+func (mr *MockPersonServiceMockRecorder) GetPersonByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersonByID", reflect.TypeOf((*MockPersonService)(nil).GetPersonByID), ctx, id)
+}
+
+// RemovePersonFilm mocks base method.
+func (m *MockPersonService) RemovePersonFilm(ctx context.Context, credit domain.Credit) (*domain.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePersonFilm", ctx, credit)
+	ret0, _ := ret[0].(*domain.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePersonFilm indicates an expected call of RemovePersonFilm.
+func (mr *MockPersonServiceMockRecorder) RemovePersonFilm(ctx, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePersonFilm", reflect.TypeOf((*MockPersonService)(nil).RemovePersonFilm), ctx, credit)
+}
+
+// UpdatePerson mocks base method.
+func (m *MockPersonService) UpdatePerson(ctx context.Context, person *domain.Person) (*domain.Person, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePerson", ctx, person)
+	ret0, _ := ret[0].(*domain.Person)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePerson indicates an expected call of UpdatePerson.
+func (mr *MockPersonServiceMockRecorder) UpdatePerson(ctx, person interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePerson", reflect.TypeOf((*MockPersonService)(nil).UpdatePerson), ctx, person)
+}